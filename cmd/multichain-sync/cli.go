@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
-
+	"strconv"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -18,7 +18,10 @@ import (
 	"github.com/dapplink-labs/multichain-sync-btc/common/opio"
 	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/database/dynamic"
 	flags2 "github.com/dapplink-labs/multichain-sync-btc/flags"
+	"github.com/dapplink-labs/multichain-sync-btc/health"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/notifier"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
@@ -38,7 +41,12 @@ func runMultichainSync(ctx *cli.Context, shutdown context.CancelCauseFunc) (clia
 		log.Error("failed to load config", "err", err)
 		return nil, err
 	}
-	return multichain_transaction_syncs.NewMultiChainSync(ctx.Context, &cfg, shutdown)
+	multiChainSync, err := multichain_transaction_syncs.NewMultiChainSync(ctx.Context, &cfg, shutdown)
+	if err != nil {
+		return nil, err
+	}
+	checker := health.NewChecker(cfg.HealthInterval, func() bool { return !multiChainSync.Stopped() }, multiChainSync.ReadinessChecks(cfg.ScannerMaxStall)...)
+	return withMonitoring(&cfg, multiChainSync, checker, cfg.MetricsServer.Port, cfg.HealthGrpcPort, shutdown), nil
 }
 
 func runRpc(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycle, error) {
@@ -48,42 +56,178 @@ func runRpc(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycl
 		log.Error("failed to load config", "err", err)
 		return nil, err
 	}
-	grpcServerCfg := &services.BusinessMiddleConfig{
-		GrpcHostname: cfg.RpcServer.Host,
-		GrpcPort:     cfg.RpcServer.Port,
-	}
-	db, err := database.NewDB(ctx.Context, cfg.MasterDB)
-	if err != nil {
-		log.Error("failed to connect to database", "err", err)
-		return nil, err
+	// 失效通知可能先于从库回放到达，从库最大延迟内查到的可能是旧数据，不写缓存
+	cacheCfg := cfg.CacheConfig
+	if cfg.SlaveDbEnable {
+		cacheCfg.StaleWindow = cfg.SlaveDbMaxLag
 	}
+	// 主链的服务监听 rpc 端口，其他链的服务挂在主链上，按请求头 x-chain 分发；主链停止时一起停止其他链并关闭所有链的数据库
+	var (
+		rpcServices *services.BusinessMiddleWireServices
+		checks      []health.Check
+	)
+	for _, chain := range cfg.Chains {
+		chainCfg := cfg.ForChain(chain)
+		grpcServerCfg := &services.BusinessMiddleConfig{
+			GrpcHostname:      cfg.RpcServer.Host,
+			GrpcPort:          cfg.RpcServer.Port,
+			Chain:             chainCfg.Chain,
+			AdminToken:        cfg.RpcAdminToken,
+			RateLimit:         cfg.RpcRateLimit,
+			RateBurst:         cfg.RpcRateBurst,
+			PeerRateLimit:     cfg.RpcPeerRateLimit,
+			PeerRateBurst:     cfg.RpcPeerRateBurst,
+			ShutdownTimeout:   cfg.RpcShutdownTimeout,
+			FinalizedConfirms: uint64(chainCfg.ChainNode.FinalizedConfirmations),
+			ScannerLag:        uint64(chainCfg.ChainNode.Confirmations),
+			ApiCacheEnable:    cfg.ApiCacheEnable && rpcServices == nil,
+			CacheConfig:       cacheCfg,
+			TenantSchemas:     cfg.Schemas(),
+			BusinessPolicies:  cfg.Businesses,
+		}
+		// 查询接口开启从库时读从库，写入和事务里的读仍走主库
+		db, err := database.NewReadWriteDB(ctx.Context, &chainCfg)
+		if err != nil {
+			log.Error("failed to connect to database", "chain", chain.Key(), "err", err)
+			return nil, err
+		}
 
-	log.Info("Chain account rpc", "rpc uri", cfg.ChainBtcRpc)
-	conn, err := grpc.NewClient(cfg.ChainBtcRpc, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Error("Connect to da retriever fail", "err", err)
-		return nil, err
-	}
-	client := utxo.NewWalletUtxoServiceClient(conn)
-	accountClient, err := syncclient.NewWalletBtcAccountClient(context.Background(), client, "Ethereum")
-	if err != nil {
-		log.Error("new wallet account client fail", "err", err)
-		return nil, err
+		log.Info("Chain account rpc", "chain", chain.Key(), "rpc uri", chainCfg.ChainBtcRpc)
+		conn, err := grpc.NewClient(chainCfg.ChainBtcRpc,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithChainUnaryInterceptor(metrics.UpstreamUnaryInterceptor(chain.Key())),
+		)
+		if err != nil {
+			log.Error("Connect to da retriever fail", "err", err)
+			return nil, err
+		}
+		client := utxo.NewWalletUtxoServiceClient(conn)
+		accountClient, err := syncclient.NewWalletBtcAccountClient(context.Background(), client, chainCfg.Chain)
+		if err != nil {
+			log.Error("new wallet account client fail", "err", err)
+			return nil, err
+		}
+		checks = append(checks, health.ForChain(chain.Key(), health.DatabaseCheck(db), health.UpstreamCheck(accountClient.ChainTip))...)
+
+		chainServices, err := services.NewBusinessMiddleWireServices(db, grpcServerCfg, accountClient, shutdown)
+		if err != nil {
+			return nil, err
+		}
+		if rpcServices == nil {
+			rpcServices = chainServices
+		} else {
+			rpcServices.AddChain(chainServices)
+		}
 	}
-	return services.NewBusinessMiddleWireServices(db, grpcServerCfg, accountClient)
+	checker := health.NewChecker(cfg.HealthInterval, func() bool { return !rpcServices.Stopped() }, checks...)
+	rpcServices.HealthServer = checker.GrpcServer()
+	return withMonitoring(&cfg, rpcServices, checker, cfg.RpcMetricsPort, 0, shutdown), nil
 }
 
+// runMigrations 主链先迁移，其他链的 schema 依赖主链的业务方表和公共类型；
+// 链是后加的时候，迁移完成后给已经注册的业务方补建这条链上的表
 func runMigrations(ctx *cli.Context) error {
 	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
 	log.Info("running migrations...")
+	return withChainDatabases(ctx, true, func(chain config.ChainConfig, db *database.DB, cfg config.Config) error {
+		applied, err := db.MigrateUp(cfg.Migrations)
+		if err != nil {
+			log.Error("apply migrations fail", "chain", chain.Key(), "err", err)
+			return err
+		}
+		log.Info("apply migrations success", "chain", chain.Key(), "applied", len(applied))
+		if chain.Schema == "" {
+			return nil
+		}
+		requestIds, err := tenantRequestIds(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, requestId := range requestIds {
+			if _, err := dynamic.SyncTenant(requestId, db); err != nil {
+				log.Error("create tenant tables fail", "chain", chain.Key(), "requestId", requestId, "err", err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+func runMigrationsDown(ctx *cli.Context) error {
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+	steps := 1
+	if ctx.Args().Present() {
+		n, err := strconv.Atoi(ctx.Args().First())
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid migration steps: %s", ctx.Args().First())
+		}
+		steps = n
+	}
+	log.Info("reverting migrations...", "steps", steps)
+	return withChainDatabases(ctx, false, func(chain config.ChainConfig, db *database.DB, cfg config.Config) error {
+		reverted, err := db.MigrateDown(cfg.Migrations, steps)
+		if err != nil {
+			log.Error("revert migrations fail", "chain", chain.Key(), "err", err)
+			return err
+		}
+		log.Info("revert migrations success", "chain", chain.Key(), "reverted", len(reverted))
+		return nil
+	})
+}
+
+func runMigrationsStatus(ctx *cli.Context) error {
+	return withChainDatabases(ctx, false, func(chain config.ChainConfig, db *database.DB, cfg config.Config) error {
+		statusList, err := db.MigrationStatus(cfg.Migrations)
+		if err != nil {
+			log.Error("query migration status fail", "chain", chain.Key(), "err", err)
+			return err
+		}
+		fmt.Printf("# %s\n", chain.Key())
+		for _, status := range statusList {
+			state := "pending"
+			if status.Applied {
+				state = "applied at " + time.Unix(int64(status.AppliedAt), 0).UTC().Format(time.RFC3339)
+			}
+			if status.Modified {
+				state += " (modified)"
+			}
+			if status.Missing {
+				state += " (missing)"
+			}
+			fmt.Printf("%05d_%s\t%s\n", status.Version, status.Name, state)
+		}
+		return nil
+	})
+}
+
+// withChainDatabases 按配置顺序连接每条链的 schema 执行 fn，cfg 是这条链的配置；createSchema 为 true 时先通过主链的连接创建其他链的 schema
+func withChainDatabases(ctx *cli.Context, createSchema bool, fn func(chain config.ChainConfig, db *database.DB, cfg config.Config) error) error {
 	cfg, err := config.LoadConfig(ctx)
 	if err != nil {
 		log.Error("failed to load config", "err", err)
 		return err
 	}
-	db, err := database.NewDB(ctx.Context, cfg.MasterDB)
+	for _, chain := range cfg.Chains {
+		chainCfg := cfg.ForChain(chain)
+		if createSchema && chain.Schema != "" {
+			if err := withDatabase(ctx.Context, cfg.MasterDB, func(db *database.DB) error {
+				return db.CreateSchema(chain.Schema)
+			}); err != nil {
+				return err
+			}
+		}
+		if err := withDatabase(ctx.Context, chainCfg.MasterDB, func(db *database.DB) error {
+			return fn(chain, db, chainCfg)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func withDatabase(ctx context.Context, dbConfig config.DBConfig, fn func(db *database.DB) error) error {
+	db, err := database.NewDB(ctx, dbConfig)
 	if err != nil {
-		log.Error("failed to connect to database", "err", err)
+		log.Error("failed to connect to database", "schema", dbConfig.Schema, "err", err)
 		return err
 	}
 	defer func(db *database.DB) {
@@ -92,7 +236,102 @@ func runMigrations(ctx *cli.Context) error {
 			log.Error("fail to close database", "err", err)
 		}
 	}(db)
-	return db.ExecuteSQLMigration(cfg.Migrations)
+	return fn(db)
+}
+
+func runTenantsCheck(ctx *cli.Context) error {
+	driftCount := 0
+	err := withChainDatabases(ctx, false, func(chain config.ChainConfig, db *database.DB, cfg config.Config) error {
+		requestIds, err := tenantRequestIds(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, requestId := range requestIds {
+			driftList, err := dynamic.CheckTenant(requestId, db)
+			if err != nil {
+				log.Error("check tenant schema fail", "chain", chain.Key(), "requestId", requestId, "err", err)
+				return err
+			}
+			printTenantDrift(chain.Key(), requestId, driftList)
+			driftCount += len(driftList)
+		}
+		log.Info("tenant schema checked", "chain", chain.Key(), "businesses", len(requestIds))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if driftCount > 0 {
+		return fmt.Errorf("tenant schema drift detected in %d tables", driftCount)
+	}
+	return nil
+}
+
+func runTenantsSync(ctx *cli.Context) error {
+	return withChainDatabases(ctx, false, func(chain config.ChainConfig, db *database.DB, cfg config.Config) error {
+		requestIds, err := tenantRequestIds(ctx, db)
+		if err != nil {
+			return err
+		}
+		for _, requestId := range requestIds {
+			driftList, err := dynamic.SyncTenant(requestId, db)
+			if err != nil {
+				log.Error("sync tenant schema fail", "chain", chain.Key(), "requestId", requestId, "err", err)
+				return err
+			}
+			printTenantDrift(chain.Key(), requestId, driftList)
+		}
+		log.Info("sync tenant schema success", "chain", chain.Key(), "businesses", len(requestIds))
+		return nil
+	})
+}
+
+// tenantRequestIds 命令行指定了业务方时只处理这些业务方，否则处理所有业务方
+func tenantRequestIds(ctx *cli.Context, db *database.DB) ([]string, error) {
+	if ctx.Args().Present() {
+		return ctx.Args().Slice(), nil
+	}
+	businessList, err := db.Business.QueryBusinessList()
+	if err != nil {
+		log.Error("query business list fail", "err", err)
+		return nil, err
+	}
+	var requestIds []string
+	for _, business := range businessList {
+		requestIds = append(requestIds, business.BusinessUid)
+	}
+	return requestIds, nil
+}
+
+func printTenantDrift(chain string, requestId string, driftList []dynamic.TableDrift) {
+	for _, drift := range driftList {
+		if drift.MissingTable {
+			fmt.Printf("%s\t%s\t%s\tmissing table\n", chain, requestId, drift.Table)
+			continue
+		}
+		for _, column := range drift.MissingColumns {
+			fmt.Printf("%s\t%s\t%s\tmissing column %s %s\n", chain, requestId, drift.Table, column.Name, column.DataType)
+		}
+		for _, index := range drift.MissingIndexes {
+			fmt.Printf("%s\t%s\t%s\tmissing index %s\n", chain, requestId, drift.Table, index.Name)
+		}
+		for _, column := range drift.ExtraColumns {
+			fmt.Printf("%s\t%s\t%s\textra column %s\n", chain, requestId, drift.Table, column)
+		}
+		for _, mismatch := range drift.TypeMismatches {
+			fmt.Printf("%s\t%s\t%s\tcolumn %s is %s, template is %s\n", chain, requestId, drift.Table, mismatch.Column, mismatch.TenantType, mismatch.TemplateType)
+		}
+		for _, mismatch := range drift.NullMismatches {
+			fmt.Printf("%s\t%s\t%s\tcolumn %s %s, template %s\n", chain, requestId, drift.Table, mismatch.Column, nullability(mismatch.TenantNotNull), nullability(mismatch.TemplateNotNull))
+		}
+	}
+}
+
+func nullability(notNull bool) string {
+	if notNull {
+		return "is not null"
+	}
+	return "is nullable"
 }
 
 func runNotify(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycle, error) {
@@ -102,12 +341,58 @@ func runNotify(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifec
 		log.Error("failed to load config", "err", err)
 		return nil, err
 	}
-	db, err := database.NewDB(ctx.Context, cfg.MasterDB)
+	var (
+		chains []notifier.ChainDB
+		checks []health.Check
+	)
+	for _, chain := range cfg.Chains {
+		chainCfg := cfg.ForChain(chain)
+		db, err := database.NewDB(ctx.Context, chainCfg.MasterDB)
+		if err != nil {
+			log.Error("failed to connect to database", "chain", chain.Key(), "err", err)
+			return nil, err
+		}
+		chains = append(chains, notifier.ChainDB{Chain: chain.Key(), DB: db})
+		checks = append(checks, health.ForChain(chain.Key(), health.DatabaseCheck(db))...)
+	}
+	notify, err := notifier.NewNotifier(chains, shutdown)
 	if err != nil {
-		log.Error("failed to connect to database", "err", err)
 		return nil, err
 	}
-	return notifier.NewNotifier(db, shutdown)
+	checker := health.NewChecker(cfg.HealthInterval, func() bool { return !notify.Stopped() }, checks...)
+	return withMonitoring(&cfg, notify, checker, cfg.NotifyMetricsPort, cfg.NotifyHealthGrpcPort, shutdown), nil
+}
+
+// withMonitoring 给常驻命令挂上指标、/healthz、/readyz 和 grpc health 服务，http 接口共用该命令的指标端口 metricsPort；
+// grpc health 服务单独监听 healthGrpcPort，rpc 命令把 health 服务注册在 rpc 端口上，传 0 不单独监听
+func withMonitoring(cfg *config.Config, lifecycle cliapp.Lifecycle, checker *health.Checker, metricsPort int, healthGrpcPort int, shutdown context.CancelCauseFunc) cliapp.Lifecycle {
+	metricsServer := metrics.NewServer(cfg.MetricsServer.Host, metricsPort, shutdown)
+	metricsServer.Handle("/healthz", checker.LivenessHandler())
+	metricsServer.Handle("/readyz", checker.ReadinessHandler())
+	auxiliary := []cliapp.Lifecycle{checker, metricsServer}
+	if healthGrpcPort != 0 {
+		auxiliary = append(auxiliary, health.NewGrpcServer(cfg.MetricsServer.Host, healthGrpcPort, checker, shutdown))
+	}
+	return cliapp.WithAuxiliary(lifecycle, auxiliary...)
+}
+
+// runConfigValidate 加载并校验配置，所有问题逐行输出，有问题时返回错误
+func runConfigValidate(ctx *cli.Context) error {
+	_, err := config.LoadConfig(ctx)
+	var problems []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		problems = joined.Unwrap()
+	} else if err != nil {
+		problems = append(problems, err)
+	}
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Println("  -", problem)
+		}
+		return fmt.Errorf("config has %d problem(s)", len(problems))
+	}
+	fmt.Println("config is valid")
+	return nil
 }
 
 func NewCli(GitCommit string, GitData string) *cli.App {
@@ -140,6 +425,59 @@ func NewCli(GitCommit string, GitData string) *cli.App {
 				Flags:       flags,
 				Description: "Run database migrations",
 				Action:      runMigrations,
+				Subcommands: []*cli.Command{
+					{
+						Name:        "up",
+						Flags:       flags,
+						Description: "Apply all pending migrations",
+						Action:      runMigrations,
+					},
+					{
+						Name:        "down",
+						Flags:       flags,
+						ArgsUsage:   "[N]",
+						Description: "Revert the last N applied migrations, default 1",
+						Action:      runMigrationsDown,
+					},
+					{
+						Name:        "status",
+						Flags:       flags,
+						Description: "Show applied and pending migrations",
+						Action:      runMigrationsStatus,
+					},
+				},
+			},
+			{
+				Name:        "tenants",
+				Description: "Check or sync per-business tables against their templates",
+				Subcommands: []*cli.Command{
+					{
+						Name:        "check",
+						Flags:       flags,
+						ArgsUsage:   "[requestId...]",
+						Description: "Report schema drift of per-business tables",
+						Action:      runTenantsCheck,
+					},
+					{
+						Name:        "sync",
+						Flags:       flags,
+						ArgsUsage:   "[requestId...]",
+						Description: "Create missing per-business tables, columns and indexes",
+						Action:      runTenantsSync,
+					},
+				},
+			},
+			{
+				Name:        "config",
+				Description: "Inspect the configuration loaded from flags, environment variables and the config file",
+				Subcommands: []*cli.Command{
+					{
+						Name:        "validate",
+						Flags:       flags,
+						Description: "Validate the configuration and report all problems at once",
+						Action:      runConfigValidate,
+					},
+				},
 			},
 			{
 				Name:        "version",
@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix 业务方 api key 的前缀，方便在日志和配置中识别
+const Prefix = "dk_"
+
+// Generate 生成新的 api key，返回明文和用于存储的哈希；明文只在签发时返回给业务方一次
+func Generate() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key := Prefix + hex.EncodeToString(buf)
+	return key, Hash(key), nil
+}
+
+// Hash api key 是高熵随机串，直接使用 sha256 存储和查询
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	return hex.EncodeToString(sum[:])
+}
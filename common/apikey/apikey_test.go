@@ -0,0 +1,22 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	key, hash, err := Generate()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(key, Prefix))
+	require.Len(t, key, len(Prefix)+64)
+	require.Equal(t, hash, Hash(key))
+	require.Equal(t, hash, Hash(" "+key+"\n"))
+
+	other, otherHash, err := Generate()
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+	require.NotEqual(t, hash, otherHash)
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+)
+
+// AddressIndex 所有业务方地址的内存索引，交易分类时直接查内存，不再逐个输出查库。
+// 这里不用 ristretto：ristretto 会按淘汰策略丢弃写入，索引漏掉地址会把充值识别成外部交易
+type AddressIndex struct {
+	mu           sync.RWMutex
+	addresses    map[string]map[string]uint8 // businessId -> address -> address type
+	hotWallets   map[string]string
+	coldWallets  map[string]string
+	loadedUntils map[string]uint64 // businessId -> 已加载地址的最大时间戳，用于增量刷新
+}
+
+var (
+	addressIndexes  = make(map[string]*AddressIndex)
+	addressIndexMux sync.Mutex
+)
+
+func NewAddressIndex() *AddressIndex {
+	return &AddressIndex{
+		addresses:    make(map[string]map[string]uint8),
+		hotWallets:   make(map[string]string),
+		coldWallets:  make(map[string]string),
+		loadedUntils: make(map[string]uint64),
+	}
+}
+
+// GetAddressIndex 获取进程内某条链的地址索引，每条链的地址互相独立
+func GetAddressIndex(chain string) *AddressIndex {
+	addressIndexMux.Lock()
+	defer addressIndexMux.Unlock()
+	idx, ok := addressIndexes[chain]
+	if !ok {
+		idx = NewAddressIndex()
+		addressIndexes[chain] = idx
+	}
+	return idx
+}
+
+// RemoveBusiness 把业务方移出进程内所有链的地址索引，业务方注销或暂停时调用
+func RemoveBusiness(businessId string) {
+	addressIndexMux.Lock()
+	defer addressIndexMux.Unlock()
+	for _, idx := range addressIndexes {
+		idx.Remove(businessId)
+	}
+}
+
+// Add 把地址加入索引，重复加入是幂等的
+func (idx *AddressIndex) Add(businessId string, addressList []database.Addresses) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	businessAddresses, ok := idx.addresses[businessId]
+	if !ok {
+		businessAddresses = make(map[string]uint8)
+		idx.addresses[businessId] = businessAddresses
+	}
+	for _, address := range addressList {
+		businessAddresses[address.Address] = address.AddressType
+		switch address.AddressType {
+		case 1:
+			idx.hotWallets[businessId] = address.Address
+		case 2:
+			idx.coldWallets[businessId] = address.Address
+		}
+		if address.Timestamp > idx.loadedUntils[businessId] {
+			idx.loadedUntils[businessId] = address.Timestamp
+		}
+	}
+}
+
+// Remove 删除业务方的全部地址，之后再刷新索引时会从库里重新全量加载
+func (idx *AddressIndex) Remove(businessId string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(businessId)
+}
+
+// Retain 只保留 businessIds 中的业务方，已经注销的业务方从索引里删除
+func (idx *AddressIndex) Retain(businessIds []string) {
+	keep := make(map[string]struct{}, len(businessIds))
+	for _, businessId := range businessIds {
+		keep[businessId] = struct{}{}
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for businessId := range idx.addresses {
+		if _, ok := keep[businessId]; !ok {
+			idx.remove(businessId)
+		}
+	}
+}
+
+func (idx *AddressIndex) remove(businessId string) {
+	delete(idx.addresses, businessId)
+	delete(idx.hotWallets, businessId)
+	delete(idx.coldWallets, businessId)
+	delete(idx.loadedUntils, businessId)
+}
+
+// LoadedUntil 返回业务方已加载地址的最大时间戳，增量刷新时从这个时间戳开始查
+func (idx *AddressIndex) LoadedUntil(businessId string) (uint64, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	_, loaded := idx.addresses[businessId]
+	return idx.loadedUntils[businessId], loaded
+}
+
+// AddressExist 返回地址是否属于业务方以及地址类型 0:用户地址；1:热钱包地址(归集地址)；2:冷钱包地址
+func (idx *AddressIndex) AddressExist(businessId string, address string) (bool, uint8) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	addressType, ok := idx.addresses[businessId][address]
+	return ok, addressType
+}
+
+// Addresses 返回业务方某一类型的全部地址
+func (idx *AddressIndex) Addresses(businessId string, addressType uint8) []string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	var addressList []string
+	for address, t := range idx.addresses[businessId] {
+		if t == addressType {
+			addressList = append(addressList, address)
+		}
+	}
+	return addressList
+}
+
+func (idx *AddressIndex) HotWallet(businessId string) string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.hotWallets[businessId]
+}
+
+func (idx *AddressIndex) ColdWallet(businessId string) string {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.coldWallets[businessId]
+}
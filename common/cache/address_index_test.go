@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+)
+
+func TestAddressIndex(t *testing.T) {
+	idx := NewAddressIndex()
+	_, loaded := idx.LoadedUntil("biz")
+	require.False(t, loaded)
+
+	idx.Add("biz", []database.Addresses{
+		{Address: "user1", AddressType: 0, Timestamp: 10},
+		{Address: "hot", AddressType: 1, Timestamp: 12},
+		{Address: "cold", AddressType: 2, Timestamp: 11},
+	})
+
+	exist, addressType := idx.AddressExist("biz", "user1")
+	require.True(t, exist)
+	require.Equal(t, uint8(0), addressType)
+	exist, addressType = idx.AddressExist("biz", "hot")
+	require.True(t, exist)
+	require.Equal(t, uint8(1), addressType)
+	exist, _ = idx.AddressExist("other", "user1")
+	require.False(t, exist)
+	exist, _ = idx.AddressExist("biz", "external")
+	require.False(t, exist)
+
+	require.Equal(t, "hot", idx.HotWallet("biz"))
+	require.Equal(t, "cold", idx.ColdWallet("biz"))
+	require.Equal(t, "", idx.HotWallet("other"))
+	require.Equal(t, []string{"user1"}, idx.Addresses("biz", 0))
+	require.Empty(t, idx.Addresses("other", 0))
+
+	loadedUntil, loaded := idx.LoadedUntil("biz")
+	require.True(t, loaded)
+	require.Equal(t, uint64(12), loadedUntil)
+
+	// 重复加入不影响结果
+	idx.Add("biz", []database.Addresses{{Address: "user1", AddressType: 0, Timestamp: 10}})
+	loadedUntil, _ = idx.LoadedUntil("biz")
+	require.Equal(t, uint64(12), loadedUntil)
+}
+
+func TestAddressIndexRemove(t *testing.T) {
+	idx := NewAddressIndex()
+	idx.Add("biz", []database.Addresses{
+		{Address: "user1", AddressType: 0, Timestamp: 10},
+		{Address: "hot", AddressType: 1, Timestamp: 12},
+	})
+	idx.Add("other", []database.Addresses{{Address: "user2", AddressType: 0, Timestamp: 5}})
+
+	idx.Remove("biz")
+	exist, _ := idx.AddressExist("biz", "user1")
+	require.False(t, exist)
+	require.Equal(t, "", idx.HotWallet("biz"))
+	_, loaded := idx.LoadedUntil("biz")
+	require.False(t, loaded)
+	exist, _ = idx.AddressExist("other", "user2")
+	require.True(t, exist)
+
+	// 删除之后可以重新全量加载
+	idx.Add("biz", []database.Addresses{{Address: "user1", AddressType: 0, Timestamp: 10}})
+	exist, _ = idx.AddressExist("biz", "user1")
+	require.True(t, exist)
+
+	idx.Retain([]string{"other"})
+	exist, _ = idx.AddressExist("biz", "user1")
+	require.False(t, exist)
+	exist, _ = idx.AddressExist("other", "user2")
+	require.True(t, exist)
+}
+
+func TestRemoveBusiness(t *testing.T) {
+	btc := GetAddressIndex("test-remove-btc")
+	ltc := GetAddressIndex("test-remove-ltc")
+	btc.Add("biz", []database.Addresses{{Address: "btc-user", AddressType: 0, Timestamp: 1}})
+	ltc.Add("biz", []database.Addresses{{Address: "ltc-user", AddressType: 0, Timestamp: 1}})
+
+	RemoveBusiness("biz")
+	exist, _ := btc.AddressExist("biz", "btc-user")
+	require.False(t, exist)
+	exist, _ = ltc.AddressExist("biz", "ltc-user")
+	require.False(t, exist)
+}
@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+)
+
+const (
+	defaultListSize   = 1_000
+	defaultDetailSize = 10_000
+)
+
+// ApiCache 查询接口的响应缓存，列表和详情分开设置容量和过期时间。
+// ristretto 不支持按前缀删除，key 里带上业务方和数据类别的版本号，失效时只递增版本号，旧的 key 不再命中，等过期或被淘汰
+type ApiCache struct {
+	list      *ristretto.Cache[string, any]
+	detail    *ristretto.Cache[string, any]
+	listTTL   time.Duration
+	detailTTL time.Duration
+
+	mu            sync.RWMutex
+	epoch         uint64               // 全部失效时递增
+	generations   map[string]uint64    // businessId + kind -> 版本号
+	staleWindow   time.Duration        // 失效之后这段时间内不写缓存
+	invalidatedAt map[string]time.Time // 和 generations 相同的 key，空串代表全部失效
+	now           func() time.Time
+}
+
+func NewApiCache(cfg config.CacheConfig) (*ApiCache, error) {
+	listSize, detailSize := int64(cfg.ListSize), int64(cfg.DetailSize)
+	if listSize <= 0 {
+		listSize = defaultListSize
+	}
+	if detailSize <= 0 {
+		detailSize = defaultDetailSize
+	}
+	list, err := newRistretto(listSize)
+	if err != nil {
+		return nil, err
+	}
+	detail, err := newRistretto(detailSize)
+	if err != nil {
+		list.Close()
+		return nil, err
+	}
+	return &ApiCache{
+		list:        list,
+		detail:      detail,
+		listTTL:     cfg.ListExpireTime,
+		detailTTL:   cfg.DetailExpireTime,
+		generations: make(map[string]uint64),
+
+		staleWindow:   cfg.StaleWindow,
+		invalidatedAt: make(map[string]time.Time),
+		now:           time.Now,
+	}, nil
+}
+
+// newRistretto 每个条目的 cost 为 1，MaxCost 就是最多缓存的条目数
+func newRistretto(size int64) (*ristretto.Cache[string, any], error) {
+	return ristretto.NewCache[string, any](&ristretto.Config[string, any]{
+		NumCounters: size * 10,
+		MaxCost:     size,
+		BufferItems: 64,
+	})
+}
+
+// Invalidate 失效业务方指定类别的缓存，不传类别时失效业务方的全部缓存，businessId 为空时失效所有缓存
+func (c *ApiCache) Invalidate(businessId string, kinds ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if businessId == "" {
+		c.epoch++
+		c.invalidatedAt[""] = now
+		return
+	}
+	if len(kinds) == 0 {
+		c.generations[businessId]++
+		c.invalidatedAt[businessId] = now
+		return
+	}
+	for _, kind := range kinds {
+		c.generations[generationKey(businessId, kind)]++
+		c.invalidatedAt[generationKey(businessId, kind)] = now
+	}
+}
+
+// settled 业务方这类数据最近一次失效已经超过 staleWindow；开启从库时失效通知可能先于从库回放到达，窗口内查到的可能还是旧数据
+func (c *ApiCache) settled(businessId string, kind string) bool {
+	if c.staleWindow <= 0 {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	now := c.now()
+	for _, key := range []string{"", businessId, generationKey(businessId, kind)} {
+		if at, ok := c.invalidatedAt[key]; ok && now.Sub(at) < c.staleWindow {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ApiCache) Close() {
+	if c == nil {
+		return
+	}
+	c.list.Close()
+	c.detail.Close()
+}
+
+func (c *ApiCache) key(businessId string, kind string, key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return strings.Join([]string{
+		strconv.FormatUint(c.epoch, 10),
+		businessId,
+		strconv.FormatUint(c.generations[businessId], 10),
+		kind,
+		strconv.FormatUint(c.generations[generationKey(businessId, kind)], 10),
+		key,
+	}, "\x00")
+}
+
+func generationKey(businessId string, kind string) string {
+	return businessId + "\x00" + kind
+}
+
+// Entry 某一类数据的强类型缓存，ApiCache 为 nil（未开启缓存）时 Get 总是不命中、Set 不做任何事
+type Entry[V any] struct {
+	cache  *ApiCache
+	kind   string
+	detail bool
+}
+
+// ListEntry 列表查询的缓存，使用列表的容量和过期时间
+func ListEntry[V any](c *ApiCache, kind string) Entry[V] {
+	return Entry[V]{cache: c, kind: kind}
+}
+
+// DetailEntry 详情查询的缓存，使用详情的容量和过期时间
+func DetailEntry[V any](c *ApiCache, kind string) Entry[V] {
+	return Entry[V]{cache: c, kind: kind, detail: true}
+}
+
+// Key 在查询数据库之前生成缓存 key，固定当时的版本号：查询期间发生失效时，查到的旧数据写在旧版本号下，不会被读到
+func (e Entry[V]) Key(businessId string, key string) string {
+	if e.cache == nil {
+		return ""
+	}
+	return e.cache.key(businessId, e.kind, key)
+}
+
+// Cacheable 业务方这类数据最近失效过、还在 staleWindow 内时返回 false，查询结果不写缓存
+func (e Entry[V]) Cacheable(businessId string) bool {
+	return e.cache != nil && e.cache.settled(businessId, e.kind)
+}
+
+func (e Entry[V]) Get(cacheKey string) (V, bool) {
+	var zero V
+	if e.cache == nil {
+		return zero, false
+	}
+	value, ok := e.store().Get(cacheKey)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := value.(V)
+	return typed, ok
+}
+
+func (e Entry[V]) Set(cacheKey string, value V) {
+	if e.cache == nil {
+		return
+	}
+	if e.detail {
+		e.cache.detail.SetWithTTL(cacheKey, value, 1, e.cache.detailTTL)
+	} else {
+		e.cache.list.SetWithTTL(cacheKey, value, 1, e.cache.listTTL)
+	}
+}
+
+func (e Entry[V]) store() *ristretto.Cache[string, any] {
+	if e.detail {
+		return e.cache.detail
+	}
+	return e.cache.list
+}
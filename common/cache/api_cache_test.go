@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+)
+
+func TestApiCache(t *testing.T) {
+	c, err := NewApiCache(config.CacheConfig{ListSize: 100, DetailSize: 100, ListExpireTime: time.Minute, DetailExpireTime: time.Minute})
+	require.NoError(t, err)
+	defer c.Close()
+
+	deposits := ListEntry[[]string](c, "deposits")
+	balances := DetailEntry[string](c, "balances")
+
+	depositKey := deposits.Key("biz", "page1")
+	deposits.Set(depositKey, []string{"a", "b"})
+	balanceKey := balances.Key("biz", "addr")
+	balances.Set(balanceKey, "100")
+	c.list.Wait()
+	c.detail.Wait()
+
+	list, ok := deposits.Get(depositKey)
+	require.True(t, ok)
+	require.Equal(t, []string{"a", "b"}, list)
+
+	// 同一个 key 在列表和详情里互不影响
+	_, ok = ListEntry[string](c, "balances").Get(balanceKey)
+	require.False(t, ok)
+
+	// 失效只影响对应的业务方和类别
+	c.Invalidate("biz", "deposits")
+	_, ok = deposits.Get(deposits.Key("biz", "page1"))
+	require.False(t, ok)
+	balance, ok := balances.Get(balances.Key("biz", "addr"))
+	require.True(t, ok)
+	require.Equal(t, "100", balance)
+
+	// 失效前生成的 key 写入的数据不会被之后的查询读到
+	deposits.Set(depositKey, []string{"stale"})
+	c.list.Wait()
+	_, ok = deposits.Get(deposits.Key("biz", "page1"))
+	require.False(t, ok)
+
+	c.Invalidate("other")
+	_, ok = balances.Get(balances.Key("biz", "addr"))
+	require.True(t, ok)
+	c.Invalidate("biz")
+	_, ok = balances.Get(balances.Key("biz", "addr"))
+	require.False(t, ok)
+
+	balances.Set(balances.Key("biz", "addr"), "200")
+	c.detail.Wait()
+	c.Invalidate("")
+	_, ok = balances.Get(balances.Key("biz", "addr"))
+	require.False(t, ok)
+}
+
+func TestApiCacheDisabled(t *testing.T) {
+	var c *ApiCache
+	entry := ListEntry[int](c, "deposits")
+	key := entry.Key("biz", "page1")
+	entry.Set(key, 1)
+	_, ok := entry.Get(key)
+	require.False(t, ok)
+	c.Invalidate("biz")
+	c.Close()
+}
+
+func TestApiCacheStaleWindow(t *testing.T) {
+	c, err := NewApiCache(config.CacheConfig{ListSize: 100, DetailSize: 100, ListExpireTime: time.Minute, DetailExpireTime: time.Minute, StaleWindow: 5 * time.Second})
+	require.NoError(t, err)
+	defer c.Close()
+	now := time.Unix(1_700_000_000, 0)
+	c.now = func() time.Time { return now }
+
+	deposits := ListEntry[string](c, "deposits")
+	balances := DetailEntry[string](c, "balances")
+	require.True(t, deposits.Cacheable("biz"))
+
+	// 失效之后窗口内不写缓存，只影响对应的业务方和类别
+	c.Invalidate("biz", "deposits")
+	require.False(t, deposits.Cacheable("biz"))
+	require.True(t, balances.Cacheable("biz"))
+	require.True(t, deposits.Cacheable("other"))
+	now = now.Add(5 * time.Second)
+	require.True(t, deposits.Cacheable("biz"))
+
+	c.Invalidate("biz")
+	require.False(t, balances.Cacheable("biz"))
+	require.True(t, balances.Cacheable("other"))
+
+	now = now.Add(time.Minute)
+	c.Invalidate("")
+	require.False(t, balances.Cacheable("other"))
+
+	// 没有开启从库时不限制
+	c.staleWindow = 0
+	require.True(t, balances.Cacheable("other"))
+	require.False(t, ListEntry[string](nil, "deposits").Cacheable("biz"))
+}
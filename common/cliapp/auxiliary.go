@@ -0,0 +1,36 @@
+package cliapp
+
+import (
+	"context"
+	"errors"
+)
+
+// auxiliaryLifecycle 主生命周期加上指标、健康检查等辅助服务
+type auxiliaryLifecycle struct {
+	Lifecycle
+	auxiliary []Lifecycle
+}
+
+// WithAuxiliary 辅助服务按顺序先于主服务启动，主服务停止之后再逆序停止，Stopped 只反映主服务的状态
+func WithAuxiliary(main Lifecycle, auxiliary ...Lifecycle) Lifecycle {
+	return &auxiliaryLifecycle{Lifecycle: main, auxiliary: auxiliary}
+}
+
+func (a *auxiliaryLifecycle) Start(ctx context.Context) error {
+	for _, aux := range a.auxiliary {
+		if err := aux.Start(ctx); err != nil {
+			return err
+		}
+	}
+	return a.Lifecycle.Start(ctx)
+}
+
+func (a *auxiliaryLifecycle) Stop(ctx context.Context) error {
+	err := a.Lifecycle.Stop(ctx)
+	for i := len(a.auxiliary) - 1; i >= 0; i-- {
+		if stopErr := a.auxiliary[i].Stop(ctx); stopErr != nil {
+			err = errors.Join(err, stopErr)
+		}
+	}
+	return err
+}
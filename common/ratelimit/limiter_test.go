@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l := NewLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		require.True(t, l.Allow("biz"))
+	}
+	require.False(t, l.Allow("biz"))
+	// 每个 key 独立计数
+	require.True(t, l.Allow("other"))
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.Allow("biz"))
+	require.False(t, l.Allow("biz"))
+
+	// 令牌最多积累 burst 个
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		require.True(t, l.Allow("biz"))
+	}
+	require.False(t, l.Allow("biz"))
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	l := NewLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		require.True(t, l.Allow("biz"))
+	}
+}
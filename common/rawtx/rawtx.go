@@ -0,0 +1,141 @@
+package rawtx
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidTx = errors.New("invalid raw transaction")
+
+// TxHash 从签名后的原始交易计算交易哈希（txid），segwit 交易去掉 marker、flag 和见证数据之后再做两次 sha256，
+// BTC、LTC、DOGE、BCH 的 txid 算法相同
+func TxHash(rawTxHex string) (string, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
+	if err != nil {
+		return "", ErrInvalidTx
+	}
+	stripped, err := stripWitness(raw)
+	if err != nil {
+		return "", err
+	}
+	first := sha256.Sum256(stripped)
+	second := sha256.Sum256(first[:])
+	for i, j := 0, len(second)-1; i < j; i, j = i+1, j-1 {
+		second[i], second[j] = second[j], second[i]
+	}
+	return hex.EncodeToString(second[:]), nil
+}
+
+// maxRbfSequence BIP125：任意一个输入的 nSequence 小于 0xfffffffe 时交易声明可以被替换
+const maxRbfSequence = 0xfffffffe
+
+// SignalsReplacement 检查签名后的原始交易是否声明了 BIP125 可替换；没有声明的交易只有开启 full-RBF 的节点才接受替换
+func SignalsReplacement(rawTxHex string) (bool, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(rawTxHex, "0x"))
+	if err != nil {
+		return false, ErrInvalidTx
+	}
+	stripped, err := stripWitness(raw)
+	if err != nil {
+		return false, err
+	}
+	r := &reader{buf: stripped, pos: 4}
+	inputs := r.varInt()
+	signals := false
+	for i := uint64(0); i < inputs && r.err == nil; i++ {
+		r.skip(36)
+		r.skip(r.varInt())
+		if r.err == nil && len(r.buf)-r.pos >= 4 && binary.LittleEndian.Uint32(r.buf[r.pos:]) < maxRbfSequence {
+			signals = true
+		}
+		r.skip(4)
+	}
+	if r.err != nil {
+		return false, r.err
+	}
+	return signals, nil
+}
+
+// stripWitness 返回不带见证数据的交易序列化，非 segwit 交易原样返回
+func stripWitness(raw []byte) ([]byte, error) {
+	if len(raw) < 10 {
+		return nil, ErrInvalidTx
+	}
+	if raw[4] != 0x00 || raw[5] != 0x01 {
+		return raw, nil
+	}
+	r := &reader{buf: raw, pos: 6}
+	inputs := r.varInt()
+	for i := uint64(0); i < inputs && r.err == nil; i++ {
+		r.skip(36)
+		r.skip(r.varInt())
+		r.skip(4)
+	}
+	outputs := r.varInt()
+	for i := uint64(0); i < outputs && r.err == nil; i++ {
+		r.skip(8)
+		r.skip(r.varInt())
+	}
+	bodyEnd := r.pos
+	for i := uint64(0); i < inputs && r.err == nil; i++ {
+		items := r.varInt()
+		for j := uint64(0); j < items && r.err == nil; j++ {
+			r.skip(r.varInt())
+		}
+	}
+	if r.err != nil || len(raw)-r.pos != 4 {
+		return nil, ErrInvalidTx
+	}
+	stripped := make([]byte, 0, 4+bodyEnd-6+4)
+	stripped = append(stripped, raw[:4]...)
+	stripped = append(stripped, raw[6:bodyEnd]...)
+	return append(stripped, raw[r.pos:]...), nil
+}
+
+type reader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *reader) skip(n uint64) {
+	if r.err != nil {
+		return
+	}
+	if n > uint64(len(r.buf)-r.pos) {
+		r.err = ErrInvalidTx
+		return
+	}
+	r.pos += int(n)
+}
+
+func (r *reader) varInt() uint64 {
+	if r.err != nil || r.pos >= len(r.buf) {
+		r.err = ErrInvalidTx
+		return 0
+	}
+	prefix := r.buf[r.pos]
+	r.pos++
+	size := 0
+	switch prefix {
+	case 0xfd:
+		size = 2
+	case 0xfe:
+		size = 4
+	case 0xff:
+		size = 8
+	default:
+		return uint64(prefix)
+	}
+	if len(r.buf)-r.pos < size {
+		r.err = ErrInvalidTx
+		return 0
+	}
+	var value [8]byte
+	copy(value[:], r.buf[r.pos:r.pos+size])
+	r.pos += size
+	return binary.LittleEndian.Uint64(value[:])
+}
@@ -0,0 +1,65 @@
+package rawtx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 创世区块的 coinbase 交易
+const genesisCoinbase = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff4d04ffff001d0104455468652054696d65732030332f4a616e2f32303039204368616e63656c6c6f72206f6e206272696e6b206f66207365636f6e64206261696c6f757420666f722062616e6b73ffffffff0100f2052a01000000434104678afdb0fe5548271967f1a67130b7105cd6a828e03909a67962e0ea1f61deb649f6bc3f4cef38c4f35504e51ec112de5c384df7ba0b8d578a4c702b6bf11d5fac00000000"
+
+func TestTxHash(t *testing.T) {
+	hash, err := TxHash(genesisCoinbase)
+	require.NoError(t, err)
+	require.Equal(t, "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", hash)
+}
+
+func TestTxHashSegwit(t *testing.T) {
+	version := "02000000"
+	body := "01" + // 一个输入
+		"aa00000000000000000000000000000000000000000000000000000000000000" + "01000000" + "00" + "fdffffff" +
+		"01" + // 一个输出
+		"e803000000000000" + "160014" + "0000000000000000000000000000000000000000"
+	witness := "02" + "01aa" + "02bbcc"
+	lockTime := "00000000"
+
+	legacy, err := TxHash(version + body + lockTime)
+	require.NoError(t, err)
+	segwit, err := TxHash(version + "0001" + body + witness + lockTime)
+	require.NoError(t, err)
+	// txid 不包含见证数据
+	require.Equal(t, legacy, segwit)
+}
+
+func TestTxHashInvalid(t *testing.T) {
+	_, err := TxHash("zz")
+	require.ErrorIs(t, err, ErrInvalidTx)
+	_, err = TxHash("0200")
+	require.ErrorIs(t, err, ErrInvalidTx)
+	// 见证数据被截断
+	_, err = TxHash("02000000" + "0001" + "01" + "aa00000000000000000000000000000000000000000000000000000000000000" + "01000000" + "00" + "fdffffff" + "00" + "02" + "01aa")
+	require.ErrorIs(t, err, ErrInvalidTx)
+}
+
+func TestSignalsReplacement(t *testing.T) {
+	tx := func(sequence string) string {
+		return "02000000" + "0001" + "01" +
+			"aa00000000000000000000000000000000000000000000000000000000000000" + "01000000" + "00" + sequence +
+			"01" + "e803000000000000" + "160014" + "0000000000000000000000000000000000000000" +
+			"01" + "01aa" + "00000000"
+	}
+	// 0xfffffffd 声明 BIP125，0xfffffffe（只启用 locktime）和 0xffffffff 不声明
+	signals, err := SignalsReplacement(tx("fdffffff"))
+	require.NoError(t, err)
+	require.True(t, signals)
+	signals, err = SignalsReplacement(tx("feffffff"))
+	require.NoError(t, err)
+	require.False(t, signals)
+	signals, err = SignalsReplacement(genesisCoinbase)
+	require.NoError(t, err)
+	require.False(t, signals)
+
+	_, err = SignalsReplacement("zz")
+	require.ErrorIs(t, err, ErrInvalidTx)
+}
@@ -0,0 +1,38 @@
+package tasks
+
+import (
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ParallelMap 用最多 limit 个 goroutine 并发执行 fn(0..count-1)，结果按下标顺序返回；
+// 任意一个任务失败就返回第一个错误，未开始的任务不再执行
+func ParallelMap[T any](count int, limit int, fn func(i int) (T, error)) ([]T, error) {
+	if limit <= 0 {
+		limit = 1
+	}
+	results := make([]T, count)
+	var errGroup errgroup.Group
+	errGroup.SetLimit(limit)
+	var failed atomic.Bool
+	for i := 0; i < count; i++ {
+		if failed.Load() {
+			break
+		}
+		index := i
+		errGroup.Go(func() error {
+			result, err := fn(index)
+			if err != nil {
+				failed.Store(true)
+				return err
+			}
+			results[index] = result
+			return nil
+		})
+	}
+	if err := errGroup.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelMapKeepsOrder(t *testing.T) {
+	results, err := ParallelMap(20, 4, func(i int) (int, error) {
+		// 让后面的任务先完成，验证结果仍按下标排列
+		time.Sleep(time.Duration(20-i) * time.Millisecond)
+		return i * i, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 20)
+	for i, result := range results {
+		require.Equal(t, i*i, result)
+	}
+}
+
+func TestParallelMapLimit(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	_, err := ParallelMap(16, 3, func(i int) (struct{}, error) {
+		current := running.Add(1)
+		for {
+			old := maxRunning.Load()
+			if current <= old || maxRunning.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return struct{}{}, nil
+	})
+	require.NoError(t, err)
+	require.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestParallelMapError(t *testing.T) {
+	errFetch := errors.New("fetch fail")
+	results, err := ParallelMap(10, 2, func(i int) (int, error) {
+		if i == 5 {
+			return 0, errFetch
+		}
+		return i, nil
+	})
+	require.ErrorIs(t, err, errFetch)
+	require.Nil(t, results)
+}
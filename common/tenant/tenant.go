@@ -0,0 +1,35 @@
+package tenant
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+)
+
+// MaxIdentifierLength postgres 标识符的最大长度，超过的部分会被静默截断
+const MaxIdentifierLength = 63
+
+// ErrInvalidRequestId 业务方 id 不符合 ^[a-z0-9_]{1,32}$
+var ErrInvalidRequestId = errors.New("request_id must match ^[a-z0-9_]{1,32}$")
+
+// requestIdRegexp 业务方 id 会拼进表名、索引名和 NOTIFY 消息，只允许小写字母、数字和下划线
+var requestIdRegexp = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// ValidateRequestId 注册业务方和加载业务方策略时校验业务方 id
+func ValidateRequestId(requestId string) error {
+	if !requestIdRegexp.MatchString(requestId) {
+		return ErrInvalidRequestId
+	}
+	return nil
+}
+
+// Identifier 超过 63 个字符的标识符截断后拼上完整名称的哈希，保证不同的长名称不会截断成同一个
+func Identifier(name string) string {
+	if len(name) <= MaxIdentifierLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	hash := hex.EncodeToString(sum[:])[:8]
+	return name[:MaxIdentifierLength-len(hash)-1] + "_" + hash
+}
@@ -0,0 +1,29 @@
+package tenant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequestId(t *testing.T) {
+	for _, requestId := range []string{"a", "biz_01", strings.Repeat("a", 32)} {
+		require.NoError(t, ValidateRequestId(requestId), requestId)
+	}
+	for _, requestId := range []string{"", "Biz", "biz-01", "biz:01", `biz"; DROP TABLE business; --`, strings.Repeat("a", 33)} {
+		require.ErrorIs(t, ValidateRequestId(requestId), ErrInvalidRequestId, requestId)
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	require.Equal(t, "deposits_biz", Identifier("deposits_biz"))
+
+	name := "withdraw_replacements_" + strings.Repeat("a", 32) + "_1700000000"
+	short := Identifier(name)
+	require.Len(t, short, MaxIdentifierLength)
+	require.True(t, strings.HasPrefix(short, name[:50]))
+	require.Equal(t, short, Identifier(name))
+	// 前 54 个字符相同的两个名称截断后仍然不同
+	require.NotEqual(t, short, Identifier(name[:len(name)-1]+"1"))
+}
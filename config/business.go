@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/tenant"
+	"github.com/dapplink-labs/multichain-sync-btc/services/coinselect"
+)
+
+// FeePriorities 业务方可选的费率档位，和 feeestimator 的档位一致；feeestimator 依赖 config，这里不能反过来引用
+var FeePriorities = []string{"economy", "normal", "fast"}
+
+// BusinessPolicy 配置文件里为业务方预设的策略，业务方注册时请求里没有填写的字段使用这里的值
+type BusinessPolicy struct {
+	RequestId         string
+	CoinSelection     string
+	FeePriority       string
+	MinFeeRate        int64
+	MaxFeeRate        int64
+	SafeConfirms      uint32
+	FinalizedConfirms uint32
+}
+
+// newBusinessPolicy 按 businesses 里的一项构造业务方策略，字段错误全部返回
+func newBusinessPolicy(fields map[string]string) (BusinessPolicy, []error) {
+	var policy BusinessPolicy
+	var problems []error
+	for _, key := range sortedKeys(fields) {
+		value := fields[key]
+		var err error
+		switch key {
+		case "request_id":
+			policy.RequestId = value
+		case "coin_selection":
+			policy.CoinSelection = value
+		case "fee_priority":
+			policy.FeePriority = value
+		case "min_fee_rate":
+			policy.MinFeeRate, err = parseFeeRate(key, value)
+		case "max_fee_rate":
+			policy.MaxFeeRate, err = parseFeeRate(key, value)
+		case "safe_confirms":
+			policy.SafeConfirms, err = parseConfirms(key, value)
+		case "finalized_confirms":
+			policy.FinalizedConfirms, err = parseConfirms(key, value)
+		default:
+			err = fmt.Errorf("unknown field %q", key)
+		}
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if policy.RequestId == "" {
+		problems = append(problems, fmt.Errorf("request_id is required"))
+	}
+	return policy, problems
+}
+
+// Validate 检查策略里和业务方注册接口相同的约束
+func (p BusinessPolicy) Validate() []error {
+	var problems []error
+	if err := tenant.ValidateRequestId(p.RequestId); err != nil {
+		problems = append(problems, fmt.Errorf("business %s: %w", p.RequestId, err))
+	}
+	if _, err := coinselect.NewStrategy(p.CoinSelection); err != nil {
+		problems = append(problems, fmt.Errorf("business %s: coin_selection: %w", p.RequestId, err))
+	}
+	if p.FeePriority != "" && !slices.Contains(FeePriorities, p.FeePriority) {
+		problems = append(problems, fmt.Errorf("business %s: fee_priority: unknown fee priority: %s", p.RequestId, p.FeePriority))
+	}
+	if p.MaxFeeRate > 0 && p.MinFeeRate > p.MaxFeeRate {
+		problems = append(problems, fmt.Errorf("business %s: min_fee_rate %d is greater than max_fee_rate %d", p.RequestId, p.MinFeeRate, p.MaxFeeRate))
+	}
+	// 确认数以 uint8 记录，阈值不能超过 255
+	if p.FinalizedConfirms > math.MaxUint8 {
+		problems = append(problems, fmt.Errorf("business %s: finalized_confirms %d exceeds %d", p.RequestId, p.FinalizedConfirms, math.MaxUint8))
+	}
+	if p.FinalizedConfirms > 0 && p.SafeConfirms > p.FinalizedConfirms {
+		problems = append(problems, fmt.Errorf("business %s: safe_confirms %d is greater than finalized_confirms %d", p.RequestId, p.SafeConfirms, p.FinalizedConfirms))
+	}
+	return problems
+}
+
+func parseFeeRate(key, value string) (int64, error) {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return parsed, nil
+}
+
+func parseConfirms(key, value string) (uint32, error) {
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return uint32(parsed), nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChainProfile 一条 utxo 链的参数，chain-name 选择使用哪条链，同一个二进制按配置同步不同的链
+type ChainProfile struct {
+	// Key 配置里使用的名字
+	Key string
+	// ChainName 和 CoinName 是上游 WalletUtxoService 识别的链名和币种
+	ChainName string
+	CoinName  string
+	Network   string
+	// AddressFormats 导出地址支持的格式，第一个是默认格式
+	AddressFormats []string
+	// DustLimit 找零低于这个金额不上链，直接并入手续费，单位是链的最小单位
+	DustLimit int64
+	// FeeUnit 费率单位，只用于展示和日志
+	FeeUnit string
+	// MinFeeRate 节点默认的最低转发费率，业务方配置的最低费率更低时使用这个值
+	MinFeeRate int64
+	// Segwit 链是否支持隔离见证，不支持时 P2SH 地址按传统 P2SH 估算交易大小
+	Segwit bool
+	// PubKeyHashAddrIds、ScriptHashAddrIds base58check 地址的版本字节，包含主网、测试网和回归测试网
+	PubKeyHashAddrIds []byte
+	ScriptHashAddrIds []byte
+	// Bech32HRPs 隔离见证地址的前缀，不支持隔离见证的链为空
+	Bech32HRPs []string
+	// CashAddrPrefixes cashaddr 地址的前缀，只有 BCH 使用
+	CashAddrPrefixes []string
+}
+
+var chainProfiles = []ChainProfile{
+	{
+		Key:            "btc",
+		ChainName:      "Bitcoin",
+		CoinName:       "BTC",
+		Network:        "mainnet",
+		AddressFormats: []string{"p2wpkh", "p2pkh", "p2sh", "p2tr"},
+		DustLimit:      546,
+		FeeUnit:        "sat/vB",
+		MinFeeRate:     1,
+		Segwit:         true,
+
+		PubKeyHashAddrIds: []byte{0x00, 0x6f},
+		ScriptHashAddrIds: []byte{0x05, 0xc4},
+		Bech32HRPs:        []string{"bc", "tb", "bcrt"},
+	},
+	{
+		Key:            "ltc",
+		ChainName:      "Litecoin",
+		CoinName:       "LTC",
+		Network:        "mainnet",
+		AddressFormats: []string{"p2wpkh", "p2pkh", "p2sh"},
+		DustLimit:      5460,
+		FeeUnit:        "litoshi/vB",
+		MinFeeRate:     10,
+		Segwit:         true,
+
+		// 0x05 是早期与 BTC 共用的 P2SH 版本，节点仍然接受
+		PubKeyHashAddrIds: []byte{0x30, 0x6f},
+		ScriptHashAddrIds: []byte{0x32, 0x05, 0x3a, 0xc4},
+		Bech32HRPs:        []string{"ltc", "tltc", "rltc"},
+	},
+	{
+		Key:            "doge",
+		ChainName:      "Dogecoin",
+		CoinName:       "DOGE",
+		Network:        "mainnet",
+		AddressFormats: []string{"p2pkh", "p2sh"},
+		DustLimit:      1_000_000,
+		FeeUnit:        "koinu/B",
+		MinFeeRate:     100,
+		Segwit:         false,
+
+		PubKeyHashAddrIds: []byte{0x1e, 0x71, 0x6f},
+		ScriptHashAddrIds: []byte{0x16, 0xc4},
+	},
+	{
+		Key:            "bch",
+		ChainName:      "BitcoinCash",
+		CoinName:       "BCH",
+		Network:        "mainnet",
+		AddressFormats: []string{"p2pkh", "p2sh"},
+		DustLimit:      546,
+		FeeUnit:        "sat/B",
+		MinFeeRate:     1,
+		Segwit:         false,
+
+		// 传统地址和 BTC 相同，cashaddr 是默认格式
+		PubKeyHashAddrIds: []byte{0x00, 0x6f},
+		ScriptHashAddrIds: []byte{0x05, 0xc4},
+		CashAddrPrefixes:  []string{"bitcoincash", "bchtest", "bchreg"},
+	},
+}
+
+// LookupChainProfile 按配置名、链名或币种查找，不区分大小写；network 非空时覆盖默认网络
+func LookupChainProfile(name string, network string) (ChainProfile, error) {
+	for _, profile := range chainProfiles {
+		if strings.EqualFold(name, profile.Key) || strings.EqualFold(name, profile.ChainName) || strings.EqualFold(name, profile.CoinName) {
+			if network != "" {
+				profile.Network = network
+			}
+			profile.AddressFormats = append([]string(nil), profile.AddressFormats...)
+			profile.PubKeyHashAddrIds = append([]byte(nil), profile.PubKeyHashAddrIds...)
+			profile.ScriptHashAddrIds = append([]byte(nil), profile.ScriptHashAddrIds...)
+			profile.Bech32HRPs = append([]string(nil), profile.Bech32HRPs...)
+			profile.CashAddrPrefixes = append([]string(nil), profile.CashAddrPrefixes...)
+			return profile, nil
+		}
+	}
+	return ChainProfile{}, fmt.Errorf("unsupported chain %q, supported chains: %s", name, strings.Join(SupportedChains(), ", "))
+}
+
+func SupportedChains() []string {
+	keys := make([]string, 0, len(chainProfiles))
+	for _, profile := range chainProfiles {
+		keys = append(keys, profile.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// AddressFormat 校验导出地址的格式，为空时使用默认格式
+func (p ChainProfile) AddressFormat(format string) (string, error) {
+	if format == "" {
+		return p.AddressFormats[0], nil
+	}
+	for _, supported := range p.AddressFormats {
+		if strings.EqualFold(format, supported) {
+			return supported, nil
+		}
+	}
+	return "", fmt.Errorf("address format %q is not supported on %s, supported formats: %s", format, p.ChainName, strings.Join(p.AddressFormats, ", "))
+}
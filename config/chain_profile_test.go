@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupChainProfile(t *testing.T) {
+	profile, err := LookupChainProfile("Litecoin", "")
+	require.NoError(t, err)
+	require.Equal(t, "ltc", profile.Key)
+	require.Equal(t, "mainnet", profile.Network)
+	require.True(t, profile.Segwit)
+
+	profile, err = LookupChainProfile("DOGE", "testnet")
+	require.NoError(t, err)
+	require.Equal(t, "Dogecoin", profile.ChainName)
+	require.Equal(t, "testnet", profile.Network)
+	require.False(t, profile.Segwit)
+
+	_, err = LookupChainProfile("Ethereum", "")
+	require.Error(t, err)
+}
+
+func TestAddressFormat(t *testing.T) {
+	btc, err := LookupChainProfile("btc", "")
+	require.NoError(t, err)
+	format, err := btc.AddressFormat("")
+	require.NoError(t, err)
+	require.Equal(t, "p2wpkh", format)
+	format, err = btc.AddressFormat("P2TR")
+	require.NoError(t, err)
+	require.Equal(t, "p2tr", format)
+
+	doge, err := LookupChainProfile("doge", "")
+	require.NoError(t, err)
+	_, err = doge.AddressFormat("p2wpkh")
+	require.Error(t, err)
+}
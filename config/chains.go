@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChainConfig 同一个进程里同步的一条链。主链沿用 chain-* 配置，表在默认 schema 里；
+// 通过 --chain 增加的链，表放在以链名命名的 schema 里，区块、游标和租户表都和其他链隔离
+type ChainConfig struct {
+	Profile                ChainProfile
+	Schema                 string
+	Rpc                    string
+	StartingHeight         uint
+	Confirmations          uint
+	SafeConfirmations      uint
+	FinalizedConfirmations uint
+}
+
+func (c ChainConfig) Key() string {
+	return c.Profile.Key
+}
+
+// primaryChain 主链的配置来自原有的 chain-* 参数，需要在默认值补齐之后调用
+func primaryChain(cfg Config) ChainConfig {
+	return ChainConfig{
+		Profile:                cfg.Chain,
+		Rpc:                    cfg.ChainBtcRpc,
+		StartingHeight:         cfg.ChainNode.StartingHeight,
+		Confirmations:          cfg.ChainNode.Confirmations,
+		SafeConfirmations:      cfg.ChainNode.SafeConfirmations,
+		FinalizedConfirmations: cfg.ChainNode.FinalizedConfirmations,
+	}
+}
+
+// ParseChainSpec 解析 --chain 的值，字段用空格分隔，name 和 rpc 必填，
+// 例如 "name=ltc rpc=127.0.0.1:8390 starting-height=2700000 confirmations=12"
+func ParseChainSpec(spec string) (ChainConfig, error) {
+	values := make(map[string]string)
+	for _, field := range strings.Fields(spec) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || key == "" || value == "" {
+			return ChainConfig{}, fmt.Errorf("invalid chain field %q in %q, expected key=value", field, spec)
+		}
+		if _, exist := values[key]; exist {
+			return ChainConfig{}, fmt.Errorf("duplicate chain field %q in %q", key, spec)
+		}
+		values[key] = value
+	}
+
+	return newChainConfig(values)
+}
+
+// newChainConfig 按字段构造一条链的配置，--chain 和配置文件的 chains 共用，字段名用中横线
+func newChainConfig(values map[string]string) (ChainConfig, error) {
+	profile, err := LookupChainProfile(values["name"], values["network"])
+	if err != nil {
+		return ChainConfig{}, err
+	}
+	chain := ChainConfig{
+		Profile: profile,
+		Schema:  profile.Key,
+		Rpc:     values["rpc"],
+	}
+	if chain.Rpc == "" {
+		return ChainConfig{}, fmt.Errorf("chain %s: rpc is required", profile.Key)
+	}
+
+	for key, value := range values {
+		var target *uint
+		switch key {
+		case "name", "network", "rpc":
+			continue
+		case "starting-height":
+			target = &chain.StartingHeight
+		case "confirmations":
+			target = &chain.Confirmations
+		case "safe-confirmations":
+			target = &chain.SafeConfirmations
+		case "finalized-confirmations":
+			target = &chain.FinalizedConfirmations
+		default:
+			return ChainConfig{}, fmt.Errorf("chain %s: unknown field %q", profile.Key, key)
+		}
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return ChainConfig{}, fmt.Errorf("chain %s: invalid %s %q", profile.Key, key, value)
+		}
+		*target = uint(parsed)
+	}
+	chain.applyDefaults()
+	return chain, nil
+}
+
+func (c *ChainConfig) applyDefaults() {
+	if c.Confirmations == 0 {
+		c.Confirmations = defaulConfirmations
+	}
+	if c.FinalizedConfirmations == 0 {
+		c.FinalizedConfirmations = c.Confirmations
+	}
+	if c.SafeConfirmations == 0 {
+		c.SafeConfirmations = defaultSafeConfirmations
+	}
+	if c.SafeConfirmations > c.FinalizedConfirmations {
+		c.SafeConfirmations = c.FinalizedConfirmations
+	}
+}
+
+// validateChains 同一条链只能同步一次，schema 也不能重复
+func validateChains(chains []ChainConfig) error {
+	keys := make(map[string]bool, len(chains))
+	schemas := make(map[string]bool, len(chains))
+	for _, chain := range chains {
+		if keys[chain.Key()] {
+			return fmt.Errorf("chain %s is configured more than once", chain.Key())
+		}
+		if schemas[chain.Schema] {
+			return fmt.Errorf("chain %s: schema %q is used by another chain", chain.Key(), chain.Schema)
+		}
+		keys[chain.Key()] = true
+		schemas[chain.Schema] = true
+	}
+	return nil
+}
+
+// ForChain 返回某一条链使用的配置：链相关的参数换成这条链的，数据库连接切到这条链的 schema，
+// worker 和服务按原来的方式读取 Config 即可
+func (cfg Config) ForChain(chain ChainConfig) Config {
+	chainCfg := cfg
+	chainCfg.Chain = chain.Profile
+	chainCfg.ChainBtcRpc = chain.Rpc
+	chainCfg.ChainNode.ChainName = chain.Profile.ChainName
+	chainCfg.ChainNode.ChainNetwork = chain.Profile.Network
+	chainCfg.ChainNode.StartingHeight = chain.StartingHeight
+	chainCfg.ChainNode.Confirmations = chain.Confirmations
+	chainCfg.ChainNode.SafeConfirmations = chain.SafeConfirmations
+	chainCfg.ChainNode.FinalizedConfirmations = chain.FinalizedConfirmations
+	chainCfg.MasterDB.Schema = chain.Schema
+	chainCfg.SlaveDB.Schema = chain.Schema
+	chainCfg.Chains = []ChainConfig{chain}
+	return chainCfg
+}
+
+// Schemas 所有链的 schema，主链是空串，代表默认 schema
+func (cfg Config) Schemas() []string {
+	schemas := make([]string, 0, len(cfg.Chains))
+	for _, chain := range cfg.Chains {
+		schemas = append(schemas, chain.Schema)
+	}
+	return schemas
+}
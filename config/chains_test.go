@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChainSpec(t *testing.T) {
+	chain, err := ParseChainSpec("name=ltc rpc=127.0.0.1:8390 starting-height=2700000")
+	require.NoError(t, err)
+	require.Equal(t, "ltc", chain.Key())
+	require.Equal(t, "ltc", chain.Schema)
+	require.Equal(t, "127.0.0.1:8390", chain.Rpc)
+	require.Equal(t, uint(2700000), chain.StartingHeight)
+	require.Equal(t, uint(defaulConfirmations), chain.Confirmations)
+	require.Equal(t, uint(defaulConfirmations), chain.FinalizedConfirmations)
+	require.Equal(t, uint(defaultSafeConfirmations), chain.SafeConfirmations)
+
+	chain, err = ParseChainSpec("name=Dogecoin network=testnet rpc=doge:8390 confirmations=3")
+	require.NoError(t, err)
+	require.Equal(t, "testnet", chain.Profile.Network)
+	require.Equal(t, uint(3), chain.SafeConfirmations)
+
+	for _, spec := range []string{
+		"name=ltc",
+		"rpc=127.0.0.1:8390",
+		"name=eth rpc=127.0.0.1:8390",
+		"name=ltc rpc=127.0.0.1:8390 confirmations=-1",
+		"name=ltc rpc=127.0.0.1:8390 unknown=1",
+		"name=ltc rpc=a rpc=b",
+		"name=ltc rpc",
+	} {
+		_, err := ParseChainSpec(spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestValidateChains(t *testing.T) {
+	btc, err := LookupChainProfile("btc", "")
+	require.NoError(t, err)
+	ltc, err := ParseChainSpec("name=ltc rpc=127.0.0.1:8390")
+	require.NoError(t, err)
+	require.NoError(t, validateChains([]ChainConfig{{Profile: btc}, ltc}))
+	require.Error(t, validateChains([]ChainConfig{{Profile: btc}, ltc, ltc}))
+}
+
+func TestForChain(t *testing.T) {
+	cfg := Config{
+		ChainBtcRpc: "127.0.0.1:8389",
+		ChainNode:   ChainNodeConfig{ChainName: "Bitcoin", StartingHeight: 800000, Confirmations: 6, BlocksStep: 500},
+		MasterDB:    DBConfig{Name: "wallet"},
+	}
+	ltc, err := ParseChainSpec("name=ltc rpc=127.0.0.1:8390 starting-height=2700000")
+	require.NoError(t, err)
+
+	chainCfg := cfg.ForChain(ltc)
+	require.Equal(t, "ltc", chainCfg.Chain.Key)
+	require.Equal(t, "Litecoin", chainCfg.ChainNode.ChainName)
+	require.Equal(t, "127.0.0.1:8390", chainCfg.ChainBtcRpc)
+	require.Equal(t, uint(2700000), chainCfg.ChainNode.StartingHeight)
+	require.Equal(t, uint(defaulConfirmations), chainCfg.ChainNode.Confirmations)
+	require.Equal(t, uint64(500), chainCfg.ChainNode.BlocksStep)
+	require.Equal(t, "ltc", chainCfg.MasterDB.Schema)
+	require.Equal(t, "wallet", chainCfg.MasterDB.Name)
+	require.Equal(t, []ChainConfig{ltc}, chainCfg.Chains)
+
+	require.Equal(t, "", cfg.MasterDB.Schema)
+	require.Equal(t, "127.0.0.1:8389", cfg.ChainBtcRpc)
+}
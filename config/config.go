@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -15,38 +16,79 @@ const (
 	defaultSynchronizerInterval = 5000
 	defaultWorkerInterval       = 500
 	defaultBlocksStep           = 500
+	defaultFetchConcurrency     = 8
+	defaultFeeBumpBlocks        = 6
+	defaultFeeBumpPercent       = 25
+	defaultReservationTtl       = 30 * time.Minute
+	defaultMempoolInterval      = 10 * time.Second
+	defaultSafeConfirmations    = 6
 )
 
 type Config struct {
-	Migrations     string
-	ChainNode      ChainNodeConfig
-	MasterDB       DBConfig
-	SlaveDB        DBConfig
-	SlaveDbEnable  bool
-	ApiCacheEnable bool
-	CacheConfig    CacheConfig
-	RpcServer      ServerConfig
-	MetricsServer  ServerConfig
-	ChainBtcRpc    string
+	Migrations         string
+	ChainNode          ChainNodeConfig
+	Chain              ChainProfile
+	MasterDB           DBConfig
+	SlaveDB            DBConfig
+	SlaveDbEnable      bool
+	SlaveDbMaxLag      time.Duration
+	SlaveDbLagCheck    time.Duration
+	ApiCacheEnable     bool
+	CacheConfig        CacheConfig
+	RpcServer          ServerConfig
+	RpcAdminToken      string
+	RpcRateLimit       float64
+	RpcRateBurst       int
+	RpcPeerRateLimit   float64
+	RpcPeerRateBurst   int
+	RpcShutdownTimeout time.Duration
+	MetricsServer      ServerConfig
+	HealthGrpcPort     int
+	HealthInterval     time.Duration
+	ScannerMaxStall    time.Duration
+	ChainBtcRpc        string
+	Chains             []ChainConfig
+	// RpcMetricsPort、NotifyMetricsPort rpc 和 notify 命令的指标端口，和 sync 命令的 MetricsServer.Port 分开，
+	// 几个命令部署在同一台机器上时不会抢端口
+	RpcMetricsPort    int
+	NotifyMetricsPort int
+	// NotifyHealthGrpcPort notify 命令的 grpc health 端口，sync 命令用 HealthGrpcPort
+	NotifyHealthGrpcPort int
+	// Businesses 配置文件里预设的业务方策略
+	Businesses []BusinessPolicy
 }
 
 type ChainNodeConfig struct {
-	ChainId              uint64
-	ChainName            string
-	RpcUrl               string
-	StartingHeight       uint
-	Confirmations        uint
-	SynchronizerInterval time.Duration
-	WorkerInterval       time.Duration
-	BlocksStep           uint64
+	ChainName              string
+	ChainNetwork           string
+	RpcUrl                 string
+	StartingHeight         uint
+	Confirmations          uint
+	SynchronizerInterval   time.Duration
+	WorkerInterval         time.Duration
+	BlocksStep             uint64
+	HeaderFetchConcurrency uint
+	BlockFetchConcurrency  uint
+	FeeBumpBlocks          uint
+	FeeBumpPercent         uint
+	ReservationTtl         time.Duration
+	MempoolEnable          bool
+	MempoolInterval        time.Duration
+	SafeConfirmations      uint
+	FinalizedConfirmations uint
 }
 
 type DBConfig struct {
-	Host     string
-	Port     int
-	Name     string
-	User     string
-	Password string
+	Host            string
+	Port            int
+	Name            string
+	User            string
+	Password        string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	// Schema 这条链的表所在的 schema，为空时使用默认 schema
+	Schema string
 }
 
 type CacheConfig struct {
@@ -54,6 +96,8 @@ type CacheConfig struct {
 	DetailSize       int
 	ListExpireTime   time.Duration
 	DetailExpireTime time.Duration
+	// StaleWindow 失效之后这段时间内不写缓存，开启从库时为从库的最大延迟
+	StaleWindow time.Duration
 }
 
 type ServerConfig struct {
@@ -61,10 +105,33 @@ type ServerConfig struct {
 	Port int
 }
 
+// LoadConfig 读取配置，优先级依次为命令行参数、环境变量、--config 指定的配置文件、参数默认值。
+// 配置里的问题会全部收集后一起返回
 func LoadConfig(cliCtx *cli.Context) (Config, error) {
+	var problems []error
+	var file *fileConfig
+	if path := cliCtx.String(flags.ConfigFileFlag.Name); path != "" {
+		var fileProblems []error
+		var err error
+		file, fileProblems, err = loadConfigFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+		problems = append(problems, fileProblems...)
+		problems = append(problems, file.apply(cliCtx)...)
+	}
+
 	var cfg Config
 	cfg = NewConfig(cliCtx)
 
+	if cfg.ChainNode.ChainName != "" {
+		chain, err := LookupChainProfile(cfg.ChainNode.ChainName, cfg.ChainNode.ChainNetwork)
+		if err != nil {
+			problems = append(problems, err)
+		}
+		cfg.Chain = chain
+	}
+
 	if cfg.ChainNode.Confirmations == 0 {
 		cfg.ChainNode.Confirmations = defaulConfirmations
 	}
@@ -81,7 +148,79 @@ func LoadConfig(cliCtx *cli.Context) (Config, error) {
 		cfg.ChainNode.BlocksStep = defaultBlocksStep
 	}
 
-	log.Info("loaded chain config", "config", cfg.ChainNode)
+	if cfg.ChainNode.HeaderFetchConcurrency == 0 {
+		cfg.ChainNode.HeaderFetchConcurrency = defaultFetchConcurrency
+	}
+
+	if cfg.ChainNode.BlockFetchConcurrency == 0 {
+		cfg.ChainNode.BlockFetchConcurrency = defaultFetchConcurrency
+	}
+
+	if cfg.ChainNode.FeeBumpBlocks == 0 {
+		cfg.ChainNode.FeeBumpBlocks = defaultFeeBumpBlocks
+	}
+
+	if cfg.ChainNode.FeeBumpPercent == 0 {
+		cfg.ChainNode.FeeBumpPercent = defaultFeeBumpPercent
+	}
+
+	if cfg.ChainNode.ReservationTtl == 0 {
+		cfg.ChainNode.ReservationTtl = defaultReservationTtl
+	}
+
+	if cfg.ChainNode.MempoolInterval == 0 {
+		cfg.ChainNode.MempoolInterval = defaultMempoolInterval
+	}
+
+	if cfg.ChainNode.FinalizedConfirmations == 0 {
+		cfg.ChainNode.FinalizedConfirmations = cfg.ChainNode.Confirmations
+	}
+
+	if cfg.ChainNode.SafeConfirmations == 0 {
+		cfg.ChainNode.SafeConfirmations = defaultSafeConfirmations
+	}
+
+	if cfg.ChainNode.SafeConfirmations > cfg.ChainNode.FinalizedConfirmations {
+		cfg.ChainNode.SafeConfirmations = cfg.ChainNode.FinalizedConfirmations
+	}
+
+	// --chain 或 WALLET_CHAINS 设置时整体替换配置文件里的 chains
+	cfg.Chains = []ChainConfig{primaryChain(cfg)}
+	if cliCtx.IsSet(flags.ChainsFlag.Name) || file == nil {
+		for _, spec := range cliCtx.StringSlice(flags.ChainsFlag.Name) {
+			chain, err := ParseChainSpec(spec)
+			if err != nil {
+				problems = append(problems, err)
+				continue
+			}
+			cfg.Chains = append(cfg.Chains, chain)
+		}
+	} else {
+		chains, chainProblems := file.chainConfigs()
+		problems = append(problems, chainProblems...)
+		cfg.Chains = append(cfg.Chains, chains...)
+	}
+	if cfg.Chain.Key != "" {
+		if err := validateChains(cfg.Chains); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if file != nil {
+		policies, policyProblems := file.businessPolicies()
+		problems = append(problems, policyProblems...)
+		cfg.Businesses = policies
+	}
+
+	problems = append(problems, cfg.Validate()...)
+	if len(problems) > 0 {
+		return cfg, errors.Join(problems...)
+	}
+
+	for _, chain := range cfg.Chains {
+		log.Info("loaded chain config", "chain", chain.Profile.ChainName, "network", chain.Profile.Network, "schema", chain.Schema, "confirmations", chain.Confirmations)
+	}
+	log.Info("loaded chain node config", "config", cfg.ChainNode)
 	return cfg, nil
 }
 
@@ -90,31 +229,48 @@ func NewConfig(ctx *cli.Context) Config {
 		Migrations:  ctx.String(flags.MigrationsFlag.Name),
 		ChainBtcRpc: ctx.String(flags.ChainBtcRpcFlag.Name),
 		ChainNode: ChainNodeConfig{
-			ChainId:              ctx.Uint64(flags.ChainIdFlag.Name),
-			ChainName:            ctx.String(flags.ChainNameFlag.Name),
-			RpcUrl:               ctx.String(flags.RpcUrlFlag.Name),
-			StartingHeight:       ctx.Uint(flags.StartingHeightFlag.Name),
-			Confirmations:        ctx.Uint(flags.ConfirmationsFlag.Name),
-			SynchronizerInterval: ctx.Duration(flags.SynchronizerIntervalFlag.Name),
-			WorkerInterval:       ctx.Duration(flags.WorkerIntervalFlag.Name),
-			BlocksStep:           ctx.Uint64(flags.BlocksStepFlag.Name),
+			ChainName:              ctx.String(flags.ChainNameFlag.Name),
+			ChainNetwork:           ctx.String(flags.ChainNetworkFlag.Name),
+			RpcUrl:                 ctx.String(flags.RpcUrlFlag.Name),
+			StartingHeight:         ctx.Uint(flags.StartingHeightFlag.Name),
+			Confirmations:          ctx.Uint(flags.ConfirmationsFlag.Name),
+			SynchronizerInterval:   ctx.Duration(flags.SynchronizerIntervalFlag.Name),
+			WorkerInterval:         ctx.Duration(flags.WorkerIntervalFlag.Name),
+			BlocksStep:             ctx.Uint64(flags.BlocksStepFlag.Name),
+			HeaderFetchConcurrency: ctx.Uint(flags.HeaderFetchConcurrencyFlag.Name),
+			BlockFetchConcurrency:  ctx.Uint(flags.BlockFetchConcurrencyFlag.Name),
+			FeeBumpBlocks:          ctx.Uint(flags.FeeBumpBlocksFlag.Name),
+			FeeBumpPercent:         ctx.Uint(flags.FeeBumpPercentFlag.Name),
+			ReservationTtl:         ctx.Duration(flags.ReservationTtlFlag.Name),
+			MempoolEnable:          ctx.Bool(flags.MempoolEnableFlag.Name),
+			MempoolInterval:        ctx.Duration(flags.MempoolIntervalFlag.Name),
+			SafeConfirmations:      ctx.Uint(flags.SafeConfirmationsFlag.Name),
+			FinalizedConfirmations: ctx.Uint(flags.FinalizedConfirmationsFlag.Name),
 		},
 		MasterDB: DBConfig{
-			Host:     ctx.String(flags.MasterDbHostFlag.Name),
-			Port:     ctx.Int(flags.MasterDbPortFlag.Name),
-			Name:     ctx.String(flags.MasterDbNameFlag.Name),
-			User:     ctx.String(flags.MasterDbUserFlag.Name),
-			Password: ctx.String(flags.MasterDbPasswordFlag.Name),
+			Host:            ctx.String(flags.MasterDbHostFlag.Name),
+			Port:            ctx.Int(flags.MasterDbPortFlag.Name),
+			Name:            ctx.String(flags.MasterDbNameFlag.Name),
+			User:            ctx.String(flags.MasterDbUserFlag.Name),
+			Password:        ctx.String(flags.MasterDbPasswordFlag.Name),
+			MaxOpenConns:    ctx.Int(flags.MasterDbMaxOpenConnsFlag.Name),
+			MaxIdleConns:    ctx.Int(flags.MasterDbMaxIdleConnsFlag.Name),
+			ConnMaxLifetime: ctx.Duration(flags.MasterDbConnMaxLifetimeFlag.Name),
 		},
 		SlaveDB: DBConfig{
-			Host:     ctx.String(flags.SlaveDbHostFlag.Name),
-			Port:     ctx.Int(flags.SlaveDbPortFlag.Name),
-			Name:     ctx.String(flags.SlaveDbNameFlag.Name),
-			User:     ctx.String(flags.SlaveDbUserFlag.Name),
-			Password: ctx.String(flags.SlaveDbPasswordFlag.Name),
+			Host:            ctx.String(flags.SlaveDbHostFlag.Name),
+			Port:            ctx.Int(flags.SlaveDbPortFlag.Name),
+			Name:            ctx.String(flags.SlaveDbNameFlag.Name),
+			User:            ctx.String(flags.SlaveDbUserFlag.Name),
+			Password:        ctx.String(flags.SlaveDbPasswordFlag.Name),
+			MaxOpenConns:    ctx.Int(flags.SlaveDbMaxOpenConnsFlag.Name),
+			MaxIdleConns:    ctx.Int(flags.SlaveDbMaxIdleConnsFlag.Name),
+			ConnMaxLifetime: ctx.Duration(flags.SlaveDbConnMaxLifetimeFlag.Name),
 		},
-		SlaveDbEnable:  ctx.Bool(flags.SlaveDbEnableFlag.Name),
-		ApiCacheEnable: ctx.Bool(flags.ApiCacheEnableFlag.Name),
+		SlaveDbEnable:   ctx.Bool(flags.SlaveDbEnableFlag.Name),
+		SlaveDbMaxLag:   ctx.Duration(flags.SlaveDbMaxLagFlag.Name),
+		SlaveDbLagCheck: ctx.Duration(flags.SlaveDbLagCheckIntervalFlag.Name),
+		ApiCacheEnable:  ctx.Bool(flags.ApiCacheEnableFlag.Name),
 		CacheConfig: CacheConfig{
 			ListSize:         ctx.Int(flags.ApiCacheListSizeFlag.Name),
 			DetailSize:       ctx.Int(flags.ApiCacheDetailSizeFlag.Name),
@@ -125,9 +281,23 @@ func NewConfig(ctx *cli.Context) Config {
 			Host: ctx.String(flags.RpcHostFlag.Name),
 			Port: ctx.Int(flags.RpcPortFlag.Name),
 		},
+		RpcAdminToken:      ctx.String(flags.RpcAdminTokenFlag.Name),
+		RpcRateLimit:       ctx.Float64(flags.RpcRateLimitFlag.Name),
+		RpcRateBurst:       ctx.Int(flags.RpcRateBurstFlag.Name),
+		RpcPeerRateLimit:   ctx.Float64(flags.RpcPeerRateLimitFlag.Name),
+		RpcPeerRateBurst:   ctx.Int(flags.RpcPeerRateBurstFlag.Name),
+		RpcShutdownTimeout: ctx.Duration(flags.RpcShutdownTimeoutFlag.Name),
 		MetricsServer: ServerConfig{
 			Host: ctx.String(flags.MetricsHostFlag.Name),
 			Port: ctx.Int(flags.MetricsPortFlag.Name),
 		},
+		HealthGrpcPort:  ctx.Int(flags.HealthGrpcPortFlag.Name),
+		HealthInterval:  ctx.Duration(flags.HealthCheckIntervalFlag.Name),
+		ScannerMaxStall: ctx.Duration(flags.ScannerMaxStallFlag.Name),
+
+		RpcMetricsPort:    ctx.Int(flags.RpcMetricsPortFlag.Name),
+		NotifyMetricsPort: ctx.Int(flags.NotifyMetricsPortFlag.Name),
+
+		NotifyHealthGrpcPort: ctx.Int(flags.NotifyHealthGrpcPortFlag.Name),
 	}
 }
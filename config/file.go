@@ -0,0 +1,280 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dapplink-labs/multichain-sync-btc/flags"
+)
+
+// fileKeys 配置文件里的字段和命令行参数的对应关系，字段按 section 分组、用下划线命名，
+// 例如 master_db.password 对应 --master-db-password，不在表里的字段一律视为错误
+var fileKeys = map[string]string{
+	"migrations_dir": flags.MigrationsFlag.Name,
+
+	"chain.name":                    flags.ChainNameFlag.Name,
+	"chain.network":                 flags.ChainNetworkFlag.Name,
+	"chain.rpc":                     flags.ChainBtcRpcFlag.Name,
+	"chain.rpc_url":                 flags.RpcUrlFlag.Name,
+	"chain.starting_height":         flags.StartingHeightFlag.Name,
+	"chain.confirmations":           flags.ConfirmationsFlag.Name,
+	"chain.safe_confirmations":      flags.SafeConfirmationsFlag.Name,
+	"chain.finalized_confirmations": flags.FinalizedConfirmationsFlag.Name,
+
+	"sync.interval":                 flags.SynchronizerIntervalFlag.Name,
+	"sync.worker_interval":          flags.WorkerIntervalFlag.Name,
+	"sync.blocks_step":              flags.BlocksStepFlag.Name,
+	"sync.header_fetch_concurrency": flags.HeaderFetchConcurrencyFlag.Name,
+	"sync.block_fetch_concurrency":  flags.BlockFetchConcurrencyFlag.Name,
+	"sync.fee_bump_blocks":          flags.FeeBumpBlocksFlag.Name,
+	"sync.fee_bump_percent":         flags.FeeBumpPercentFlag.Name,
+	"sync.reservation_ttl":          flags.ReservationTtlFlag.Name,
+	"sync.mempool_enable":           flags.MempoolEnableFlag.Name,
+	"sync.mempool_interval":         flags.MempoolIntervalFlag.Name,
+
+	"master_db.host":              flags.MasterDbHostFlag.Name,
+	"master_db.port":              flags.MasterDbPortFlag.Name,
+	"master_db.user":              flags.MasterDbUserFlag.Name,
+	"master_db.password":          flags.MasterDbPasswordFlag.Name,
+	"master_db.name":              flags.MasterDbNameFlag.Name,
+	"master_db.max_open_conns":    flags.MasterDbMaxOpenConnsFlag.Name,
+	"master_db.max_idle_conns":    flags.MasterDbMaxIdleConnsFlag.Name,
+	"master_db.conn_max_lifetime": flags.MasterDbConnMaxLifetimeFlag.Name,
+
+	"slave_db.enable":             flags.SlaveDbEnableFlag.Name,
+	"slave_db.host":               flags.SlaveDbHostFlag.Name,
+	"slave_db.port":               flags.SlaveDbPortFlag.Name,
+	"slave_db.user":               flags.SlaveDbUserFlag.Name,
+	"slave_db.password":           flags.SlaveDbPasswordFlag.Name,
+	"slave_db.name":               flags.SlaveDbNameFlag.Name,
+	"slave_db.max_open_conns":     flags.SlaveDbMaxOpenConnsFlag.Name,
+	"slave_db.max_idle_conns":     flags.SlaveDbMaxIdleConnsFlag.Name,
+	"slave_db.conn_max_lifetime":  flags.SlaveDbConnMaxLifetimeFlag.Name,
+	"slave_db.max_lag":            flags.SlaveDbMaxLagFlag.Name,
+	"slave_db.lag_check_interval": flags.SlaveDbLagCheckIntervalFlag.Name,
+
+	"api_cache.enable":             flags.ApiCacheEnableFlag.Name,
+	"api_cache.list_size":          flags.ApiCacheListSizeFlag.Name,
+	"api_cache.detail_size":        flags.ApiCacheDetailSizeFlag.Name,
+	"api_cache.list_expire_time":   flags.ApiCacheListExpireTimeFlag.Name,
+	"api_cache.detail_expire_time": flags.ApiCacheDetailExpireTimeFlag.Name,
+
+	"rpc.host":             flags.RpcHostFlag.Name,
+	"rpc.port":             flags.RpcPortFlag.Name,
+	"rpc.admin_token":      flags.RpcAdminTokenFlag.Name,
+	"rpc.rate_limit":       flags.RpcRateLimitFlag.Name,
+	"rpc.rate_burst":       flags.RpcRateBurstFlag.Name,
+	"rpc.peer_rate_limit":  flags.RpcPeerRateLimitFlag.Name,
+	"rpc.peer_rate_burst":  flags.RpcPeerRateBurstFlag.Name,
+	"rpc.shutdown_timeout": flags.RpcShutdownTimeoutFlag.Name,
+
+	"metrics.host":        flags.MetricsHostFlag.Name,
+	"metrics.port":        flags.MetricsPortFlag.Name,
+	"metrics.rpc_port":    flags.RpcMetricsPortFlag.Name,
+	"metrics.notify_port": flags.NotifyMetricsPortFlag.Name,
+
+	"health.grpc_port":         flags.HealthGrpcPortFlag.Name,
+	"health.notify_grpc_port":  flags.NotifyHealthGrpcPortFlag.Name,
+	"health.check_interval":    flags.HealthCheckIntervalFlag.Name,
+	"health.scanner_max_stall": flags.ScannerMaxStallFlag.Name,
+}
+
+const (
+	chainsSection     = "chains"
+	businessesSection = "businesses"
+)
+
+// fileConfig 解析后的配置文件，values 以命令行参数名为 key；chains 和 businesses 是列表，单独保存
+type fileConfig struct {
+	Path       string
+	Values     map[string]string
+	Chains     []map[string]string
+	Businesses []map[string]string
+}
+
+// loadConfigFile 读取配置文件，按扩展名选择 yaml 或 toml。文件读不出或语法错误时返回 error，
+// 字段层面的问题全部收集到 problems 里，方便一次性报告
+func loadConfigFile(path string) (*fileConfig, []error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read config file %s: %w", path, err)
+	}
+	return parseConfigFile(path, data)
+}
+
+func parseConfigFile(path string, data []byte) (*fileConfig, []error, error) {
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		if err := decoder.Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	case ".toml":
+		if _, err := toml.Decode(string(data), &raw); err != nil {
+			return nil, nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	default:
+		return nil, nil, fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+
+	file := &fileConfig{Path: path, Values: make(map[string]string)}
+	var problems []error
+	for _, key := range sortedKeys(raw) {
+		value := raw[key]
+		switch key {
+		case chainsSection:
+			file.Chains, problems = parseList(key, value, problems)
+		case businessesSection:
+			file.Businesses, problems = parseList(key, value, problems)
+		default:
+			problems = file.flatten(key, value, problems)
+		}
+	}
+	return file, problems, nil
+}
+
+// flatten 把嵌套的 section 展开成 chain.id 这样的字段，再换成对应的命令行参数
+func (f *fileConfig) flatten(key string, value interface{}, problems []error) []error {
+	if section, ok := value.(map[string]interface{}); ok {
+		for _, child := range sortedKeys(section) {
+			problems = f.flatten(key+"."+child, section[child], problems)
+		}
+		return problems
+	}
+	name, ok := fileKeys[key]
+	if !ok {
+		return append(problems, fmt.Errorf("config file: unknown field %q", key))
+	}
+	scalar, err := scalarValue(key, value)
+	if err != nil {
+		return append(problems, err)
+	}
+	f.Values[name] = scalar
+	return problems
+}
+
+// parseList 解析 chains、businesses 这类列表，每一项是只含标量字段的表
+func parseList(section string, value interface{}, problems []error) ([]map[string]string, []error) {
+	var items []interface{}
+	switch list := value.(type) {
+	case []interface{}:
+		items = list
+	case []map[string]interface{}:
+		for _, item := range list {
+			items = append(items, item)
+		}
+	default:
+		return nil, append(problems, fmt.Errorf("config file: %s must be a list", section))
+	}
+
+	result := make([]map[string]string, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			problems = append(problems, fmt.Errorf("config file: %s[%d] must be a table", section, i))
+			continue
+		}
+		values := make(map[string]string, len(fields))
+		for _, key := range sortedKeys(fields) {
+			scalar, err := scalarValue(fmt.Sprintf("%s[%d].%s", section, i, key), fields[key])
+			if err != nil {
+				problems = append(problems, err)
+				continue
+			}
+			values[key] = scalar
+		}
+		result = append(result, values)
+	}
+	return result, problems
+}
+
+func scalarValue(key string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("config file: %s must be a string, number or bool", key)
+	}
+}
+
+// apply 把配置文件的值写到命令行参数上。命令行或环境变量已经设置的参数不覆盖，
+// 因此数据库密码这类敏感信息可以不写在文件里，通过 WALLET_MASTER_DB_PASSWORD 等环境变量提供
+func (f *fileConfig) apply(cliCtx *cli.Context) []error {
+	var problems []error
+	for _, name := range sortedKeys(f.Values) {
+		if cliCtx.IsSet(name) {
+			continue
+		}
+		if err := cliCtx.Set(name, f.Values[name]); err != nil {
+			problems = append(problems, fmt.Errorf("config file: %s: invalid value %q", fileKey(name), f.Values[name]))
+		}
+	}
+	return problems
+}
+
+// chainConfigs 配置文件里通过 chains 增加的链，字段名和 --chain 一致，中横线写成下划线
+func (f *fileConfig) chainConfigs() ([]ChainConfig, []error) {
+	var chains []ChainConfig
+	var problems []error
+	for i, fields := range f.Chains {
+		values := make(map[string]string, len(fields))
+		for key, value := range fields {
+			values[strings.ReplaceAll(key, "_", "-")] = value
+		}
+		chain, err := newChainConfig(values)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("config file: chains[%d]: %w", i, err))
+			continue
+		}
+		chains = append(chains, chain)
+	}
+	return chains, problems
+}
+
+// businessPolicies 配置文件里的业务方策略
+func (f *fileConfig) businessPolicies() ([]BusinessPolicy, []error) {
+	var policies []BusinessPolicy
+	var problems []error
+	for i, fields := range f.Businesses {
+		policy, errs := newBusinessPolicy(fields)
+		for _, err := range errs {
+			problems = append(problems, fmt.Errorf("config file: businesses[%d]: %w", i, err))
+		}
+		if len(errs) == 0 {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, problems
+}
+
+// fileKey 命令行参数在配置文件里的字段名，用于错误信息
+func fileKey(name string) string {
+	for key, flagName := range fileKeys {
+		if flagName == name {
+			return key
+		}
+	}
+	return name
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
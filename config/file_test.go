@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/dapplink-labs/multichain-sync-btc/flags"
+)
+
+const testYamlConfig = `
+chain:
+  name: btc
+  rpc: 127.0.0.1:8389
+  rpc_url: 127.0.0.1:8389
+  starting_height: 800000
+master_db:
+  host: 127.0.0.1
+  port: 5432
+  user: wallet
+  password: from-file
+  name: wallet
+rpc:
+  host: 127.0.0.1
+  port: 8985
+metrics:
+  host: 127.0.0.1
+  port: 8986
+chains:
+  - name: ltc
+    rpc: 127.0.0.1:8390
+    starting_height: 2700000
+businesses:
+  - request_id: exchange
+    coin_selection: knapsack
+    safe_confirms: 3
+    finalized_confirms: 6
+`
+
+func TestParseConfigFile(t *testing.T) {
+	file, problems, err := parseConfigFile("wallet.yaml", []byte(testYamlConfig))
+	require.NoError(t, err)
+	require.Empty(t, problems)
+	require.Equal(t, "btc", file.Values[flags.ChainNameFlag.Name])
+	require.Equal(t, "5432", file.Values[flags.MasterDbPortFlag.Name])
+	require.Len(t, file.Chains, 1)
+
+	chains, problems := file.chainConfigs()
+	require.Empty(t, problems)
+	require.Equal(t, uint(2700000), chains[0].StartingHeight)
+
+	policies, problems := file.businessPolicies()
+	require.Empty(t, problems)
+	require.Equal(t, BusinessPolicy{RequestId: "exchange", CoinSelection: "knapsack", SafeConfirms: 3, FinalizedConfirms: 6}, policies[0])
+
+	toml := `
+[chain]
+name = "ltc"
+starting_height = 2700000
+
+[[chains]]
+name = "doge"
+rpc = "127.0.0.1:8391"
+`
+	file, problems, err = parseConfigFile("wallet.toml", []byte(toml))
+	require.NoError(t, err)
+	require.Empty(t, problems)
+	require.Equal(t, "2700000", file.Values[flags.StartingHeightFlag.Name])
+	require.Equal(t, "doge", file.Chains[0]["name"])
+
+	_, _, err = parseConfigFile("wallet.json", []byte("{}"))
+	require.Error(t, err)
+	_, _, err = parseConfigFile("wallet.yaml", []byte("chain: ["))
+	require.Error(t, err)
+}
+
+func TestParseConfigFileProblems(t *testing.T) {
+	data := `
+chain:
+  confirmation: 3
+  rpc: [a, b]
+rpc:
+  hostname: 127.0.0.1
+chains: ltc
+businesses:
+  - coin_selection: knapsack
+    min_fee_rate: -1
+    unknown: 1
+`
+	file, problems, err := parseConfigFile("wallet.yaml", []byte(data))
+	require.NoError(t, err)
+	require.Len(t, problems, 4)
+
+	_, problems = file.businessPolicies()
+	require.Len(t, problems, 3)
+}
+
+func TestLoadConfigFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallet.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testYamlConfig), 0o600))
+	t.Setenv("WALLET_MASTER_DB_PASSWORD", "from-env")
+
+	cfg, err := runLoadConfig(t, "--config", path, "--rpc-port", "9000")
+	require.NoError(t, err)
+	require.Equal(t, "from-env", cfg.MasterDB.Password)
+	require.Equal(t, 9000, cfg.RpcServer.Port)
+	require.Equal(t, uint(800000), cfg.ChainNode.StartingHeight)
+	require.Len(t, cfg.Chains, 2)
+	require.Equal(t, "ltc", cfg.Chains[1].Key())
+	require.Len(t, cfg.Businesses, 1)
+
+	// --chain 整体替换配置文件里的 chains
+	cfg, err = runLoadConfig(t, "--config", path, "--chain", "name=doge rpc=127.0.0.1:8391")
+	require.NoError(t, err)
+	require.Len(t, cfg.Chains, 2)
+	require.Equal(t, "doge", cfg.Chains[1].Key())
+}
+
+func TestValidate(t *testing.T) {
+	_, err := runLoadConfig(t, "--master-db-port", "70000")
+	require.Error(t, err)
+	problems := err.(interface{ Unwrap() []error }).Unwrap()
+	// chain-name、rpc-url、btc-rpc、master db 的 host/port/user/name、rpc host、metrics host，端口有默认值
+	require.Len(t, problems, 9)
+
+	cfg := Config{
+		Businesses: []BusinessPolicy{
+			{RequestId: "a", MinFeeRate: 10, MaxFeeRate: 5},
+			{RequestId: "a", SafeConfirms: 6, FinalizedConfirms: 3},
+			{RequestId: "b", FinalizedConfirms: 300},
+			{RequestId: "Bad-Id", CoinSelection: "random", FeePriority: "urgent"},
+		},
+	}
+	// 15 个基础配置的问题，加上重复的业务方和 6 个策略问题
+	require.Len(t, cfg.Validate(), 22)
+}
+
+func runLoadConfig(t *testing.T, args ...string) (Config, error) {
+	var cfg Config
+	var loadErr error
+	app := &cli.App{
+		Flags: flags.Flags,
+		Action: func(ctx *cli.Context) error {
+			cfg, loadErr = LoadConfig(ctx)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(append([]string{"wallet"}, args...)))
+	return cfg, loadErr
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dapplink-labs/multichain-sync-btc/flags"
+)
+
+// Validate 检查补齐默认值之后的配置，所有问题一起返回，不在第一个错误处停下
+func (cfg Config) Validate() []error {
+	var problems []error
+	require := func(name string, value string) {
+		if value == "" {
+			problems = append(problems, flagError(name, "is required"))
+		}
+	}
+	port := func(name string, value int) {
+		if value <= 0 || value > 65535 {
+			problems = append(problems, flagError(name, "invalid port %d", value))
+		}
+	}
+
+	require(flags.ChainNameFlag.Name, cfg.ChainNode.ChainName)
+	require(flags.RpcUrlFlag.Name, cfg.ChainNode.RpcUrl)
+	require(flags.ChainBtcRpcFlag.Name, cfg.ChainBtcRpc)
+
+	require(flags.MasterDbHostFlag.Name, cfg.MasterDB.Host)
+	port(flags.MasterDbPortFlag.Name, cfg.MasterDB.Port)
+	require(flags.MasterDbUserFlag.Name, cfg.MasterDB.User)
+	require(flags.MasterDbNameFlag.Name, cfg.MasterDB.Name)
+	if cfg.SlaveDbEnable {
+		require(flags.SlaveDbHostFlag.Name, cfg.SlaveDB.Host)
+		port(flags.SlaveDbPortFlag.Name, cfg.SlaveDB.Port)
+		require(flags.SlaveDbUserFlag.Name, cfg.SlaveDB.User)
+		require(flags.SlaveDbNameFlag.Name, cfg.SlaveDB.Name)
+	}
+
+	require(flags.RpcHostFlag.Name, cfg.RpcServer.Host)
+	port(flags.RpcPortFlag.Name, cfg.RpcServer.Port)
+	require(flags.MetricsHostFlag.Name, cfg.MetricsServer.Host)
+	port(flags.MetricsPortFlag.Name, cfg.MetricsServer.Port)
+	port(flags.RpcMetricsPortFlag.Name, cfg.RpcMetricsPort)
+	port(flags.NotifyMetricsPortFlag.Name, cfg.NotifyMetricsPort)
+	port(flags.HealthGrpcPortFlag.Name, cfg.HealthGrpcPort)
+	port(flags.NotifyHealthGrpcPortFlag.Name, cfg.NotifyHealthGrpcPort)
+	if cfg.RpcRateLimit < 0 {
+		problems = append(problems, flagError(flags.RpcRateLimitFlag.Name, "must not be negative"))
+	}
+	if cfg.RpcRateBurst < 0 {
+		problems = append(problems, flagError(flags.RpcRateBurstFlag.Name, "must not be negative"))
+	}
+	if cfg.RpcPeerRateLimit < 0 {
+		problems = append(problems, flagError(flags.RpcPeerRateLimitFlag.Name, "must not be negative"))
+	}
+	if cfg.RpcPeerRateBurst < 0 {
+		problems = append(problems, flagError(flags.RpcPeerRateBurstFlag.Name, "must not be negative"))
+	}
+
+	requestIds := make(map[string]bool, len(cfg.Businesses))
+	for _, policy := range cfg.Businesses {
+		if requestIds[policy.RequestId] {
+			problems = append(problems, fmt.Errorf("business %s is configured more than once", policy.RequestId))
+		}
+		requestIds[policy.RequestId] = true
+		problems = append(problems, policy.Validate()...)
+	}
+	return problems
+}
+
+// flagError 错误信息同时给出命令行参数和配置文件里的字段名
+func flagError(name string, format string, args ...interface{}) error {
+	return fmt.Errorf("--%s (%s): %s", name, fileKey(name), fmt.Sprintf(format, args...))
+}
@@ -22,6 +22,7 @@ type AddressesView interface {
 	QueryHotWalletInfo(string) (*Addresses, error)
 	QueryColdWalletInfo(string) (*Addresses, error)
 	GetAllAddresses(string) ([]*Addresses, error)
+	QueryAddressesAfterTimestamp(string, uint64) ([]Addresses, error)
 }
 
 type AddressesDB interface {
@@ -103,3 +104,13 @@ func (db *addressesDB) GetAllAddresses(requestId string) ([]*Addresses, error) {
 	}
 	return addresses, nil
 }
+
+// QueryAddressesAfterTimestamp 查询时间戳不小于 timestamp 的地址，用于增量刷新内存地址索引
+func (db *addressesDB) QueryAddressesAfterTimestamp(requestId string, timestamp uint64) ([]Addresses, error) {
+	var addresses []Addresses
+	err := db.gorm.Table("addresses_"+requestId).Where("timestamp >= ?", timestamp).Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
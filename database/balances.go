@@ -30,6 +30,8 @@ type BalancesDB interface {
 	UpdateOrCreate(string, []TokenBalance) error
 	StoreBalances(string, []Balances) error
 	UpdateBalances(string, []Balances) error
+	RollbackBalances(string, []TokenBalance) ([]TokenBalance, error)
+	RestoreBalances(string, []TokenBalance) error
 }
 
 type balancesDB struct {
@@ -195,3 +197,51 @@ func (db *balancesDB) UpdateOrCreate(requestId string, balanceList []TokenBalanc
 	}
 	return nil
 }
+
+// RollbackBalances 回滚被重组掉的交易给地址增加的余额。余额不够扣说明余额和库里的交易已经对不上，
+// 这个地址的余额保持不变，不会改成 0 掩盖问题，也不让一个地址的问题挡住整个回滚事务；这些项返回给调用方记录下来人工核对
+func (db *balancesDB) RollbackBalances(requestId string, balanceList []TokenBalance) ([]TokenBalance, error) {
+	var underflows []TokenBalance
+	for _, value := range balanceList {
+		var balance Balances
+		result := db.gorm.Table("balances_"+requestId).Where("address = ?", value.ToAddress).Take(&balance)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, result.Error
+		}
+		remaining := new(big.Int).Sub(balance.Balance, value.Balance)
+		if remaining.Sign() < 0 {
+			log.Error("rollback balance below zero, keep balance for manual reconciliation", "businessId", requestId, "address", balance.Address, "balance", balance.Balance, "rollback", value.Balance)
+			underflows = append(underflows, value)
+			continue
+		}
+		balance.Balance = remaining
+		if err := db.gorm.Table("balances_" + requestId).Save(&balance).Error; err != nil {
+			log.Error("rollback balance fail", "address", balance.Address, "err", err)
+			return nil, err
+		}
+	}
+	return underflows, nil
+}
+
+// RestoreBalances 被重组掉的交易从花费地址扣掉的余额加回去，是 UpdateOrCreate 里提现扣款的逆操作
+func (db *balancesDB) RestoreBalances(requestId string, balanceList []TokenBalance) error {
+	for _, value := range balanceList {
+		var balance Balances
+		result := db.gorm.Table("balances_"+requestId).Where("address = ?", value.FromAddress).Take(&balance)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return result.Error
+		}
+		balance.Balance = new(big.Int).Add(balance.Balance, value.Balance)
+		if err := db.gorm.Table("balances_" + requestId).Save(&balance).Error; err != nil {
+			log.Error("restore balance fail", "address", balance.Address, "err", err)
+			return err
+		}
+	}
+	return nil
+}
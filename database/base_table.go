@@ -1,13 +1,50 @@
 package database
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
 	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/tenant"
 )
 
+// TableColumn 表字段定义，DataType 为 format_type 格式化之后的类型
+type TableColumn struct {
+	Name         string
+	DataType     string
+	NotNull      bool
+	DefaultValue string
+}
+
+// TableIndex 表索引定义，Definition 为 pg_indexes 中的完整建索引语句
+type TableIndex struct {
+	Name       string
+	Definition string
+}
+
+// quoteIdent 表名和字段名里包含业务方 id，拼进 DDL 时统一加引号
+func quoteIdent(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+type CreateTableView interface {
+	TableExists(tableName string) (bool, error)
+	TableColumns(tableName string) ([]TableColumn, error)
+	TableIndexes(tableName string) ([]TableIndex, error)
+}
+
 type CreateTableDB interface {
-	CreateTable(tableName, realTableName string)
+	CreateTableView
+
+	CreateTable(tableName, realTableName string) error
+	AddColumn(tableName string, column TableColumn) error
+	CreateIndex(indexSql string) error
+	DropTable(tableName string) error
+	ArchiveTable(tableName string, archivedName string) error
 }
 
 type createTableDB struct {
@@ -18,9 +55,120 @@ func NewCreateTableDB(db *gorm.DB) CreateTableDB {
 	return &createTableDB{gorm: db}
 }
 
-func (dao *createTableDB) CreateTable(tableName, realTableName string) {
-	err := dao.gorm.Exec("CREATE TABLE IF NOT EXISTS " + tableName + "(like " + realTableName + " including all)").Error
+func (dao *createTableDB) CreateTable(tableName, realTableName string) error {
+	err := dao.gorm.Exec("CREATE TABLE IF NOT EXISTS " + quoteIdent(tableName) + " (LIKE " + quoteIdent(realTableName) + " INCLUDING ALL)").Error
+	if err != nil {
+		log.Error("create table from base table fail", "table", tableName, "err", err)
+		return err
+	}
+	return nil
+}
+
+// TableExists 只在当前 schema 里查找；其他链迁移时 search_path 带着 public，to_regclass 会找到主链的同名表
+func (dao *createTableDB) TableExists(tableName string) (bool, error) {
+	var exists bool
+	err := dao.gorm.Raw("SELECT EXISTS (SELECT 1 FROM pg_tables WHERE schemaname = current_schema() AND tablename = ?)", tableName).Scan(&exists).Error
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (dao *createTableDB) TableColumns(tableName string) ([]TableColumn, error) {
+	var columns []TableColumn
+	err := dao.gorm.Raw(`SELECT a.attname AS name,
+       format_type(a.atttypid, a.atttypmod) AS data_type,
+       a.attnotnull AS not_null,
+       COALESCE(pg_get_expr(d.adbin, d.adrelid), '') AS default_value
+FROM pg_attribute a
+         LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attrelid = to_regclass(?)
+  AND a.attnum > 0
+  AND NOT a.attisdropped
+ORDER BY a.attnum`, quoteIdent(tableName)).Scan(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+func (dao *createTableDB) TableIndexes(tableName string) ([]TableIndex, error) {
+	var indexes []TableIndex
+	err := dao.gorm.Raw("SELECT indexname AS name, indexdef AS definition FROM pg_indexes WHERE schemaname = current_schema() AND tablename = ? ORDER BY indexname", tableName).
+		Scan(&indexes).Error
 	if err != nil {
-		log.Error("create table from base table fail", "err", err)
+		return nil, err
+	}
+	return indexes, nil
+}
+
+// AddColumn 给表补上缺少的字段；没有默认值的非空字段只有在表里没有数据时才加上 NOT NULL，
+// 已有数据时字段保持可空，之后的检查会报告非空约束不一致，由人工补数据后再加约束
+func (dao *createTableDB) AddColumn(tableName string, column TableColumn) error {
+	if column.Name == "" || column.DataType == "" {
+		return errors.New("invalid column definition")
+	}
+	columnSql := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", quoteIdent(tableName), quoteIdent(column.Name), column.DataType)
+	if column.DefaultValue != "" {
+		columnSql += " DEFAULT " + column.DefaultValue
+		if column.NotNull {
+			columnSql += " NOT NULL"
+		}
+	}
+	if err := dao.gorm.Exec(columnSql).Error; err != nil {
+		log.Error("add column fail", "table", tableName, "column", column.Name, "err", err)
+		return err
+	}
+	if !column.NotNull || column.DefaultValue != "" {
+		return nil
+	}
+	var hasRows bool
+	if err := dao.gorm.Raw("SELECT EXISTS (SELECT 1 FROM " + quoteIdent(tableName) + ")").Scan(&hasRows).Error; err != nil {
+		return err
+	}
+	if hasRows {
+		log.Warn("not null column added as nullable, backfill it and set not null manually", "table", tableName, "column", column.Name)
+		return nil
+	}
+	if err := dao.gorm.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", quoteIdent(tableName), quoteIdent(column.Name))).Error; err != nil {
+		log.Error("set column not null fail", "table", tableName, "column", column.Name, "err", err)
+		return err
+	}
+	return nil
+}
+
+// CreateIndex 执行完整的建索引语句，语句里的标识符由调用方加好引号
+func (dao *createTableDB) CreateIndex(indexSql string) error {
+	if err := dao.gorm.Exec(indexSql).Error; err != nil {
+		log.Error("create index fail", "sql", indexSql, "err", err)
+		return err
+	}
+	return nil
+}
+
+func (dao *createTableDB) DropTable(tableName string) error {
+	if err := dao.gorm.Exec("DROP TABLE IF EXISTS " + quoteIdent(tableName)).Error; err != nil {
+		log.Error("drop table fail", "table", tableName, "err", err)
+		return err
+	}
+	return nil
+}
+
+// ArchiveTable 把表改名之后移到 archived schema，数据保留但不再被业务使用；archivedName 超过 63 个字符时报错，不让 postgres 静默截断
+func (dao *createTableDB) ArchiveTable(tableName string, archivedName string) error {
+	if len(archivedName) > tenant.MaxIdentifierLength {
+		return fmt.Errorf("archived table name %s is longer than %d characters", archivedName, tenant.MaxIdentifierLength)
+	}
+	if err := dao.gorm.Exec("CREATE SCHEMA IF NOT EXISTS archived").Error; err != nil {
+		return err
+	}
+	if err := dao.gorm.Exec("ALTER TABLE IF EXISTS " + quoteIdent(tableName) + " RENAME TO " + quoteIdent(archivedName)).Error; err != nil {
+		log.Error("rename table fail", "table", tableName, "err", err)
+		return err
+	}
+	if err := dao.gorm.Exec("ALTER TABLE IF EXISTS " + quoteIdent(archivedName) + " SET SCHEMA archived").Error; err != nil {
+		log.Error("archive table fail", "table", archivedName, "err", err)
+		return err
 	}
+	return nil
 }
@@ -23,12 +23,14 @@ func BlockHeaderFromHeader(header *types.Header) syncclient.BlockHeader {
 
 type BlocksView interface {
 	LatestBlocks() (*syncclient.BlockHeader, error)
+	QueryBlocksByNumber(*big.Int) (*syncclient.BlockHeader, error)
 }
 
 type BlocksDB interface {
 	BlocksView
 
 	StoreBlockss([]Blocks) error
+	DeleteBlocksAfterNumber(*big.Int) error
 }
 
 type blocksDB struct {
@@ -55,3 +57,20 @@ func (db *blocksDB) LatestBlocks() (*syncclient.BlockHeader, error) {
 	}
 	return (*syncclient.BlockHeader)(&header), nil
 }
+
+func (db *blocksDB) QueryBlocksByNumber(number *big.Int) (*syncclient.BlockHeader, error) {
+	var header Blocks
+	result := db.gorm.Where("number = ?", number.Uint64()).Take(&header)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return (*syncclient.BlockHeader)(&header), nil
+}
+
+// DeleteBlocksAfterNumber 删除高度大于 number 的区块，用于回滚被重组掉的区块
+func (db *blocksDB) DeleteBlocksAfterNumber(number *big.Int) error {
+	return db.gorm.Where("number > ?", number.Uint64()).Delete(&Blocks{}).Error
+}
@@ -5,29 +5,62 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
 
 	"github.com/ethereum/go-ethereum/log"
 )
 
+const (
+	BusinessStatusActive       = "active"       // 正常
+	BusinessStatusSuspended    = "suspended"    // 暂停通知、提现和手续费追加，链上数据照常入库
+	BusinessStatusDeregistered = "deregistered" // 已注销，业务方的表已归档或删除
+)
+
+// ErrBusinessExist 同一个 business_uid 已经有没有注销的业务方
+var ErrBusinessExist = errors.New("business already exist")
+
 type Business struct {
-	GUID        uuid.UUID `gorm:"primaryKey" json:"guid"`
-	BusinessUid string    `json:"business_uid"`
-	NotifyUrl   string    `json:"notify_url"`
-	CallBackUrl string    `json:"call_back_url"`
-	Timestamp   uint64
+	GUID              uuid.UUID `gorm:"primaryKey" json:"guid"`
+	BusinessUid       string    `json:"business_uid"`
+	NotifyUrl         string    `json:"notify_url"`
+	CallBackUrl       string    `json:"call_back_url"`
+	CoinSelection     string    `json:"coin_selection"`
+	FeePriority       string    `json:"fee_priority"`
+	MinFeeRate        int64     `json:"min_fee_rate"`
+	MaxFeeRate        int64     `json:"max_fee_rate"`
+	MultisigM         int       `json:"multisig_m"`
+	MultisigN         int       `json:"multisig_n"`
+	SafeConfirms      uint64    `json:"safe_confirms"`
+	FinalizedConfirms uint64    `json:"finalized_confirms"`
+	Status            string    `json:"status"`
+	ApiKeyHash        string    `json:"-"`
+	Timestamp         uint64
+}
+
+func (b *Business) Active() bool {
+	return b.Status == BusinessStatusActive
 }
 
 type BusinessView interface {
 	QueryBusinessList() ([]Business, error)
+	QueryActiveBusinessList() ([]Business, error)
 	QueryBusinessByUuid(string) (*Business, error)
+	QueryBusinessByApiKeyHash(apiKeyHash string) (*Business, error)
 }
 
 type BusinessDB interface {
 	BusinessView
 
 	StoreBusiness(*Business) error
+	UpdateBusinessUrls(businessUid string, notifyUrl string, callBackUrl string) error
+	UpdateBusinessStatus(businessUid string, status string) error
+	UpdateBusinessApiKeyHash(businessUid string, apiKeyHash string) error
 }
 
+// sharedBusinessTable 业务方表所有链共用一份，放在 public 里；其他链的连接 search_path 不含 public，访问时总是带上 schema，
+// 其他链的 schema 执行迁移时跳过操作这张表的语句
+const sharedBusinessTable = "public.business"
+
 type businessDB struct {
 	gorm *gorm.DB
 }
@@ -36,14 +69,24 @@ func NewBusinessDB(db *gorm.DB) BusinessDB {
 	return &businessDB{gorm: db}
 }
 
+// StoreBusiness 并发注册同一个 business_uid 时由唯一索引兜底，冲突时返回 ErrBusinessExist
 func (db *businessDB) StoreBusiness(business *Business) error {
-	result := db.gorm.Table("business").Create(business)
-	return result.Error
+	result := db.gorm.Table(sharedBusinessTable).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(business)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBusinessExist
+	}
+	return nil
 }
 
+// QueryBusinessList 查询所有没有注销的业务方（包括暂停的），扫块和内存池按这个列表入库链上数据
 func (db *businessDB) QueryBusinessList() ([]Business, error) {
 	var business []Business
-	err := db.gorm.Table("business").Find(&business).Error
+	err := db.gorm.Table(sharedBusinessTable).Where("status <> ?", BusinessStatusDeregistered).Find(&business).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, gorm.ErrRecordNotFound
@@ -53,12 +96,70 @@ func (db *businessDB) QueryBusinessList() ([]Business, error) {
 	return business, err
 }
 
+// QueryActiveBusinessList 查询正常状态的业务方，通知、提现和手续费追加只处理这些业务方
+func (db *businessDB) QueryActiveBusinessList() ([]Business, error) {
+	var business []Business
+	err := db.gorm.Table(sharedBusinessTable).Where("status = ?", BusinessStatusActive).Find(&business).Error
+	if err != nil {
+		return nil, err
+	}
+	return business, nil
+}
+
 func (db *businessDB) QueryBusinessByUuid(businessUid string) (*Business, error) {
 	var business *Business
-	result := db.gorm.Table("business").Where("business_uid", businessUid).First(&business)
+	result := db.gorm.Table(sharedBusinessTable).Where("business_uid = ? AND status <> ?", businessUid, BusinessStatusDeregistered).First(&business)
 	if result.Error != nil {
 		log.Error("query business all fail", "Err", result.Error)
 		return nil, result.Error
 	}
 	return business, nil
 }
+
+func (db *businessDB) UpdateBusinessUrls(businessUid string, notifyUrl string, callBackUrl string) error {
+	result := db.gorm.Table(sharedBusinessTable).
+		Where("business_uid = ? AND status <> ?", businessUid, BusinessStatusDeregistered).
+		Updates(map[string]interface{}{"notify_url": notifyUrl, "call_back_url": callBackUrl})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (db *businessDB) UpdateBusinessStatus(businessUid string, status string) error {
+	result := db.gorm.Table(sharedBusinessTable).
+		Where("business_uid = ? AND status <> ?", businessUid, BusinessStatusDeregistered).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (db *businessDB) QueryBusinessByApiKeyHash(apiKeyHash string) (*Business, error) {
+	var business Business
+	result := db.gorm.Table(sharedBusinessTable).Where("api_key_hash = ? AND status <> ?", apiKeyHash, BusinessStatusDeregistered).Take(&business)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &business, nil
+}
+
+func (db *businessDB) UpdateBusinessApiKeyHash(businessUid string, apiKeyHash string) error {
+	result := db.gorm.Table(sharedBusinessTable).
+		Where("business_uid = ? AND status <> ?", businessUid, BusinessStatusDeregistered).
+		Update("api_key_hash", apiKeyHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// 查询接口缓存的数据类别，写入方按类别通知 rpc 进程失效缓存
+const (
+	ApiCacheDeposits     = "deposits"
+	ApiCacheWithdraws    = "withdraws"
+	ApiCacheInternals    = "internals"
+	ApiCacheTransactions = "transactions"
+	ApiCacheBalances     = "balances"
+)
+
+// apiCacheChannel 扫块、通知等进程和 rpc 进程不是同一个进程，通过 postgres NOTIFY 广播缓存失效
+const apiCacheChannel = "api_cache_invalidate"
+
+// NotifyApiCacheInvalidate 在事务里调用时，通知随事务提交发出，回滚则不发
+func (db *DB) NotifyApiCacheInvalidate(businessId string, kinds ...string) error {
+	return db.gorm.Exec("SELECT pg_notify(?, ?)", apiCacheChannel, encodeApiCacheInvalidation(businessId, kinds)).Error
+}
+
+// ListenApiCacheInvalidate 阻塞监听缓存失效通知直到 ctx 取消，连接断开时自动重连；
+// 断开期间可能丢失通知，重连后以空的 businessId 回调一次，表示失效全部缓存
+func (db *DB) ListenApiCacheInvalidate(ctx context.Context, fn func(businessId string, kinds []string)) error {
+	backoff := time.Second
+	for {
+		err := db.listenApiCache(ctx, func(businessId string, kinds []string) {
+			backoff = time.Second
+			fn(businessId, kinds)
+		}, func() {
+			fn("", nil)
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Warn("api cache listener disconnected, retry", "err", err, "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (db *DB) listenApiCache(ctx context.Context, fn func(businessId string, kinds []string), onListen func()) error {
+	sqlDB, err := db.gorm.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("api cache listener requires the pgx driver")
+		}
+		pgxConn := stdConn.Conn()
+		if _, err := pgxConn.Exec(ctx, "LISTEN "+apiCacheChannel); err != nil {
+			return err
+		}
+		onListen()
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			businessId, kinds, ok := decodeApiCacheInvalidation(notification.Payload)
+			if !ok {
+				log.Warn("invalid api cache notification", "payload", notification.Payload)
+				continue
+			}
+			fn(businessId, kinds)
+		}
+	})
+}
+
+// encodeApiCacheInvalidation 通知内容为 <businessId>:<kind>,<kind>，没有类别表示失效业务方的全部缓存
+func encodeApiCacheInvalidation(businessId string, kinds []string) string {
+	return businessId + ":" + strings.Join(kinds, ",")
+}
+
+// decodeApiCacheInvalidation 缓存类型里没有冒号，按最后一个冒号拆分，业务方 id 里带冒号也能正确解析
+func decodeApiCacheInvalidation(payload string) (string, []string, bool) {
+	index := strings.LastIndex(payload, ":")
+	if index <= 0 {
+		return "", nil, false
+	}
+	businessId, kindList := payload[:index], payload[index+1:]
+	if kindList == "" {
+		return businessId, nil, true
+	}
+	return businessId, strings.Split(kindList, ","), true
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApiCacheInvalidationPayload(t *testing.T) {
+	payload := encodeApiCacheInvalidation("biz", []string{ApiCacheDeposits, ApiCacheBalances})
+	businessId, kinds, ok := decodeApiCacheInvalidation(payload)
+	require.True(t, ok)
+	require.Equal(t, "biz", businessId)
+	require.Equal(t, []string{ApiCacheDeposits, ApiCacheBalances}, kinds)
+
+	businessId, kinds, ok = decodeApiCacheInvalidation(encodeApiCacheInvalidation("biz", nil))
+	require.True(t, ok)
+	require.Equal(t, "biz", businessId)
+	require.Empty(t, kinds)
+
+	businessId, kinds, ok = decodeApiCacheInvalidation(encodeApiCacheInvalidation("legacy:biz", []string{ApiCacheDeposits}))
+	require.True(t, ok)
+	require.Equal(t, "legacy:biz", businessId)
+	require.Equal(t, []string{ApiCacheDeposits}, kinds)
+
+	for _, invalid := range []string{"", "biz", ":deposits"} {
+		_, _, ok = decodeApiCacheInvalidation(invalid)
+		require.False(t, ok)
+	}
+}
@@ -22,12 +22,15 @@ type ChildTxs struct {
 
 type ChildTxsView interface {
 	QueryChildTxnByTxId(string, string) ([]ChildTxs, error)
+	QueryChildTxsByHash(businessId string, hash string) ([]ChildTxs, error)
+	QueryChildTxsPage(businessId string, query TxQuery) ([]ChildTxs, string, error)
 }
 
 type ChildTxsDB interface {
 	ChildTxsView
 
 	StoreChildTxs(string, []ChildTxs) error
+	DeleteChildTxsByHashes(string, []string) error
 }
 
 type childTxsDB struct {
@@ -56,3 +59,43 @@ func (c childTxsDB) QueryChildTxnByTxId(businessId string, txId string) ([]Child
 	}
 	return childTxList, nil
 }
+
+func (c childTxsDB) DeleteChildTxsByHashes(businessId string, hashes []string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+	return c.gorm.Table("child_txs_"+businessId).Where("hash IN ?", hashes).Delete(&ChildTxs{}).Error
+}
+
+func (c childTxsDB) QueryChildTxsByHash(businessId string, hash string) ([]ChildTxs, error) {
+	var childTxList []ChildTxs
+	err := c.gorm.Table("child_txs_"+businessId).Where("hash = ?", hash).Order("tx_index").Find(&childTxList).Error
+	if err != nil {
+		log.Error("query child txs by hash fail", "err", err)
+		return nil, err
+	}
+	return childTxList, nil
+}
+
+// QueryChildTxsPage 分页查询子交易，hash 同时匹配交易哈希和所属提现/内部交易的 guid；子交易没有状态和高度，只按地址、类型和时间过滤
+func (c childTxsDB) QueryChildTxsPage(businessId string, query TxQuery) ([]ChildTxs, string, error) {
+	tx := c.gorm.Table("child_txs_" + businessId)
+	if query.Hash != "" {
+		tx = tx.Where("hash = ? OR tx_id = ?", query.Hash, query.Hash)
+	}
+	if query.Address != "" {
+		tx = tx.Where("from_address = ? OR to_address = ?", query.Address, query.Address)
+	}
+	if query.TxType != "" {
+		tx = tx.Where("tx_type = ?", query.TxType)
+	}
+	tx = applyTimeQuery(tx, query)
+	childTxList, nextCursor, err := queryPage(tx, query, func(childTx ChildTxs) (uint64, string) {
+		return childTx.Timestamp, childTx.GUID.String()
+	})
+	if err != nil {
+		log.Error("query child txs page fail", "err", err)
+		return nil, "", err
+	}
+	return childTxList, nextCursor, nil
+}
@@ -1,5 +1,13 @@
 package database
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
 type TxStatus string
 
 // 提现是没有确认位的
@@ -36,6 +44,73 @@ const (
 
 	TxStatusInternalCallBack TxStatus = "send_to_business_for_sign"
 
+	TxStatusDropped           TxStatus = "dropped"                // 内存池中的交易被替换或者被驱逐
+	TxStatusDroppedNotify     TxStatus = "dropped_notify_success" // 交易被丢弃通知成功
+	TxStatusDroppedNotifyFail TxStatus = "dropped_notify_fail"    // 交易被丢弃通知失败
+
 	//====================子交易的状体==========================
 
 )
+
+// NotifyStatus 返回交易通知业务方之后应该进入的状态，notifySuccess 表示业务方是否确认收到通知
+func NotifyStatus(status TxStatus, notifySuccess bool) TxStatus {
+	pick := func(success, fail TxStatus) TxStatus {
+		if notifySuccess {
+			return success
+		}
+		return fail
+	}
+	switch status {
+	case TxStatusUnSafe, TxStatusUnSafeNotifyFail:
+		return pick(TxStatusUnSafeNotify, TxStatusUnSafeNotifyFail)
+	case TxStatusSafe, TxStatusSafeNotifyFail:
+		return pick(TxStatusSafeNotify, TxStatusSafeNotifyFail)
+	case TxStatusFinalized, TxStatusFinalizedNotifyFail:
+		return pick(TxStatusFinalizedNotify, TxStatusFinalizedNotifyFail)
+	case TxStatusSent, TxStatusSentNotifyFail:
+		return pick(TxStatusSentNotify, TxStatusSentNotifyFail)
+	case TxStatusWithdrawed, TxStatusWithdrawedNotifyFail:
+		return pick(TxStatusWithdrawedNotify, TxStatusWithdrawedNotifyFail)
+	case TxStatusFail, TxStatusFailNotifyFail:
+		return pick(TxStatusFailNotify, TxStatusFailNotifyFail)
+	case TxStatusFallback, TxStatusFallbackNotifyFail:
+		return pick(TxStatusFallbackNotify, TxStatusFallbackNotifyFail)
+	case TxStatusDropped, TxStatusDroppedNotifyFail:
+		return pick(TxStatusDroppedNotify, TxStatusDroppedNotifyFail)
+	default:
+		return status
+	}
+}
+
+// FallbackStatusList 已经进入回滚流程的状态，回滚检测时需要跳过
+var FallbackStatusList = []TxStatus{
+	TxStatusFallback,
+	TxStatusFallbackNotify,
+	TxStatusFallbackNotifyFail,
+	TxStatusFallbackDone,
+}
+
+// orphanedBlockHashSql 交易所在区块已经被重组掉（在 reorg_blocks 中且不在 blocks 中）
+const orphanedBlockHashSql = "block_hash IN (SELECT hash FROM reorg_blocks) AND block_hash NOT IN (SELECT hash FROM blocks)"
+
+// ErrStatusChanged 条件更新状态时，部分记录的状态已经被其他流程（例如回滚检测）改掉，这些记录没有被覆盖
+var ErrStatusChanged = errors.New("status changed by another process")
+
+// updateStatusFrom 按读取时的状态分组做条件更新，只有状态仍然是读取时的值才会改成 status；
+// 实际更新的行数少于预期时返回 ErrStatusChanged，由调用方决定跳过还是回滚整个事务
+func updateStatusFrom(tx *gorm.DB, tableName string, status TxStatus, guidsByStatus map[TxStatus][]uuid.UUID, expected int) (int64, error) {
+	var updated int64
+	for fromStatus, guids := range guidsByStatus {
+		result := tx.Table(tableName).
+			Where("guid IN ? AND status = ?", guids, fromStatus).
+			Update("status", status)
+		if result.Error != nil {
+			return updated, fmt.Errorf("batch update status failed: %w", result.Error)
+		}
+		updated += result.RowsAffected
+	}
+	if updated < int64(expected) {
+		return updated, fmt.Errorf("%w: %s updated %d of %d rows to %s", ErrStatusChanged, tableName, updated, expected, status)
+	}
+	return updated, nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrParentOutputReserved 父交易输出已经被另一笔未作废的 CPFP 子交易占用
+var ErrParentOutputReserved = errors.New("parent output already reserved by another cpfp transaction")
+
+// CpfpReservations CPFP 子交易占用的父交易输出
+type CpfpReservations struct {
+	ParentHash      string    `gorm:"primaryKey" json:"parent_hash"`
+	ParentVoutIndex uint32    `gorm:"primaryKey" json:"parent_vout_index"`
+	InternalGuid    uuid.UUID `json:"internal_guid"`
+	Timestamp       uint64    `json:"timestamp"`
+}
+
+type CpfpReservationsDB interface {
+	ReserveParentOutput(businessId string, reservation *CpfpReservations) error
+	ReleaseParentOutput(businessId string, internalGuid uuid.UUID) error
+}
+
+type cpfpReservationsDB struct {
+	gorm *gorm.DB
+}
+
+func NewCpfpReservationsDB(db *gorm.DB) CpfpReservationsDB {
+	return &cpfpReservationsDB{gorm: db}
+}
+
+// ReserveParentOutput 占用父交易输出，输出已经被占用时返回 ErrParentOutputReserved
+func (db *cpfpReservationsDB) ReserveParentOutput(businessId string, reservation *CpfpReservations) error {
+	result := db.gorm.Table("cpfp_reservations_" + businessId).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(reservation)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrParentOutputReserved
+	}
+	return nil
+}
+
+// ReleaseParentOutput 子交易作废后释放它占用的父交易输出
+func (db *cpfpReservationsDB) ReleaseParentOutput(businessId string, internalGuid uuid.UUID) error {
+	return db.gorm.Table("cpfp_reservations_"+businessId).
+		Where("internal_guid = ?", internalGuid).
+		Delete(&CpfpReservations{}).Error
+}
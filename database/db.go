@@ -3,10 +3,8 @@ package database
 import (
 	"context"
 	"fmt"
-	"os"
-	"path/filepath"
 
-	"github.com/pkg/errors"
+	"github.com/ethereum/go-ethereum/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -16,7 +14,8 @@ import (
 )
 
 type DB struct {
-	gorm *gorm.DB
+	gorm   *gorm.DB
+	schema string
 
 	CreateTable  CreateTableDB
 	Blocks       BlocksDB
@@ -31,9 +30,65 @@ type DB struct {
 	Vins         VinsDB
 	Vouts        VoutsDB
 	ChildTxs     ChildTxsDB
+	Utxos        UtxoDB
+
+	WithdrawReplacements WithdrawReplacementsDB
+	CpfpReservations     CpfpReservationsDB
+
+	view    *ViewDB
+	replica *replica
+}
+
+// ViewDB 只读查询，开启从库时由 DB.Reader 路由到从库
+type ViewDB struct {
+	Blocks       BlocksView
+	Addresses    AddressesView
+	Business     BusinessView
+	Deposits     DepositsView
+	Withdraws    WithdrawsView
+	Internals    InternalsView
+	Transactions TransactionsView
+	Vins         VinsView
+	Vouts        VoutsView
+	ChildTxs     ChildTxsView
+	Utxos        UtxoView
 }
 
 func NewDB(ctx context.Context, dbConfig config.DBConfig) (*DB, error) {
+	gorm, err := openDB(ctx, dbConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSchema(gorm, dbConfig.Schema); err != nil {
+		return nil, err
+	}
+	return newDB(gorm, dbConfig.Schema), nil
+}
+
+// NewReadWriteDB 连接主库，开启从库时同时连接从库，Reader 返回的只读查询在从库延迟不超过阈值时走从库
+func NewReadWriteDB(ctx context.Context, cfg *config.Config) (*DB, error) {
+	db, err := NewDB(ctx, cfg.MasterDB)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.SlaveDbEnable {
+		return db, nil
+	}
+	slave, err := openDB(ctx, cfg.SlaveDB)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := checkSchema(slave, cfg.SlaveDB.Schema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	db.replica = newReplica(slave, cfg.SlaveDB.Schema, cfg.SlaveDbMaxLag, cfg.SlaveDbLagCheck)
+	log.Info("slave database enabled", "host", cfg.SlaveDB.Host, "maxLag", cfg.SlaveDbMaxLag)
+	return db, nil
+}
+
+func openDB(ctx context.Context, dbConfig config.DBConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("host=%s dbname=%s sslmode=disable", dbConfig.Host, dbConfig.Name)
 	if dbConfig.Port != 0 {
 		dsn += fmt.Sprintf(" port=%d", dbConfig.Port)
@@ -44,6 +99,9 @@ func NewDB(ctx context.Context, dbConfig config.DBConfig) (*DB, error) {
 	if dbConfig.Password != "" {
 		dsn += fmt.Sprintf(" password=%s", dbConfig.Password)
 	}
+	if dbConfig.Schema != "" {
+		dsn += " search_path=" + searchPath(dbConfig.Schema)
+	}
 
 	gormConfig := gorm.Config{
 		SkipDefaultTransaction: true,
@@ -51,20 +109,31 @@ func NewDB(ctx context.Context, dbConfig config.DBConfig) (*DB, error) {
 	}
 
 	retryStrategy := &retry2.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
-	gorm, err := retry2.Do[*gorm.DB](context.Background(), 10, retryStrategy, func() (*gorm.DB, error) {
+	gorm, err := retry2.Do[*gorm.DB](ctx, 10, retryStrategy, func() (*gorm.DB, error) {
 		gorm, err := gorm.Open(postgres.Open(dsn), &gormConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to database: %w", err)
 		}
 		return gorm, nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
+	sqlDB, err := gorm.DB()
 	if err != nil {
 		return nil, err
 	}
+	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+	return gorm, nil
+}
 
-	db := &DB{
+func newDB(gorm *gorm.DB, schema string) *DB {
+	return &DB{
 		gorm:         gorm,
+		schema:       schema,
 		CreateTable:  NewCreateTableDB(gorm),
 		Blocks:       NewBlocksDB(gorm),
 		ReorgBlocks:  NewReorgBlocksDB(gorm),
@@ -78,57 +147,136 @@ func NewDB(ctx context.Context, dbConfig config.DBConfig) (*DB, error) {
 		Vins:         NewVinsDB(gorm),
 		Vouts:        NewVoutsDB(gorm),
 		ChildTxs:     NewChildTxsDB(gorm),
+		Utxos:        NewUtxoDB(gorm),
+
+		WithdrawReplacements: NewWithdrawReplacementsDB(gorm),
+		CpfpReservations:     NewCpfpReservationsDB(gorm),
+
+		view: newViewDB(gorm, schema),
 	}
-	return db, nil
 }
 
+func newViewDB(gorm *gorm.DB, schema string) *ViewDB {
+	return &ViewDB{
+		Blocks:       NewBlocksDB(gorm),
+		Addresses:    NewAddressesDB(gorm),
+		Business:     NewBusinessDB(gorm),
+		Deposits:     NewDepositsDB(gorm),
+		Withdraws:    NewWithdrawsDB(gorm),
+		Internals:    NewInternalsDB(gorm),
+		Transactions: NewTransactionsDB(gorm),
+		Vins:         NewVinsDB(gorm),
+		Vouts:        NewVoutsDB(gorm),
+		ChildTxs:     NewChildTxsDB(gorm),
+		Utxos:        NewUtxoDB(gorm),
+	}
+}
+
+// Reader 返回只读查询，从库可用且延迟不超过阈值时走从库，否则走主库；事务里始终是当前事务
+func (db *DB) Reader() *ViewDB {
+	if db.replica != nil && db.replica.usable() {
+		return db.replica.view
+	}
+	return db.view
+}
+
+// ReplicaLagging 开启从库且从库没有追平主库时返回 true，此时从库读到的数据可能比缓存失效通知旧
+func (db *DB) ReplicaLagging() bool {
+	return db.replica != nil && db.replica.lagging()
+}
+
+// Transaction 事务里的读写都在主库上执行
 func (db *DB) Transaction(fn func(db *DB) error) error {
 	return db.gorm.Transaction(func(tx *gorm.DB) error {
-		txDB := &DB{
-			gorm:         tx,
-			Blocks:       NewBlocksDB(tx),
-			ReorgBlocks:  NewReorgBlocksDB(tx),
-			Addresses:    NewAddressesDB(tx),
-			Balances:     NewBalancesDB(tx),
-			Business:     NewBusinessDB(tx),
-			Deposits:     NewDepositsDB(tx),
-			Withdraws:    NewWithdrawsDB(tx),
-			Internals:    NewInternalsDB(tx),
-			Transactions: NewTransactionsDB(tx),
-			Vins:         NewVinsDB(tx),
-			Vouts:        NewVoutsDB(tx),
-			ChildTxs:     NewChildTxsDB(tx),
-		}
-		return fn(txDB)
+		return fn(newDB(tx, db.schema))
 	})
 }
 
-func (db *DB) Close() error {
+// WithContext 返回绑定 ctx 的主库连接，ctx 取消或超时后正在执行的查询随之中断
+func (db *DB) WithContext(ctx context.Context) *DB {
+	return newDB(db.gorm.WithContext(ctx), db.schema)
+}
+
+// Schema 这条链的表所在的 schema，空串代表默认 schema
+func (db *DB) Schema() string {
+	return db.schema
+}
+
+// InSchema 把当前事务的 search_path 切到另一条链的 schema 执行 fn，结束后恢复；
+// 只能在 Transaction 里调用，用于在一个事务里处理所有链的业务方表
+func (db *DB) InSchema(schema string, fn func(db *DB) error) error {
+	if schema == db.schema {
+		return fn(db)
+	}
+	var previous string
+	if err := db.gorm.Raw("SELECT current_setting('search_path')").Scan(&previous).Error; err != nil {
+		return err
+	}
+	if err := db.gorm.Exec("SELECT set_config('search_path', ?, true)", searchPath(schema)).Error; err != nil {
+		log.Error("switch schema fail", "schema", schema, "err", err)
+		return err
+	}
+	err := fn(newDB(db.gorm, schema))
+	if resetErr := db.gorm.Exec("SELECT set_config('search_path', ?, true)", previous).Error; err == nil {
+		err = resetErr
+	}
+	return err
+}
+
+// CreateSchema 迁移前创建其他链使用的 schema
+func (db *DB) CreateSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	if err := db.gorm.Exec("CREATE SCHEMA IF NOT EXISTS " + quoteIdent(schema)).Error; err != nil {
+		log.Error("create schema fail", "schema", schema, "err", err)
+		return err
+	}
+	return nil
+}
+
+// checkSchema 连接时确认链的 schema 已经创建
+func checkSchema(gorm *gorm.DB, schema string) error {
+	if schema == "" {
+		return nil
+	}
+	var current string
+	if err := gorm.Raw("SELECT COALESCE(current_schema(), '')").Scan(&current).Error; err != nil {
+		return err
+	}
+	if current != schema {
+		return fmt.Errorf("schema %q does not exist, run the migrate command first", schema)
+	}
+	return nil
+}
+
+// searchPath 其他链的连接只搜索链自己的 schema，表缺失时直接报错，不会落到 public 里主链的同名表；
+// 业务方表用 public.business 访问，迁移需要的 UINT256 等公共类型由迁移事务临时加上 public
+func searchPath(schema string) string {
+	if schema == "" {
+		return "\"$user\",public"
+	}
+	return quoteIdent(schema)
+}
+
+// Ping 检查主库连接是否可用
+func (db *DB) Ping(ctx context.Context) error {
 	sql, err := db.gorm.DB()
 	if err != nil {
 		return err
 	}
-	return sql.Close()
+	return sql.PingContext(ctx)
 }
 
-func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
-	err := filepath.Walk(migrationsFolder, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("Failed to process migration file: %s", path))
-		}
-		if info.IsDir() {
-			return nil
-		}
-		fileContent, readErr := os.ReadFile(path)
-		if readErr != nil {
-			return errors.Wrap(readErr, fmt.Sprintf("Error reading SQL file: %s", path))
-		}
-
-		execErr := db.gorm.Exec(string(fileContent)).Error
-		if execErr != nil {
-			return errors.Wrap(execErr, fmt.Sprintf("Error executing SQL script: %s", path))
+func (db *DB) Close() error {
+	if db.replica != nil {
+		if err := db.replica.close(); err != nil {
+			log.Error("close slave database fail", "err", err)
 		}
-		return nil
-	})
-	return err
+	}
+	sql, err := db.gorm.DB()
+	if err != nil {
+		return err
+	}
+	return sql.Close()
 }
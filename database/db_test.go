@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithContext(t *testing.T) {
+	db, _ := newFakeMigrationDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// 绑定的 ctx 已取消，查询不会发到数据库
+	_, err := db.WithContext(ctx).Blocks.LatestBlocks()
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, db.Schema(), db.WithContext(ctx).Schema())
+}
+
+func TestInSchema(t *testing.T) {
+	db, state := newFakeMigrationDB(t)
+	err := db.Transaction(func(tx *DB) error {
+		return tx.InSchema("ltc", func(inner *DB) error {
+			require.Equal(t, "ltc", inner.Schema())
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	// 切到链的 schema，结束后恢复原来的 search_path
+	require.Equal(t, []string{`"ltc"`, `"$user",public`}, state.searchPaths)
+
+	// 已经在目标 schema 里时不切换
+	state.searchPaths = nil
+	err = db.Transaction(func(tx *DB) error {
+		return tx.InSchema("", func(inner *DB) error {
+			require.Same(t, tx, inner)
+			return errors.New("rollback")
+		})
+	})
+	require.EqualError(t, err, "rollback")
+	require.Empty(t, state.searchPaths)
+}
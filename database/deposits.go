@@ -2,7 +2,9 @@ package database
 
 import (
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -24,14 +26,21 @@ type Deposits struct {
 
 type DepositsView interface {
 	QueryNotifyDeposits(string) ([]Deposits, error)
+	QueryFallbackDeposits(string) ([]Deposits, error)
+	QueryDepositsByStatus(requestId string, status TxStatus) ([]Deposits, error)
+	QueryDepositByHash(requestId string, hash string) (*Deposits, error)
+	QueryMempoolDeposits(requestId string) ([]Deposits, error)
+	QueryDepositsPage(requestId string, query TxQuery) ([]Deposits, string, error)
 }
 
 type DepositsDB interface {
 	DepositsView
 
 	StoreDeposits(string, []Deposits) error
-	UpdateDepositsComfirms(requestId string, blockNumber uint64, confirms uint64) error
-	UpdateDepositsNotifyStatus(requestId string, status uint8, depositList []Deposits) error
+	UpdateDepositsComfirms(requestId string, chainTip uint64, safeConfirms uint64, finalizedConfirms uint64) error
+	UpdateDepositsStatus(requestId string, status TxStatus, depositList []Deposits) error
+	ReconcileMempoolDeposits(requestId string, depositList []Deposits) ([]Deposits, error)
+	DropMempoolDeposits(requestId string, depositList []Deposits) (int64, error)
 }
 
 type depositsDB struct {
@@ -53,7 +62,15 @@ func (db *depositsDB) StoreDeposits(requestId string, depositList []Deposits) er
 
 func (db *depositsDB) QueryNotifyDeposits(requestId string) ([]Deposits, error) {
 	var notifyDeposits []Deposits
-	result := db.gorm.Table("deposits_"+requestId).Where("status = ? or status = ?", 0, 1).Find(notifyDeposits)
+	// 充值每进入一个确认阶段（含内存池里零确认的 unsafe）都通知业务方一次
+	notifyStatus := []TxStatus{
+		TxStatusUnSafe, TxStatusUnSafeNotifyFail,
+		TxStatusSafe, TxStatusSafeNotifyFail,
+		TxStatusFinalized, TxStatusFinalizedNotifyFail,
+		TxStatusFallback, TxStatusFallbackNotifyFail,
+		TxStatusDropped, TxStatusDroppedNotifyFail,
+	}
+	result := db.gorm.Table("deposits_"+requestId).Where("status IN ?", notifyStatus).Find(&notifyDeposits)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, gorm.ErrRecordNotFound
@@ -63,10 +80,17 @@ func (db *depositsDB) QueryNotifyDeposits(requestId string) ([]Deposits, error)
 	return notifyDeposits, nil
 }
 
-// UpdateDepositsComfirms 查询所有还没有过确认位交易，用最新区块减去对应区块更新确认，如果这个大于我们预设的确认位，那么这笔交易可以认为已经入账
-func (db *depositsDB) UpdateDepositsComfirms(requestId string, blockNumber uint64, confirms uint64) error {
+// UpdateDepositsComfirms 用链上最新高度计算已上链充值的确认数，并推进确认阶段 unsafe -> safe -> finalized；
+// 上一个阶段通知业务方成功之后才会进入下一个阶段，保证每个阶段按顺序只通知一次
+func (db *depositsDB) UpdateDepositsComfirms(requestId string, chainTip uint64, safeConfirms uint64, finalizedConfirms uint64) error {
 	var unConfirmDeposits []Deposits
-	result := db.gorm.Table("deposits_"+requestId).Where("block_number <= ? and status = ?", blockNumber, 0).Find(&unConfirmDeposits)
+	pendingStatus := []TxStatus{
+		TxStatusUnSafe, TxStatusUnSafeNotify, TxStatusUnSafeNotifyFail,
+		TxStatusSafe, TxStatusSafeNotify, TxStatusSafeNotifyFail,
+	}
+	result := db.gorm.Table("deposits_"+requestId).
+		Where("block_number > 0 AND block_number <= ? AND status IN ?", chainTip, pendingStatus).
+		Find(&unConfirmDeposits)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil
@@ -74,36 +98,172 @@ func (db *depositsDB) UpdateDepositsComfirms(requestId string, blockNumber uint6
 		return result.Error
 	}
 	for _, deposit := range unConfirmDeposits {
-		chainConfirm := blockNumber - deposit.BlockNumber.Uint64()
-		if chainConfirm >= confirms {
-			deposit.Confirms = uint8(confirms)
-			deposit.Status = TxStatusFinalized // 已经过了确认位
-		} else {
-			deposit.Confirms = uint8(chainConfirm)
+		chainConfirm := chainTip - deposit.BlockNumber.Uint64() + 1
+		confirms := uint8(math.MaxUint8)
+		if chainConfirm < math.MaxUint8 {
+			confirms = uint8(chainConfirm)
+		}
+		status := nextDepositStatus(deposit.Status, chainConfirm, safeConfirms, finalizedConfirms)
+		if confirms == deposit.Confirms && status == deposit.Status {
+			continue
 		}
-		err := db.gorm.Table("deposits_" + requestId).Save(&deposit).Error
+		// 只在状态没有被通知流程改动时更新，避免覆盖并发写入的通知结果
+		err := db.gorm.Table("deposits_"+requestId).
+			Where("guid = ? AND status = ?", deposit.GUID, deposit.Status).
+			Updates(map[string]interface{}{"confirms": confirms, "status": status}).Error
 		if err != nil {
 			return err
 		}
+		if status != deposit.Status {
+			log.Info("deposit confirmation stage changed", "requestId", requestId, "hash", deposit.Hash, "confirms", chainConfirm, "from", deposit.Status, "to", status)
+		}
 	}
 	return nil
 }
 
-func (db *depositsDB) UpdateDepositsNotifyStatus(requestId string, status uint8, depositList []Deposits) error {
-	for i := 0; i < len(depositList); i++ {
-		var depositSingle = Deposits{}
-		result := db.gorm.Table("deposits_" + requestId).Where(&Transactions{Hash: depositList[i].Hash}).Take(&depositSingle)
-		if result.Error != nil {
-			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-				return nil
-			}
-			return result.Error
+// nextDepositStatus 已通知 unsafe 的充值达到 safe 阈值进入 safe，已通知 safe 的达到 finalized 阈值进入 finalized
+func nextDepositStatus(status TxStatus, confirms uint64, safeConfirms uint64, finalizedConfirms uint64) TxStatus {
+	switch status {
+	case TxStatusUnSafeNotify:
+		if confirms >= safeConfirms {
+			return TxStatusSafe
 		}
-		depositSingle.Status = TxStatusFinalizedNotify
-		err := db.gorm.Table("transactions_" + requestId).Save(&depositSingle).Error
-		if err != nil {
-			return err
+	case TxStatusSafeNotify:
+		if confirms >= finalizedConfirms {
+			return TxStatusFinalized
 		}
 	}
+	return status
+}
+
+// UpdateDepositsStatus 只更新状态仍然是读取时的值的记录，状态已被其他流程改掉的记录返回 ErrStatusChanged
+func (db *depositsDB) UpdateDepositsStatus(requestId string, status TxStatus, depositList []Deposits) error {
+	if len(depositList) == 0 {
+		return nil
+	}
+	guidsByStatus := make(map[TxStatus][]uuid.UUID)
+	for _, deposit := range depositList {
+		guidsByStatus[deposit.Status] = append(guidsByStatus[deposit.Status], deposit.GUID)
+	}
+	updated, err := updateStatusFrom(db.gorm, "deposits_"+requestId, status, guidsByStatus, len(depositList))
+	if errors.Is(err, ErrStatusChanged) {
+		log.Warn("Some deposits status changed concurrently", "requestId", requestId, "count", updated, "expectedCount", len(depositList))
+		return err
+	} else if err != nil {
+		return err
+	}
+	log.Info("Batch update deposits status success", "requestId", requestId, "count", updated, "status", status)
 	return nil
 }
+
+// QueryFallbackDeposits 查询所在区块已经被重组掉、还没有进入回滚流程的充值交易
+func (db *depositsDB) QueryFallbackDeposits(requestId string) ([]Deposits, error) {
+	var fallbackDeposits []Deposits
+	result := db.gorm.Table("deposits_"+requestId).
+		Where(orphanedBlockHashSql).
+		Where("status NOT IN ?", FallbackStatusList).
+		Find(&fallbackDeposits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return fallbackDeposits, nil
+}
+
+func (db *depositsDB) QueryDepositsByStatus(requestId string, status TxStatus) ([]Deposits, error) {
+	var depositList []Deposits
+	result := db.gorm.Table("deposits_"+requestId).Where("status = ?", status).Find(&depositList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return depositList, nil
+}
+
+func (db *depositsDB) QueryDepositByHash(requestId string, hash string) (*Deposits, error) {
+	var deposit Deposits
+	result := db.gorm.Table("deposits_"+requestId).Where("hash = ?", hash).Take(&deposit)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+	return &deposit, nil
+}
+
+// QueryMempoolDeposits 查询还在内存池中、没有被丢弃的充值
+func (db *depositsDB) QueryMempoolDeposits(requestId string) ([]Deposits, error) {
+	var depositList []Deposits
+	droppedStatus := []TxStatus{TxStatusDropped, TxStatusDroppedNotify, TxStatusDroppedNotifyFail}
+	result := db.gorm.Table("deposits_"+requestId).
+		Where("block_number = 0 AND status NOT IN ?", droppedStatus).
+		Find(&depositList)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return depositList, nil
+}
+
+// ReconcileMempoolDeposits 区块中扫到的充值如果之前在内存池中已经入库，就把原记录补上区块信息开始计算确认数，
+// 已经通知过的 unsafe 不再重复通知，被标记为丢弃之后又重新上链的回到 unsafe 重新通知；返回没有匹配到内存池记录、需要新写入的充值
+func (db *depositsDB) ReconcileMempoolDeposits(requestId string, depositList []Deposits) ([]Deposits, error) {
+	var newDeposits []Deposits
+	droppedStatus := []TxStatus{TxStatusDropped, TxStatusDroppedNotify, TxStatusDroppedNotifyFail}
+	for _, deposit := range depositList {
+		updates := map[string]interface{}{
+			"block_hash":   deposit.BlockHash,
+			"block_number": deposit.BlockNumber.String(),
+			"confirms":     0,
+			"status":       gorm.Expr("CASE WHEN status IN ? THEN ? ELSE status END", droppedStatus, TxStatusUnSafe),
+		}
+		if deposit.Fee != nil {
+			updates["fee"] = deposit.Fee.String()
+		}
+		result := db.gorm.Table("deposits_"+requestId).Where("hash = ? AND block_number = 0", deposit.Hash).Updates(updates)
+		if result.Error != nil {
+			return nil, fmt.Errorf("reconcile mempool deposit failed: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			newDeposits = append(newDeposits, deposit)
+		} else {
+			log.Info("mempool deposit mined", "requestId", requestId, "hash", deposit.Hash, "blockNumber", deposit.BlockNumber)
+		}
+	}
+	return newDeposits, nil
+}
+
+// DropMempoolDeposits 把内存池里已经查不到的充值标记为 dropped；只更新仍然是读取时的 unsafe 状态且还没有区块哈希的记录，
+// 查询期间已经被扫块确认的充值不会被改掉，返回实际标记的数量
+func (db *depositsDB) DropMempoolDeposits(requestId string, depositList []Deposits) (int64, error) {
+	if len(depositList) == 0 {
+		return 0, nil
+	}
+	guidsByStatus := make(map[TxStatus][]uuid.UUID)
+	for _, deposit := range depositList {
+		guidsByStatus[deposit.Status] = append(guidsByStatus[deposit.Status], deposit.GUID)
+	}
+	unsafeStatus := []TxStatus{TxStatusUnSafe, TxStatusUnSafeNotify, TxStatusUnSafeNotifyFail}
+	var dropped int64
+	for fromStatus, guids := range guidsByStatus {
+		result := db.gorm.Table("deposits_"+requestId).
+			Where("guid IN ? AND status = ? AND status IN ? AND block_hash = ''", guids, fromStatus, unsafeStatus).
+			Update("status", TxStatusDropped)
+		if result.Error != nil {
+			return dropped, fmt.Errorf("drop mempool deposits failed: %w", result.Error)
+		}
+		dropped += result.RowsAffected
+	}
+	return dropped, nil
+}
+
+// QueryDepositsPage 按过滤条件分页查询充值记录，返回下一页的游标，没有下一页时游标为空
+func (db *depositsDB) QueryDepositsPage(requestId string, query TxQuery) ([]Deposits, string, error) {
+	tx := applyTxQuery(db.gorm.Table("deposits_"+requestId), requestId, query)
+	depositList, nextCursor, err := queryPage(tx, query, func(deposit Deposits) (uint64, string) {
+		return deposit.Timestamp, deposit.GUID.String()
+	})
+	if err != nil {
+		log.Error("query deposits page fail", "requestId", requestId, "err", err)
+		return nil, "", err
+	}
+	return depositList, nextCursor, nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDepositStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   TxStatus
+		confirms uint64
+		want     TxStatus
+	}{
+		{"unsafe notified below safe", TxStatusUnSafeNotify, 2, TxStatusUnSafeNotify},
+		{"unsafe notified reaches safe", TxStatusUnSafeNotify, 3, TxStatusSafe},
+		{"unsafe notified skips finalized", TxStatusUnSafeNotify, 10, TxStatusSafe},
+		{"safe notified below finalized", TxStatusSafeNotify, 9, TxStatusSafeNotify},
+		{"safe notified reaches finalized", TxStatusSafeNotify, 10, TxStatusFinalized},
+		// 还没通知的状态不推进，保证业务方按 unsafe、safe、finalized 的顺序收到通知
+		{"unsafe not notified", TxStatusUnSafe, 10, TxStatusUnSafe},
+		{"safe not notified", TxStatusSafe, 10, TxStatusSafe},
+		{"unsafe notify fail", TxStatusUnSafeNotifyFail, 10, TxStatusUnSafeNotifyFail},
+		{"finalized notified", TxStatusFinalizedNotify, 100, TxStatusFinalizedNotify},
+		{"dropped", TxStatusDropped, 100, TxStatusDropped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, nextDepositStatus(tt.status, tt.confirms, 3, 10))
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package dynamic
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/tenant"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+)
+
+// ColumnDrift 业务方表字段类型和模板不一致
+type ColumnDrift struct {
+	Column       string
+	TemplateType string
+	TenantType   string
+}
+
+// NullDrift 业务方表字段的非空约束和模板不一致
+type NullDrift struct {
+	Column          string
+	TemplateNotNull bool
+	TenantNotNull   bool
+}
+
+// TableDrift 业务方表和模板表之间的差异；缺表、缺字段、缺索引可以自动补齐，多余字段、类型和非空约束不一致只报告
+type TableDrift struct {
+	Table          string
+	Template       string
+	MissingTable   bool
+	MissingColumns []database.TableColumn
+	MissingIndexes []database.TableIndex
+	ExtraColumns   []string
+	TypeMismatches []ColumnDrift
+	NullMismatches []NullDrift
+}
+
+func (d TableDrift) HasDrift() bool {
+	return d.MissingTable || len(d.MissingColumns) > 0 || len(d.MissingIndexes) > 0 || len(d.ExtraColumns) > 0 || len(d.TypeMismatches) > 0 || len(d.NullMismatches) > 0
+}
+
+// CheckTenant 对比业务方的每张表和模板表，只返回有差异的表
+func CheckTenant(requestId string, db *database.DB) ([]TableDrift, error) {
+	var driftList []TableDrift
+	for _, template := range TemplateTables {
+		drift, err := checkTable(template, TenantTableName(template, requestId), db)
+		if err != nil {
+			return nil, err
+		}
+		if drift.HasDrift() {
+			driftList = append(driftList, drift)
+		}
+	}
+	return driftList, nil
+}
+
+// SyncTenant 在一个事务中补齐业务方缺少的表、字段和索引，返回同步前检查到的差异
+func SyncTenant(requestId string, db *database.DB) ([]TableDrift, error) {
+	driftList, err := CheckTenant(requestId, db)
+	if err != nil {
+		return nil, err
+	}
+	if len(driftList) == 0 {
+		return nil, nil
+	}
+	err = db.Transaction(func(tx *database.DB) error {
+		for _, drift := range driftList {
+			if drift.MissingTable {
+				if err := tx.CreateTable.CreateTable(drift.Table, drift.Template); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, column := range drift.MissingColumns {
+				if err := tx.CreateTable.AddColumn(drift.Table, column); err != nil {
+					return err
+				}
+			}
+			for _, index := range drift.MissingIndexes {
+				if err := tx.CreateTable.CreateIndex(index.Definition); err != nil {
+					return err
+				}
+			}
+			if len(drift.ExtraColumns) > 0 || len(drift.TypeMismatches) > 0 || len(drift.NullMismatches) > 0 {
+				log.Warn("tenant table drift needs manual fix", "table", drift.Table, "extraColumns", drift.ExtraColumns, "typeMismatches", len(drift.TypeMismatches), "nullMismatches", len(drift.NullMismatches))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return driftList, nil
+}
+
+func checkTable(template string, table string, db *database.DB) (TableDrift, error) {
+	exists, err := db.CreateTable.TableExists(table)
+	if err != nil {
+		return TableDrift{}, err
+	}
+	if !exists {
+		return TableDrift{Table: table, Template: template, MissingTable: true}, nil
+	}
+	templateColumns, err := db.CreateTable.TableColumns(template)
+	if err != nil {
+		return TableDrift{}, err
+	}
+	tenantColumns, err := db.CreateTable.TableColumns(table)
+	if err != nil {
+		return TableDrift{}, err
+	}
+	templateIndexes, err := db.CreateTable.TableIndexes(template)
+	if err != nil {
+		return TableDrift{}, err
+	}
+	tenantIndexes, err := db.CreateTable.TableIndexes(table)
+	if err != nil {
+		return TableDrift{}, err
+	}
+	return diffTable(template, table, templateColumns, tenantColumns, templateIndexes, tenantIndexes), nil
+}
+
+// diffTable 字段按名称对比类型和非空约束，索引按去掉索引名和表名之后的定义对比（LIKE 复制出来的索引名和模板不同）
+func diffTable(template string, table string, templateColumns, tenantColumns []database.TableColumn, templateIndexes, tenantIndexes []database.TableIndex) TableDrift {
+	drift := TableDrift{Table: table, Template: template}
+
+	tenantColumnMap := make(map[string]database.TableColumn, len(tenantColumns))
+	for _, column := range tenantColumns {
+		tenantColumnMap[column.Name] = column
+	}
+	templateColumnMap := make(map[string]bool, len(templateColumns))
+	for _, column := range templateColumns {
+		templateColumnMap[column.Name] = true
+		tenantColumn, ok := tenantColumnMap[column.Name]
+		if !ok {
+			drift.MissingColumns = append(drift.MissingColumns, column)
+		} else if tenantColumn.DataType != column.DataType {
+			drift.TypeMismatches = append(drift.TypeMismatches, ColumnDrift{
+				Column:       column.Name,
+				TemplateType: column.DataType,
+				TenantType:   tenantColumn.DataType,
+			})
+		} else if tenantColumn.NotNull != column.NotNull {
+			drift.NullMismatches = append(drift.NullMismatches, NullDrift{
+				Column:          column.Name,
+				TemplateNotNull: column.NotNull,
+				TenantNotNull:   tenantColumn.NotNull,
+			})
+		}
+	}
+	for _, column := range tenantColumns {
+		if !templateColumnMap[column.Name] {
+			drift.ExtraColumns = append(drift.ExtraColumns, column.Name)
+		}
+	}
+
+	tenantIndexKeys := make(map[string]bool, len(tenantIndexes))
+	for _, index := range tenantIndexes {
+		tenantIndexKeys[indexKey(index.Definition)] = true
+	}
+	for _, index := range templateIndexes {
+		if tenantIndexKeys[indexKey(index.Definition)] {
+			continue
+		}
+		drift.MissingIndexes = append(drift.MissingIndexes, tenantIndex(template, table, index))
+	}
+	return drift
+}
+
+// indexKey 取 "USING" 之后的部分加上是否唯一，作为索引的比较键
+func indexKey(definition string) string {
+	_, method, found := strings.Cut(definition, " USING ")
+	if !found {
+		return definition
+	}
+	if strings.HasPrefix(definition, "CREATE UNIQUE INDEX") {
+		return "unique " + method
+	}
+	return method
+}
+
+// tenantIndex 把模板表的索引改写成业务方表上的建索引语句，索引名把模板表名前缀换成业务方表名，超长时缩短
+func tenantIndex(template string, table string, index database.TableIndex) database.TableIndex {
+	name := table + "_" + index.Name
+	if strings.HasPrefix(index.Name, template+"_") {
+		name = table + strings.TrimPrefix(index.Name, template)
+	}
+	name = tenant.Identifier(name)
+	createSql := "CREATE INDEX IF NOT EXISTS "
+	if strings.HasPrefix(index.Definition, "CREATE UNIQUE INDEX") {
+		createSql = "CREATE UNIQUE INDEX IF NOT EXISTS "
+	}
+	_, method, _ := strings.Cut(index.Definition, " USING ")
+	return database.TableIndex{
+		Name:       name,
+		Definition: createSql + pgx.Identifier{name}.Sanitize() + " ON " + pgx.Identifier{table}.Sanitize() + " USING " + method,
+	}
+}
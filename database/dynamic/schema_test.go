@@ -0,0 +1,58 @@
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+)
+
+func TestDiffTable(t *testing.T) {
+	templateColumns := []database.TableColumn{
+		{Name: "guid", DataType: "character varying", NotNull: true},
+		{Name: "status", DataType: "character varying", NotNull: true, DefaultValue: "'unsafe'::character varying"},
+		{Name: "confirms", DataType: "smallint", NotNull: true, DefaultValue: "0"},
+	}
+	tenantColumns := []database.TableColumn{
+		{Name: "guid", DataType: "character varying", NotNull: true},
+		{Name: "status", DataType: "smallint", NotNull: true},
+		{Name: "legacy", DataType: "integer"},
+	}
+	// 旧版本 AddColumn 补上的非空字段是可空的
+	nullableGuid := append([]database.TableColumn{{Name: "guid", DataType: "character varying"}}, templateColumns[1:]...)
+	templateIndexes := []database.TableIndex{
+		{Name: "deposits_pkey", Definition: "CREATE UNIQUE INDEX deposits_pkey ON public.deposits USING btree (guid)"},
+		{Name: "deposits_status", Definition: "CREATE INDEX deposits_status ON public.deposits USING btree (status)"},
+	}
+	tenantIndexes := []database.TableIndex{
+		{Name: "deposits_biz_pkey", Definition: "CREATE UNIQUE INDEX deposits_biz_pkey ON public.deposits_biz USING btree (guid)"},
+	}
+
+	drift := diffTable("deposits", "deposits_biz", templateColumns, tenantColumns, templateIndexes, tenantIndexes)
+	require.True(t, drift.HasDrift())
+	require.Len(t, drift.MissingColumns, 1)
+	require.Equal(t, "confirms", drift.MissingColumns[0].Name)
+	require.Equal(t, []string{"legacy"}, drift.ExtraColumns)
+	require.Equal(t, []ColumnDrift{{Column: "status", TemplateType: "character varying", TenantType: "smallint"}}, drift.TypeMismatches)
+	require.Len(t, drift.MissingIndexes, 1)
+	require.Equal(t, `CREATE INDEX IF NOT EXISTS "deposits_biz_status" ON "deposits_biz" USING btree (status)`, drift.MissingIndexes[0].Definition)
+
+	require.Empty(t, drift.NullMismatches)
+
+	drift = diffTable("deposits", "deposits_biz", templateColumns, nullableGuid, templateIndexes, templateIndexes)
+	require.True(t, drift.HasDrift())
+	require.Equal(t, []NullDrift{{Column: "guid", TemplateNotNull: true, TenantNotNull: false}}, drift.NullMismatches)
+
+	drift = diffTable("deposits", "deposits_biz", templateColumns, templateColumns, templateIndexes, templateIndexes)
+	require.False(t, drift.HasDrift())
+}
+
+func TestArchivedTableName(t *testing.T) {
+	require.Equal(t, "deposits_biz_1700000000", ArchivedTableName("deposits_biz", "1700000000"))
+
+	requestId := "abcdefghijklmnopqrstuvwxyz012345"
+	archived := ArchivedTableName(TenantTableName("withdraw_replacements", requestId), "1700000000_eth")
+	require.LessOrEqual(t, len(archived), 63)
+	require.NotEqual(t, archived, ArchivedTableName(TenantTableName("withdraw_replacements", requestId), "1700000000_tron"))
+}
@@ -3,71 +3,60 @@ package dynamic
 import (
 	"fmt"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/tenant"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
 )
 
-func CreateTableFromTemplate(requestId string, db *database.DB) {
-	createAddresses(requestId, db)
-	createVins(requestId, db)
-	createVouts(requestId, db)
-	createBalances(requestId, db)
-	createDeposits(requestId, db)
-	createTransactions(requestId, db)
-	createWithdraws(requestId, db)
-	createInternals(requestId, db)
-	createChildTxn(requestId, db)
-}
-
-func createAddresses(requestId string, db *database.DB) {
-	tableName := "addresses"
-	tableNameByChainId := fmt.Sprintf("addresses_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createVins(requestId string, db *database.DB) {
-	tableName := "vins"
-	tableNameByChainId := fmt.Sprintf("vins_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createVouts(requestId string, db *database.DB) {
-	tableName := "vouts"
-	tableNameByChainId := fmt.Sprintf("vouts_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createBalances(requestId string, db *database.DB) {
-	tableName := "balances"
-	tableNameByChainId := fmt.Sprintf("balances_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createDeposits(requestId string, db *database.DB) {
-	tableName := "deposits"
-	tableNameByChainId := fmt.Sprintf("deposits_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createTransactions(requestId string, db *database.DB) {
-	tableName := "transactions"
-	tableNameByChainId := fmt.Sprintf("transactions_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createWithdraws(requestId string, db *database.DB) {
-	tableName := "withdraws"
-	tableNameByChainId := fmt.Sprintf("withdraws_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createInternals(requestId string, db *database.DB) {
-	tableName := "internals"
-	tableNameByChainId := fmt.Sprintf("internals_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
-}
-
-func createChildTxn(requestId string, db *database.DB) {
-	tableName := "child_txs"
-	tableNameByChainId := fmt.Sprintf("child_txs_%s", requestId)
-	db.CreateTable.CreateTable(tableNameByChainId, tableName)
+// TemplateTables 每个业务方按模板复制一份的表，业务方的表名为 <模板表>_<requestId>
+var TemplateTables = []string{
+	"addresses",
+	"vins",
+	"vouts",
+	"balances",
+	"deposits",
+	"transactions",
+	"withdraws",
+	"internals",
+	"child_txs",
+	"utxos",
+	"withdraw_replacements",
+	"cpfp_reservations",
+}
+
+func CreateTableFromTemplate(requestId string, db *database.DB) error {
+	for _, tableName := range TemplateTables {
+		if err := db.CreateTable.CreateTable(TenantTableName(tableName, requestId), tableName); err != nil {
+			return fmt.Errorf("create table %s for business %s fail: %w", tableName, requestId, err)
+		}
+	}
+	return nil
+}
+
+func TenantTableName(tableName string, requestId string) string {
+	return fmt.Sprintf("%s_%s", tableName, requestId)
+}
+
+// ArchiveTenantTables 注销业务方时把它的表归档为 archived.<表名>_<archiveTag>，超过 63 个字符时缩短
+func ArchiveTenantTables(requestId string, archiveTag string, db *database.DB) error {
+	for _, tableName := range TemplateTables {
+		tenantTable := TenantTableName(tableName, requestId)
+		if err := db.CreateTable.ArchiveTable(tenantTable, ArchivedTableName(tenantTable, archiveTag)); err != nil {
+			return fmt.Errorf("archive table %s fail: %w", tenantTable, err)
+		}
+	}
+	return nil
+}
+
+// ArchivedTableName 归档表名为 <表名>_<archiveTag>，archiveTag 带上时间和链的 schema 之后可能超过 postgres 标识符长度
+func ArchivedTableName(tenantTable string, archiveTag string) string {
+	return tenant.Identifier(tenantTable + "_" + archiveTag)
+}
+
+func DropTenantTables(requestId string, db *database.DB) error {
+	for _, tableName := range TemplateTables {
+		if err := db.CreateTable.DropTable(TenantTableName(tableName, requestId)); err != nil {
+			return fmt.Errorf("drop table %s fail: %w", TenantTableName(tableName, requestId), err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeMigrationState 模拟迁移用到的几条语句，事务提交时才生效，用来在没有数据库的环境里测试迁移流程
+type fakeMigrationState struct {
+	mu           sync.Mutex
+	records      map[uint64]SchemaMigrations
+	businessUids []string
+	// scripts 已提交的迁移脚本，去掉了首尾空白
+	scripts []string
+	// failOn 执行到内容相同的脚本时返回错误
+	failOn string
+	// schema current_schema() 的返回值；tenantTables 这个 schema 里已经建好的业务方表
+	schema       string
+	tenantTables map[string]bool
+	// searchPaths 依次设置过的 search_path
+	searchPaths []string
+}
+
+func (s *fakeMigrationState) versions() []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	versions := make([]uint64, 0, len(s.records))
+	for version := range s.records {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+var (
+	fakeDriverOnce   sync.Once
+	fakeDriverStates sync.Map
+)
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	state, ok := fakeDriverStates.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown fake database %s", name)
+	}
+	return &fakeConn{state: state.(*fakeMigrationState)}, nil
+}
+
+func newFakeMigrationDB(t *testing.T) (*DB, *fakeMigrationState) {
+	return newFakeSchemaDB(t, "")
+}
+
+// newFakeSchemaDB 连接指向其他链的 schema
+func newFakeSchemaDB(t *testing.T, schema string) (*DB, *fakeMigrationState) {
+	fakeDriverOnce.Do(func() { sql.Register("fake_migration", fakeDriver{}) })
+	state := &fakeMigrationState{records: make(map[uint64]SchemaMigrations), schema: schema, tenantTables: make(map[string]bool)}
+	fakeDriverStates.Store(t.Name(), state)
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{DriverName: "fake_migration", DSN: t.Name(), WithoutReturning: true}), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return newDB(gormDB, schema), state
+}
+
+type fakeConn struct {
+	state *fakeMigrationState
+	tx    *fakeTx
+}
+
+type fakeTx struct {
+	conn    *fakeConn
+	records map[uint64]SchemaMigrations
+	scripts []string
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	records := make(map[uint64]SchemaMigrations, len(c.state.records))
+	for version, record := range c.state.records {
+		records[version] = record
+	}
+	c.tx = &fakeTx{conn: c, records: records}
+	return c.tx, nil
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.conn.state.mu.Lock()
+	defer tx.conn.state.mu.Unlock()
+	tx.conn.state.records = tx.records
+	tx.conn.state.scripts = append(tx.conn.state.scripts, tx.scripts...)
+	tx.conn.tx = nil
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	tx.conn.tx = nil
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.tx == nil {
+		return nil, errors.New("fake database only supports statements in a transaction")
+	}
+	switch {
+	case strings.HasPrefix(query, "SELECT pg_advisory_xact_lock"), strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS schema_migrations"):
+	case strings.HasPrefix(query, "SELECT set_config('search_path'"):
+		c.state.searchPaths = append(c.state.searchPaths, args[0].Value.(string))
+	case strings.HasPrefix(query, `INSERT INTO "schema_migrations"`):
+		// 按语句里的列名取参数，列的顺序由 gorm 决定
+		columns := strings.Split(query[strings.Index(query, "(")+1:strings.Index(query, ")")], ",")
+		values := make(map[string]driver.Value, len(columns))
+		for i, column := range columns {
+			values[strings.Trim(column, `" `)] = args[i].Value
+		}
+		record := SchemaMigrations{
+			Version:  fakeUint64(values["version"]),
+			Name:     values["name"].(string),
+			Checksum: values["checksum"].(string),
+		}
+		c.tx.records[record.Version] = record
+		return fakeResult(record.Version), nil
+	case strings.HasPrefix(query, `DELETE FROM "schema_migrations"`):
+		delete(c.tx.records, fakeUint64(args[0].Value))
+	default:
+		script := strings.TrimSpace(query)
+		if c.state.failOn != "" && script == c.state.failOn {
+			return nil, errors.New("fake migration failure")
+		}
+		c.tx.scripts = append(c.tx.scripts, script)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.tx == nil {
+		return nil, errors.New("fake database only supports statements in a transaction")
+	}
+	switch {
+	case strings.HasPrefix(query, "SELECT COALESCE(current_schema()"):
+		return &fakeRows{columns: []string{"schema"}, values: [][]driver.Value{{c.state.schema}}}, nil
+	case strings.HasPrefix(query, "SELECT current_setting('search_path')"):
+		return &fakeRows{columns: []string{"search_path"}, values: [][]driver.Value{{searchPath(c.state.schema)}}}, nil
+	case strings.HasPrefix(query, "SELECT EXISTS (SELECT 1 FROM pg_tables"):
+		return &fakeRows{columns: []string{"exists"}, values: [][]driver.Value{{c.state.tenantTables[args[0].Value.(string)]}}}, nil
+	case strings.HasPrefix(query, "SELECT to_regclass"):
+		return &fakeRows{columns: []string{"exists"}, values: [][]driver.Value{{len(c.state.businessUids) > 0}}}, nil
+	case strings.Contains(query, `"business_uid"`):
+		rows := &fakeRows{columns: []string{"business_uid"}}
+		for _, businessUid := range c.state.businessUids {
+			rows.values = append(rows.values, []driver.Value{businessUid})
+		}
+		return rows, nil
+	case strings.Contains(query, `FROM "schema_migrations"`):
+		var records []SchemaMigrations
+		for _, record := range c.tx.records {
+			records = append(records, record)
+		}
+		desc := strings.Contains(query, "DESC")
+		sort.Slice(records, func(i, j int) bool {
+			return (records[i].Version < records[j].Version) != desc
+		})
+		if strings.Contains(query, "LIMIT") {
+			if limit := int(fakeUint64(args[len(args)-1].Value)); limit < len(records) {
+				records = records[:limit]
+			}
+		}
+		rows := &fakeRows{columns: []string{"version", "name", "checksum", "applied_at"}}
+		for _, record := range records {
+			rows.values = append(rows.values, []driver.Value{int64(record.Version), record.Name, record.Checksum, int64(record.AppliedAt)})
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+func fakeUint64(value driver.Value) uint64 {
+	switch v := value.(type) {
+	case int64:
+		return uint64(v)
+	case uint64:
+		return v
+	}
+	panic(fmt.Sprintf("unexpected integer %T", value))
+}
+
+// fakeResult 写入 schema_migrations 时 gorm 会读取自增主键
+type fakeResult int64
+
+func (r fakeResult) LastInsertId() (int64, error) { return int64(r), nil }
+
+func (r fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
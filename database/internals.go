@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/google/uuid"
@@ -17,14 +18,23 @@ type Internals struct {
 	LockTime    *big.Int  `gorm:"serializer:u256" json:"lock_time"`
 	Version     string    `json:"version"`
 	TxType      string    `json:"tx_type"`
+	ParentHash  string    `json:"parent_hash"`
 	TxSignHex   string    `json:"tx_sign_hex"`
 	Status      TxStatus  `json:"status"`
 	Timestamp   uint64    `json:"timestamp"`
 }
 
+// InternalTxTypeCpfp 子交易加速未确认父交易（CPFP）的内部交易类型
+const InternalTxTypeCpfp = "cpfp"
+
 type InternalsView interface {
+	QueryInternalByGuid(requestId string, guid string) (*Internals, error)
 	QueryNotifyInternal(requestId string) ([]Internals, error)
 	UnSendInternalsList(requestId string) ([]Internals, error)
+	QueryFallbackInternals(requestId string) ([]Internals, error)
+	QueryInternalsByStatus(requestId string, status TxStatus) ([]Internals, error)
+	QueryExpiredCpfpInternals(requestId string, before uint64) ([]Internals, error)
+	QueryInternalsPage(requestId string, query TxQuery) ([]Internals, string, error)
 }
 
 type InternalsDB interface {
@@ -33,6 +43,8 @@ type InternalsDB interface {
 	StoreInternal(string, *Internals) error
 	UpdateInternalTx(requestId string, transactionId string, signedTx string, status TxStatus) error
 	UpdateInternalStatus(requestId string, status TxStatus, internalsList []Internals) error
+	UpdateSentInternals(requestId string, internalsList []Internals) error
+	UpdateInternalStatusByTxHash(requestId string, status TxStatus, internalsList []Internals) error
 }
 
 type internalsDB struct {
@@ -46,7 +58,7 @@ func NewInternalsDB(db *gorm.DB) InternalsDB {
 func (db *internalsDB) QueryNotifyInternal(requestId string) ([]Internals, error) {
 	var notifyInternals []Internals
 	result := db.gorm.Table("internals_"+requestId).
-		Where("status = ?", TxStatusSuccess).
+		Where("status IN ?", []TxStatus{TxStatusWithdrawed, TxStatusWithdrawedNotifyFail, TxStatusFail, TxStatusFailNotifyFail, TxStatusFallback, TxStatusFallbackNotifyFail}).
 		Find(&notifyInternals)
 	if result.Error != nil {
 		return nil, result.Error
@@ -54,6 +66,18 @@ func (db *internalsDB) QueryNotifyInternal(requestId string) ([]Internals, error
 	return notifyInternals, nil
 }
 
+func (db *internalsDB) QueryInternalByGuid(requestId string, guid string) (*Internals, error) {
+	var internal Internals
+	err := db.gorm.Table("internals_"+requestId).Where("guid = ?", guid).Take(&internal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &internal, nil
+}
+
 func (db *internalsDB) StoreInternal(requestId string, internals *Internals) error {
 	return db.gorm.Table("internals_" + requestId).Create(internals).Error
 }
@@ -82,51 +106,129 @@ func (db *internalsDB) UpdateInternalTx(requestId string, transactionId string,
 	return nil
 }
 
+// UpdateInternalStatus 只更新状态仍然是读取时的值的记录，状态已被其他流程改掉的记录返回 ErrStatusChanged
 func (db *internalsDB) UpdateInternalStatus(requestId string, status TxStatus, internalsList []Internals) error {
 	if len(internalsList) == 0 {
 		return nil
 	}
 	tableName := fmt.Sprintf("internals_%s", requestId)
 
-	return db.gorm.Transaction(func(tx *gorm.DB) error {
-		var guids []uuid.UUID
-		for _, internal := range internalsList {
-			guids = append(guids, internal.Guid)
-		}
+	guidsByStatus := make(map[TxStatus][]uuid.UUID)
+	for _, internal := range internalsList {
+		guidsByStatus[internal.Status] = append(guidsByStatus[internal.Status], internal.Guid)
+	}
+	updated, err := updateStatusFrom(db.gorm, tableName, status, guidsByStatus, len(internalsList))
+	if errors.Is(err, ErrStatusChanged) {
+		log.Warn("Some internals status changed concurrently",
+			"requestId", requestId,
+			"count", updated,
+			"expectedCount", len(internalsList),
+		)
+		return err
+	} else if err != nil {
+		return err
+	}
 
-		result := tx.Table(tableName).
-			Where("guid IN ?", guids).
-			Where("status = ?", TxStatusSuccess).
-			Update("status", status)
+	log.Info("Batch update internals status success",
+		"requestId", requestId,
+		"count", updated,
+		"status", status,
+	)
+	return nil
+}
 
-		if result.Error != nil {
-			return fmt.Errorf("batch update status failed: %w", result.Error)
+func (db *internalsDB) UnSendInternalsList(requestId string) ([]Internals, error) {
+	var internalsList []Internals
+	err := db.gorm.Table("internals_"+requestId).
+		Where("status = ?", TxStatusUnSent).
+		Find(&internalsList).Error
+	if err != nil {
+		return nil, err
+	}
+	return internalsList, nil
+}
+
+// UpdateSentInternals 交易广播成功之后记录交易哈希，扫链时通过哈希匹配到内部交易记录
+func (db *internalsDB) UpdateSentInternals(requestId string, internalsList []Internals) error {
+	tableName := fmt.Sprintf("internals_%s", requestId)
+	for _, internal := range internalsList {
+		updates := map[string]interface{}{
+			"hash":   internal.Hash,
+			"status": TxStatusSent,
+		}
+		if err := db.gorm.Table(tableName).Where("guid = ?", internal.Guid).Updates(updates).Error; err != nil {
+			return fmt.Errorf("update sent internal failed: %w", err)
 		}
+	}
+	return nil
+}
 
+// UpdateInternalStatusByTxHash 扫链扫到归集、热转冷、冷转热交易之后，按交易哈希更新内部交易的状态和所在区块
+func (db *internalsDB) UpdateInternalStatusByTxHash(requestId string, status TxStatus, internalsList []Internals) error {
+	tableName := fmt.Sprintf("internals_%s", requestId)
+	for _, internal := range internalsList {
+		updates := map[string]interface{}{
+			"status":       status,
+			"block_hash":   internal.BlockHash,
+			"block_number": internal.BlockNumber.Uint64(),
+		}
+		result := db.gorm.Table(tableName).Where("hash = ?", internal.Hash).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("update internal by tx hash failed: %w", result.Error)
+		}
 		if result.RowsAffected == 0 {
-			log.Warn("No internals updated",
-				"requestId", requestId,
-				"expectedCount", len(internalsList),
-			)
+			log.Warn("No internal matched tx hash", "requestId", requestId, "hash", internal.Hash)
 		}
+	}
+	return nil
+}
 
-		log.Info("Batch update internals status success",
-			"requestId", requestId,
-			"count", result.RowsAffected,
-			"status", status,
-		)
+// QueryFallbackInternals 查询所在区块已经被重组掉、还没有进入回滚流程的内部交易
+func (db *internalsDB) QueryFallbackInternals(requestId string) ([]Internals, error) {
+	var fallbackInternals []Internals
+	err := db.gorm.Table("internals_"+requestId).
+		Where(orphanedBlockHashSql).
+		Where("status NOT IN ?", FallbackStatusList).
+		Find(&fallbackInternals).Error
+	if err != nil {
+		return nil, err
+	}
+	return fallbackInternals, nil
+}
 
-		return nil
-	})
+func (db *internalsDB) QueryInternalsByStatus(requestId string, status TxStatus) ([]Internals, error) {
+	var internalsList []Internals
+	err := db.gorm.Table("internals_"+requestId).Where("status = ?", status).Find(&internalsList).Error
+	if err != nil {
+		return nil, err
+	}
+	return internalsList, nil
 }
 
-func (db *internalsDB) UnSendInternalsList(requestId string) ([]Internals, error) {
+// QueryExpiredCpfpInternals 构建时间早于 before 仍未广播的 CPFP 子交易，它们占用的 utxo 和父交易输出需要释放
+func (db *internalsDB) QueryExpiredCpfpInternals(requestId string, before uint64) ([]Internals, error) {
 	var internalsList []Internals
 	err := db.gorm.Table("internals_"+requestId).
-		Where("status = ?", TxStatusUnSent).
+		Where("tx_type = ? AND status IN ? AND timestamp < ?", InternalTxTypeCpfp, []TxStatus{TxStatusWaitSign, TxStatusUnSent}, before).
 		Find(&internalsList).Error
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("query expired cpfp internals failed: %w", err)
 	}
 	return internalsList, nil
 }
+
+// QueryInternalsPage 按过滤条件分页查询内部交易（归集、冷热互转、CPFP），返回下一页的游标
+func (db *internalsDB) QueryInternalsPage(requestId string, query TxQuery) ([]Internals, string, error) {
+	tx := applyTxQuery(db.gorm.Table("internals_"+requestId), requestId, query)
+	if query.TxType != "" {
+		tx = tx.Where("tx_type = ?", query.TxType)
+	}
+	internalList, nextCursor, err := queryPage(tx, query, func(internal Internals) (uint64, string) {
+		return internal.Timestamp, internal.Guid.String()
+	})
+	if err != nil {
+		log.Error("query internals page fail", "requestId", requestId, "err", err)
+		return nil, "", err
+	}
+	return internalList, nextCursor, nil
+}
@@ -0,0 +1,428 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const (
+	// migrationTenantMarker 之后的语句是按业务方分表的部分，模板表和每个业务方的表各执行一次
+	migrationTenantMarker = "-- +migrate tenant"
+	// migrationTenantPlaceholder 在模板表中替换为空，在业务方表中替换为 _<requestId>
+	migrationTenantPlaceholder = "{{tenant}}"
+	// migrationLockKey 多个进程同时执行迁移时用 pg_advisory_xact_lock 串行化
+	migrationLockKey = 20240601
+)
+
+var (
+	ErrMigrationModified = errors.New("applied migration has been modified")
+	ErrMigrationMissing  = errors.New("applied migration file is missing")
+	ErrMigrationNoDown   = errors.New("migration has no down file")
+
+	migrationFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	// migrationTenantIdentRegexp 匹配包含占位符的标识符，例如 deposits{{tenant}}_status
+	migrationTenantIdentRegexp = regexp.MustCompile(`\w*\{\{tenant\}\}\w*`)
+	// migrationBusinessStatementRegexp 建表、改表、删表和建索引时操作业务方表的语句
+	migrationBusinessStatementRegexp = regexp.MustCompile(`(?i)\b(TABLE|ON)\s+(IF\s+(NOT\s+)?EXISTS\s+)?business\b`)
+	migrationCommentRegexp           = regexp.MustCompile(`--[^\n]*`)
+	// migrationDollarTagRegexp 函数体等用 $tag$ 包围的字符串的开始标记
+	migrationDollarTagRegexp = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+)
+
+// Migration 一个版本的迁移，文件名为 <version>_<name>.up.sql 和 <version>_<name>.down.sql，校验和按 up 文件计算
+type Migration struct {
+	Version  uint64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// SchemaMigrations 已经执行过的迁移记录
+type SchemaMigrations struct {
+	Version   uint64 `gorm:"primaryKey" json:"version"`
+	Name      string `json:"name"`
+	Checksum  string `json:"checksum"`
+	AppliedAt uint64 `json:"applied_at"`
+}
+
+type MigrationStatus struct {
+	Version   uint64
+	Name      string
+	Applied   bool
+	AppliedAt uint64
+	Modified  bool
+	Missing   bool
+}
+
+// LoadMigrations 读取迁移目录，按版本号排序返回
+func LoadMigrations(migrationsFolder string) ([]Migration, error) {
+	entries, err := os.ReadDir(migrationsFolder)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Error reading migrations folder: %s", migrationsFolder))
+	}
+	migrationMap := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+		fileContent, err := os.ReadFile(filepath.Join(migrationsFolder, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("Error reading SQL file: %s", entry.Name()))
+		}
+		migration, ok := migrationMap[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			migrationMap[version] = migration
+		} else if migration.Name != matches[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, migration.Name, matches[2])
+		}
+		if matches[3] == "up" {
+			checksum := sha256.Sum256(fileContent)
+			migration.Up = string(fileContent)
+			migration.Checksum = hex.EncodeToString(checksum[:])
+		} else {
+			migration.Down = string(fileContent)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(migrationMap))
+	for _, migration := range migrationMap {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("migration %05d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// splitMigration 拆分出只执行一次的公共部分和按业务方执行的部分
+func splitMigration(script string) (string, string) {
+	index := strings.Index(script, migrationTenantMarker)
+	if index < 0 {
+		return script, ""
+	}
+	return script[:index], script[index+len(migrationTenantMarker):]
+}
+
+// sharedScript 公共部分在默认 schema 里原样执行；业务方表所有链共用 public 里的一份，
+// 其他链的 schema 去掉操作业务方表的语句，不建一份用不到的表，回退时也不会动到共用的表
+func sharedScript(schema string, script string) string {
+	if schema == "" {
+		return script
+	}
+	var kept strings.Builder
+	for _, statement := range splitStatements(script) {
+		// 只看语句本身，前面的注释不算
+		if !migrationBusinessStatementRegexp.MatchString(migrationCommentRegexp.ReplaceAllString(statement, "")) {
+			kept.WriteString(statement)
+		}
+	}
+	return kept.String()
+}
+
+// splitStatements 按分号拆分语句，单引号字符串、$tag$ 包围的函数体和 -- 注释里的分号不算；每条语句保留原来的空白和结尾的分号
+func splitStatements(script string) []string {
+	var statements []string
+	start := 0
+	for i := 0; i < len(script); i++ {
+		switch {
+		case script[i] == '\'':
+			end := strings.IndexByte(script[i+1:], '\'')
+			if end < 0 {
+				i = len(script)
+				break
+			}
+			i += end + 1
+		case strings.HasPrefix(script[i:], "--"):
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+				break
+			}
+			i += end
+		case script[i] == '$':
+			tag := migrationDollarTagRegexp.FindString(script[i:])
+			if tag == "" {
+				break
+			}
+			end := strings.Index(script[i+len(tag):], tag)
+			if end < 0 {
+				i = len(script)
+				break
+			}
+			i += len(tag) + end + len(tag) - 1
+		case script[i] == ';':
+			statements = append(statements, script[start:i+1])
+			start = i + 1
+		}
+	}
+	if start < len(script) {
+		statements = append(statements, script[start:])
+	}
+	return statements
+}
+
+// renderTenant 把分表部分渲染成指定业务方的语句，requestId 为空时作用于模板表；
+// 含占位符的整个标识符渲染后加引号，避免业务方 id 被当成 SQL 解析
+func renderTenant(script string, requestId string) string {
+	suffix := ""
+	if requestId != "" {
+		suffix = "_" + requestId
+	}
+	return migrationTenantIdentRegexp.ReplaceAllStringFunc(script, func(ident string) string {
+		return pgx.Identifier{strings.ReplaceAll(ident, migrationTenantPlaceholder, suffix)}.Sanitize()
+	})
+}
+
+// MigrateUp 在一个事务中按顺序执行所有未执行的迁移，已执行的迁移文件被改动时拒绝执行
+func (db *DB) MigrateUp(migrationsFolder string) ([]Migration, error) {
+	migrations, err := LoadMigrations(migrationsFolder)
+	if err != nil {
+		return nil, err
+	}
+	var applied []Migration
+	err = db.gorm.Transaction(func(tx *gorm.DB) error {
+		appliedMap, err := prepareMigrations(tx, db.schema)
+		if err != nil {
+			return err
+		}
+		if err := verifyMigrations(migrations, appliedMap); err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			if _, ok := appliedMap[migration.Version]; ok {
+				continue
+			}
+			shared, tenant := splitMigration(migration.Up)
+			if err := tx.Exec(sharedScript(db.schema, shared)).Error; err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error executing migration: %05d_%s", migration.Version, migration.Name))
+			}
+			if err := execTenantMigration(tx, db.schema, migration, tenant); err != nil {
+				return err
+			}
+			record := &SchemaMigrations{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: uint64(time.Now().Unix()),
+			}
+			if err := tx.Table("schema_migrations").Create(record).Error; err != nil {
+				return err
+			}
+			log.Info("apply migration success", "version", migration.Version, "name", migration.Name)
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// MigrateDown 在一个事务中按倒序回退最近执行的 steps 个迁移
+func (db *DB) MigrateDown(migrationsFolder string, steps int) ([]Migration, error) {
+	migrations, err := LoadMigrations(migrationsFolder)
+	if err != nil {
+		return nil, err
+	}
+	migrationMap := make(map[uint64]Migration, len(migrations))
+	for _, migration := range migrations {
+		migrationMap[migration.Version] = migration
+	}
+	var reverted []Migration
+	err = db.gorm.Transaction(func(tx *gorm.DB) error {
+		if _, err := prepareMigrations(tx, db.schema); err != nil {
+			return err
+		}
+		var records []SchemaMigrations
+		if err := tx.Table("schema_migrations").Order("version DESC").Limit(steps).Find(&records).Error; err != nil {
+			return err
+		}
+		for _, record := range records {
+			migration, ok := migrationMap[record.Version]
+			if !ok {
+				return fmt.Errorf("%w: %05d_%s", ErrMigrationMissing, record.Version, record.Name)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%w: %05d_%s", ErrMigrationNoDown, migration.Version, migration.Name)
+			}
+			// 先回退分表部分，公共部分可能会删除 business 表
+			shared, tenant := splitMigration(migration.Down)
+			if err := execTenantMigration(tx, db.schema, migration, tenant); err != nil {
+				return err
+			}
+			if err := tx.Exec(sharedScript(db.schema, shared)).Error; err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error reverting migration: %05d_%s", migration.Version, migration.Name))
+			}
+			if err := tx.Table("schema_migrations").Where("version = ?", record.Version).Delete(&SchemaMigrations{}).Error; err != nil {
+				return err
+			}
+			log.Info("revert migration success", "version", migration.Version, "name", migration.Name)
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// MigrationStatus 对比迁移目录和 schema_migrations，返回每个版本的执行情况
+func (db *DB) MigrationStatus(migrationsFolder string) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(migrationsFolder)
+	if err != nil {
+		return nil, err
+	}
+	var records []SchemaMigrations
+	if db.gorm.Migrator().HasTable("schema_migrations") {
+		if err := db.gorm.Table("schema_migrations").Order("version ASC").Find(&records).Error; err != nil {
+			return nil, err
+		}
+	}
+	recordMap := make(map[uint64]SchemaMigrations, len(records))
+	for _, record := range records {
+		recordMap[record.Version] = record
+	}
+
+	var statusList []MigrationStatus
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := recordMap[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+			status.Modified = record.Checksum != migration.Checksum
+			delete(recordMap, migration.Version)
+		}
+		statusList = append(statusList, status)
+	}
+	for _, record := range recordMap {
+		statusList = append(statusList, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: record.AppliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statusList, func(i, j int) bool {
+		return statusList[i].Version < statusList[j].Version
+	})
+	return statusList, nil
+}
+
+// prepareMigrations 加锁并创建 schema_migrations，返回已经执行的迁移；每条链的 schema 各有一份 schema_migrations
+func prepareMigrations(tx *gorm.DB, schema string) (map[uint64]SchemaMigrations, error) {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+		return nil, err
+	}
+	if schema != "" {
+		if err := checkSchema(tx, schema); err != nil {
+			return nil, err
+		}
+		// 迁移脚本里的 UINT256 等公共类型在 public 里
+		if err := tx.Exec("SELECT set_config('search_path', ?, true)", searchPath(schema)+",public").Error; err != nil {
+			return nil, err
+		}
+	}
+	err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version    BIGINT PRIMARY KEY,
+    name       VARCHAR NOT NULL,
+    checksum   VARCHAR NOT NULL,
+    applied_at INTEGER NOT NULL
+)`).Error
+	if err != nil {
+		return nil, err
+	}
+	var records []SchemaMigrations
+	if err := tx.Table("schema_migrations").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	appliedMap := make(map[uint64]SchemaMigrations, len(records))
+	for _, record := range records {
+		appliedMap[record.Version] = record
+	}
+	return appliedMap, nil
+}
+
+func verifyMigrations(migrations []Migration, appliedMap map[uint64]SchemaMigrations) error {
+	migrationMap := make(map[uint64]Migration, len(migrations))
+	for _, migration := range migrations {
+		migrationMap[migration.Version] = migration
+	}
+	for version, record := range appliedMap {
+		migration, ok := migrationMap[version]
+		if !ok {
+			return fmt.Errorf("%w: %05d_%s", ErrMigrationMissing, record.Version, record.Name)
+		}
+		if migration.Checksum != record.Checksum {
+			return fmt.Errorf("%w: %05d_%s", ErrMigrationModified, migration.Version, migration.Name)
+		}
+	}
+	return nil
+}
+
+// execTenantMigration 分表部分先作用于模板表，再作用于每个已经注册的业务方；
+// 业务方列表读共用的业务方表，其他链的 schema 需要在主链迁移之后执行。链是后加的时候，
+// 之前注册的业务方在这条链的 schema 里还没有表，跳过，迁移完成后按最新的模板表补建
+func execTenantMigration(tx *gorm.DB, schema string, migration Migration, script string) error {
+	if strings.TrimSpace(script) == "" {
+		return nil
+	}
+	requestIds := []string{""}
+	var exists bool
+	if err := tx.Raw("SELECT to_regclass(?) IS NOT NULL", sharedBusinessTable).Scan(&exists).Error; err != nil {
+		return err
+	}
+	if exists {
+		var businessUids []string
+		if err := tx.Table(sharedBusinessTable).Order("business_uid").Pluck("business_uid", &businessUids).Error; err != nil {
+			return err
+		}
+		requestIds = append(requestIds, businessUids...)
+	}
+	for _, requestId := range requestIds {
+		if schema != "" && requestId != "" {
+			var tenantExists bool
+			err := tx.Raw("SELECT EXISTS (SELECT 1 FROM pg_tables WHERE schemaname = current_schema() AND tablename = ?)", "addresses_"+requestId).
+				Scan(&tenantExists).Error
+			if err != nil {
+				return err
+			}
+			if !tenantExists {
+				continue
+			}
+		}
+		if err := tx.Exec(renderTenant(script, requestId)).Error; err != nil {
+			return errors.Wrap(err, fmt.Sprintf("Error executing migration %05d_%s for business %q", migration.Version, migration.Name, requestId))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := LoadMigrations("../migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, migration := range migrations {
+		require.Equal(t, uint64(i+1), migration.Version)
+		require.NotEmpty(t, migration.Down, migration.Name)
+		require.Len(t, migration.Checksum, 64)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00002_b.up.sql"), []byte("SELECT 2;"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00001_a.up.sql"), []byte("SELECT 1;"), 0o644))
+	migrations, err = LoadMigrations(dir)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, []uint64{migrations[0].Version, migrations[1].Version})
+	require.Empty(t, migrations[0].Down)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "00003_c.down.sql"), []byte("SELECT 3;"), 0o644))
+	_, err = LoadMigrations(dir)
+	require.Error(t, err)
+}
+
+func TestSplitMigration(t *testing.T) {
+	shared, tenant := splitMigration("ALTER TABLE business ADD COLUMN a INT;\n-- +migrate tenant\nALTER TABLE deposits{{tenant}} ADD COLUMN b INT;\n")
+	require.Equal(t, "ALTER TABLE business ADD COLUMN a INT;\n", shared)
+	require.Equal(t, "ALTER TABLE \"deposits\" ADD COLUMN b INT;\n", renderTenant(tenant, "")[1:])
+	require.Equal(t, "ALTER TABLE \"deposits_biz\" ADD COLUMN b INT;\n", renderTenant(tenant, "biz")[1:])
+
+	shared, tenant = splitMigration("SELECT 1;")
+	require.Equal(t, "SELECT 1;", shared)
+	require.Empty(t, tenant)
+}
+
+func TestRenderTenant(t *testing.T) {
+	script := "CREATE INDEX IF NOT EXISTS deposits{{tenant}}_status ON deposits{{tenant}} (status);"
+	require.Equal(t, `CREATE INDEX IF NOT EXISTS "deposits_status" ON "deposits" (status);`, renderTenant(script, ""))
+	require.Equal(t, `CREATE INDEX IF NOT EXISTS "deposits_biz_status" ON "deposits_biz" (status);`, renderTenant(script, "biz"))
+	// 业务方 id 里的引号和分号只会成为标识符的一部分
+	require.Equal(t, `DROP TABLE "deposits_x""; DROP TABLE business; --";`, renderTenant("DROP TABLE deposits{{tenant}};", `x"; DROP TABLE business; --`))
+}
+
+func TestSharedScript(t *testing.T) {
+	script := `CREATE TABLE IF NOT EXISTS business
+(
+    guid UUID PRIMARY KEY
+);
+CREATE INDEX IF NOT EXISTS business_uid ON business (business_uid);
+-- 注释里的分号; ALTER TABLE business
+DO $$
+BEGIN
+    ALTER TABLE blocks ADD COLUMN a INT;
+END $$;
+ALTER TABLE business ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'a;b';
+CREATE INDEX IF NOT EXISTS business_status_idx ON blocks (business_status);
+DROP TABLE IF EXISTS business;
+`
+	require.Equal(t, script, sharedScript("", script))
+	// 其他链的 schema 只去掉操作业务方表的语句，函数体、字符串和注释里的分号不拆开
+	require.Equal(t, `
+-- 注释里的分号; ALTER TABLE business
+DO $$
+BEGIN
+    ALTER TABLE blocks ADD COLUMN a INT;
+END $$;
+CREATE INDEX IF NOT EXISTS business_status_idx ON blocks (business_status);
+`, sharedScript("ltc", script))
+	require.Len(t, splitStatements("SELECT 'a;b'; SELECT $body$ ; $body$;"), 2)
+}
+
+func writeMigrations(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	db, state := newFakeMigrationDB(t)
+	state.businessUids = []string{"biz"}
+	dir := t.TempDir()
+	writeMigrations(t, dir, map[string]string{
+		"00001_a.up.sql":   "CREATE TABLE a;\n-- +migrate tenant\nCREATE TABLE a{{tenant}};",
+		"00001_a.down.sql": "DROP TABLE a;\n-- +migrate tenant\nDROP TABLE a{{tenant}};",
+		"00002_b.up.sql":   "CREATE TABLE b;",
+		"00002_b.down.sql": "DROP TABLE b;",
+	})
+
+	applied, err := db.MigrateUp(dir)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	require.Equal(t, []uint64{1, 2}, state.versions())
+	// 分表部分先作用于模板表，再作用于每个业务方
+	require.Equal(t, []string{"CREATE TABLE a;", `CREATE TABLE "a";`, `CREATE TABLE "a_biz";`, "CREATE TABLE b;"}, state.scripts)
+
+	// 已经执行过的迁移不会重复执行
+	state.scripts = nil
+	applied, err = db.MigrateUp(dir)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Empty(t, state.scripts)
+
+	// 回退按倒序执行，先回退分表部分
+	reverted, err := db.MigrateDown(dir, 2)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 1}, []uint64{reverted[0].Version, reverted[1].Version})
+	require.Equal(t, []string{"DROP TABLE b;", `DROP TABLE "a";`, `DROP TABLE "a_biz";`, "DROP TABLE a;"}, state.scripts)
+	require.Empty(t, state.versions())
+}
+
+func TestMigrateUpInSchema(t *testing.T) {
+	db, state := newFakeSchemaDB(t, "ltc")
+	// old 在这条链加入之前注册，这条链的 schema 里还没有它的表
+	state.businessUids = []string{"new", "old"}
+	state.tenantTables["addresses_new"] = true
+	dir := t.TempDir()
+	writeMigrations(t, dir, map[string]string{
+		"00001_a.up.sql":   "CREATE TABLE IF NOT EXISTS business (id INT);\nCREATE TABLE a;\n-- +migrate tenant\nCREATE TABLE a{{tenant}};",
+		"00001_a.down.sql": "DROP TABLE a;\nDROP TABLE IF EXISTS business;\n-- +migrate tenant\nDROP TABLE a{{tenant}};",
+	})
+
+	_, err := db.MigrateUp(dir)
+	require.NoError(t, err)
+	// 迁移在链的 schema 里执行，共用的业务方表不在这里建
+	require.Equal(t, []string{`"ltc",public`}, state.searchPaths)
+	require.Equal(t, []string{"CREATE TABLE a;", `CREATE TABLE "a";`, `CREATE TABLE "a_new";`}, state.scripts)
+
+	state.scripts = nil
+	_, err = db.MigrateDown(dir, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{`DROP TABLE "a";`, `DROP TABLE "a_new";`, "DROP TABLE a;"}, state.scripts)
+
+	// schema 不存在时拒绝迁移
+	state.schema = ""
+	_, err = db.MigrateUp(dir)
+	require.Error(t, err)
+}
+
+func TestMigrateUpChecksumMismatch(t *testing.T) {
+	db, state := newFakeMigrationDB(t)
+	dir := t.TempDir()
+	writeMigrations(t, dir, map[string]string{"00001_a.up.sql": "CREATE TABLE a;"})
+	_, err := db.MigrateUp(dir)
+	require.NoError(t, err)
+
+	// 已执行的迁移文件被改动时拒绝执行后面的迁移
+	writeMigrations(t, dir, map[string]string{
+		"00001_a.up.sql": "CREATE TABLE a (id INT);",
+		"00002_b.up.sql": "CREATE TABLE b;",
+	})
+	state.scripts = nil
+	_, err = db.MigrateUp(dir)
+	require.ErrorIs(t, err, ErrMigrationModified)
+	require.Empty(t, state.scripts)
+	require.Equal(t, []uint64{1}, state.versions())
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "00001_a.up.sql")))
+	_, err = db.MigrateUp(dir)
+	require.ErrorIs(t, err, ErrMigrationMissing)
+}
+
+func TestMigrateRollback(t *testing.T) {
+	db, state := newFakeMigrationDB(t)
+	dir := t.TempDir()
+	writeMigrations(t, dir, map[string]string{
+		"00001_a.up.sql":   "CREATE TABLE a;",
+		"00001_a.down.sql": "DROP TABLE a;",
+		"00002_b.up.sql":   "CREATE TABLE b;",
+	})
+
+	// 一个迁移失败时整个事务回滚，前面执行成功的迁移也不会记录
+	state.failOn = "CREATE TABLE b;"
+	_, err := db.MigrateUp(dir)
+	require.Error(t, err)
+	require.Empty(t, state.versions())
+
+	state.failOn = ""
+	_, err = db.MigrateUp(dir)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, state.versions())
+
+	// 没有 down 文件的迁移不能回退，同一批回退的其他迁移也不会生效
+	_, err = db.MigrateDown(dir, 2)
+	require.ErrorIs(t, err, ErrMigrationNoDown)
+	require.Equal(t, []uint64{1, 2}, state.versions())
+
+	state.failOn = "DROP TABLE a;"
+	writeMigrations(t, dir, map[string]string{"00002_b.down.sql": "DROP TABLE b;"})
+	_, err = db.MigrateDown(dir, 2)
+	require.Error(t, err)
+	require.Equal(t, []uint64{1, 2}, state.versions())
+}
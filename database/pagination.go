@@ -0,0 +1,124 @@
+package database
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TxQuery 分页查询交易类记录的过滤条件，零值表示不过滤；高度和时间都是闭区间
+type TxQuery struct {
+	Guid       string
+	Status     []TxStatus
+	Address    string
+	Hash       string
+	TxType     string
+	FromHeight uint64
+	ToHeight   uint64
+	FromTime   uint64
+	ToTime     uint64
+	Cursor     string
+	Limit      int
+}
+
+// PageLimit 返回修正之后的每页条数
+func (q TxQuery) PageLimit() int {
+	if q.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if q.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return q.Limit
+}
+
+// EncodeCursor 游标是上一页最后一条记录的 (timestamp, guid)，按时间倒序翻页
+func EncodeCursor(timestamp uint64, guid string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", timestamp, guid)))
+}
+
+func DecodeCursor(cursor string) (uint64, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, "", ErrInvalidCursor
+	}
+	timestampStr, guid, found := strings.Cut(string(raw), ":")
+	if !found || guid == "" {
+		return 0, "", ErrInvalidCursor
+	}
+	timestamp, err := strconv.ParseUint(timestampStr, 10, 64)
+	if err != nil {
+		return 0, "", ErrInvalidCursor
+	}
+	return timestamp, guid, nil
+}
+
+// applyTxQuery 拼接交易类表（deposits / withdraws / internals / transactions）的过滤条件，
+// 地址通过 child_txs 关联：扫链记录按交易哈希关联，还没上链的提现和内部交易按 tx_id 关联 guid
+func applyTxQuery(tx *gorm.DB, requestId string, q TxQuery) *gorm.DB {
+	if q.Guid != "" {
+		tx = tx.Where("guid = ?", q.Guid)
+	}
+	if len(q.Status) > 0 {
+		tx = tx.Where("status IN ?", q.Status)
+	}
+	if q.Hash != "" {
+		tx = tx.Where("hash = ?", q.Hash)
+	}
+	if q.Address != "" {
+		childTable := "child_txs_" + requestId
+		tx = tx.Where(
+			"hash IN (SELECT hash FROM "+childTable+" WHERE from_address = ? OR to_address = ?) OR guid IN (SELECT tx_id FROM "+childTable+" WHERE from_address = ? OR to_address = ?)",
+			q.Address, q.Address, q.Address, q.Address,
+		)
+	}
+	if q.FromHeight > 0 {
+		tx = tx.Where("block_number >= ?", q.FromHeight)
+	}
+	if q.ToHeight > 0 {
+		tx = tx.Where("block_number <= ?", q.ToHeight)
+	}
+	return applyTimeQuery(tx, q)
+}
+
+func applyTimeQuery(tx *gorm.DB, q TxQuery) *gorm.DB {
+	if q.FromTime > 0 {
+		tx = tx.Where("timestamp >= ?", q.FromTime)
+	}
+	if q.ToTime > 0 {
+		tx = tx.Where("timestamp <= ?", q.ToTime)
+	}
+	return tx
+}
+
+// queryPage 按 (timestamp, guid) 倒序取一页，多取一条用来判断是否还有下一页
+func queryPage[T any](tx *gorm.DB, q TxQuery, cursorOf func(T) (uint64, string)) ([]T, string, error) {
+	if q.Cursor != "" {
+		timestamp, guid, err := DecodeCursor(q.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		tx = tx.Where("(timestamp, guid) < (?, ?)", timestamp, guid)
+	}
+	limit := q.PageLimit()
+	var list []T
+	if err := tx.Order("timestamp DESC, guid DESC").Limit(limit + 1).Find(&list).Error; err != nil {
+		return nil, "", err
+	}
+	if len(list) <= limit {
+		return list, "", nil
+	}
+	list = list[:limit]
+	return list, EncodeCursor(cursorOf(list[limit-1])), nil
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	cursor := EncodeCursor(1700000000, "3f1c2a4e-0000-4000-8000-000000000001")
+	timestamp, guid, err := DecodeCursor(cursor)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1700000000), timestamp)
+	require.Equal(t, "3f1c2a4e-0000-4000-8000-000000000001", guid)
+
+	for _, invalid := range []string{"!!", "MTIz", EncodeCursor(0, "")[:2]} {
+		_, _, err = DecodeCursor(invalid)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+	}
+}
+
+func TestPageLimit(t *testing.T) {
+	require.Equal(t, DefaultPageLimit, TxQuery{}.PageLimit())
+	require.Equal(t, 10, TxQuery{Limit: 10}.PageLimit())
+	require.Equal(t, MaxPageLimit, TxQuery{Limit: MaxPageLimit + 1}.PageLimit())
+}
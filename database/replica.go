@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gorm.io/gorm"
+)
+
+// replicaLagQuery 从库回放延迟（秒），已经追平主库时为 0，避免主库空闲时 pg_last_xact_replay_timestamp 越来越旧被误判为延迟
+const replicaLagQuery = `SELECT CASE
+    WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+    ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+const replicaLagTimeout = 2 * time.Second
+
+// replica 从库连接，按 checkInterval 在后台检测延迟，检测失败或延迟超过 maxLag 时读请求回退到主库；
+// 第一次检测完成之前读主库
+type replica struct {
+	gorm *gorm.DB
+	view *ViewDB
+
+	maxLag        time.Duration
+	checkInterval time.Duration
+	lagFn         func(ctx context.Context) (time.Duration, error)
+
+	mu         sync.Mutex
+	checkedAt  time.Time
+	healthy    bool
+	lag        time.Duration
+	refreshing bool
+}
+
+func newReplica(db *gorm.DB, schema string, maxLag time.Duration, checkInterval time.Duration) *replica {
+	r := &replica{
+		gorm:          db,
+		view:          newViewDB(db, schema),
+		maxLag:        maxLag,
+		checkInterval: checkInterval,
+	}
+	r.lagFn = r.queryLag
+	return r
+}
+
+func (r *replica) queryLag(ctx context.Context) (time.Duration, error) {
+	var seconds float64
+	if err := r.gorm.WithContext(ctx).Raw(replicaLagQuery).Scan(&seconds).Error; err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// usable 返回最近一次检测的结果，不等待检测；结果过期时在后台发起一次检测，同一时间只有一个检测在执行
+func (r *replica) usable() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.refreshing && (r.checkedAt.IsZero() || time.Since(r.checkedAt) >= r.checkInterval) {
+		r.refreshing = true
+		go r.refresh()
+	}
+	return r.healthy
+}
+
+// lagging 从库不可用或者最近一次检测时还没有追平主库
+func (r *replica) lagging() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !r.healthy || r.lag > 0
+}
+
+func (r *replica) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), replicaLagTimeout)
+	defer cancel()
+	lag, err := r.lagFn(ctx)
+	healthy := err == nil && (r.maxLag <= 0 || lag <= r.maxLag)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if healthy != r.healthy || r.checkedAt.IsZero() {
+		if err != nil {
+			log.Warn("slave database unavailable, read from master", "err", err)
+		} else if !healthy {
+			log.Warn("slave database lag exceeds threshold, read from master", "lag", lag, "maxLag", r.maxLag)
+		} else {
+			log.Info("slave database in sync, read from slave", "lag", lag)
+		}
+	}
+	r.healthy = healthy
+	r.lag = lag
+	r.checkedAt = time.Now()
+	r.refreshing = false
+}
+
+func (r *replica) close() error {
+	sql, err := r.gorm.DB()
+	if err != nil {
+		return err
+	}
+	return sql.Close()
+}
@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitRefreshed 等待后台检测结束
+func waitRefreshed(t *testing.T, r *replica) {
+	require.Eventually(t, func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return !r.refreshing && !r.checkedAt.IsZero()
+	}, time.Second, time.Millisecond)
+}
+
+// expire 让检测结果过期，下一次 usable 重新检测
+func expire(r *replica) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkedAt = time.Now().Add(-r.checkInterval)
+}
+
+func TestReplicaUsable(t *testing.T) {
+	// 检测在后台执行，usable 发起检测和 waitRefreshed 都持有锁，测试里直接读写这些变量没有竞争
+	lag, lagErr, calls := time.Duration(0), error(nil), 0
+	r := &replica{maxLag: 10 * time.Second, checkInterval: time.Hour}
+	r.lagFn = func(context.Context) (time.Duration, error) {
+		calls++
+		return lag, lagErr
+	}
+
+	// 第一次检测完成之前读主库
+	require.False(t, r.usable())
+	waitRefreshed(t, r)
+	require.True(t, r.usable())
+
+	require.False(t, r.lagging())
+
+	// 检测结果在 checkInterval 内复用
+	lag = time.Minute
+	require.True(t, r.usable())
+	require.Equal(t, 1, calls)
+
+	// 过期之后先返回旧结果，后台检测完成后生效
+	expire(r)
+	require.True(t, r.usable())
+	waitRefreshed(t, r)
+	require.False(t, r.usable())
+
+	require.True(t, r.lagging())
+
+	// 延迟没有超过阈值时读从库，但还没有追平主库
+	expire(r)
+	lag = time.Second
+	r.usable()
+	waitRefreshed(t, r)
+	require.True(t, r.usable())
+	require.True(t, r.lagging())
+
+	expire(r)
+	lag, lagErr = 0, errors.New("connection refused")
+	r.usable()
+	waitRefreshed(t, r)
+	require.False(t, r.usable())
+
+	expire(r)
+	lagErr = nil
+	r.usable()
+	waitRefreshed(t, r)
+	require.True(t, r.usable())
+	require.False(t, r.lagging())
+	require.Equal(t, 5, calls)
+}
+
+func TestReplicaUsableSlowLag(t *testing.T) {
+	started, release := make(chan struct{}, 10), make(chan struct{})
+	r := &replica{maxLag: 10 * time.Second, checkInterval: time.Hour}
+	r.lagFn = func(context.Context) (time.Duration, error) {
+		started <- struct{}{}
+		<-release
+		return 0, nil
+	}
+
+	// 检测很慢时 usable 不等待，检测结束之前只发起一次检测
+	require.False(t, r.usable())
+	<-started
+	begin := time.Now()
+	for i := 0; i < 100; i++ {
+		require.False(t, r.usable())
+	}
+	require.Less(t, time.Since(begin), 100*time.Millisecond)
+
+	close(release)
+	waitRefreshed(t, r)
+	require.True(t, r.usable())
+	require.Empty(t, started)
+}
@@ -0,0 +1,41 @@
+package database
+
+// FailWithdraws 提现在广播之前失败（构建或签名失败、超过占用时限），释放它占用的 utxo 并标记为 done_fail，
+// 由 notifier 通知业务方；状态已经被其他流程改掉的提现返回 ErrStatusChanged，整个操作回滚
+func (db *DB) FailWithdraws(businessId string, withdraws []Withdraws) error {
+	if len(withdraws) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *DB) error {
+		for _, withdraw := range withdraws {
+			if err := tx.Utxos.ReleaseUtxos(businessId, withdraw.Guid.String()); err != nil {
+				return err
+			}
+		}
+		if err := tx.Withdraws.UpdateWithdrawStatus(businessId, TxStatusFail, withdraws); err != nil {
+			return err
+		}
+		return tx.NotifyApiCacheInvalidate(businessId, ApiCacheWithdraws, ApiCacheBalances)
+	})
+}
+
+// FailInternals CPFP 子交易在广播之前失败，释放它占用的 utxo 和父交易输出并标记为 done_fail
+func (db *DB) FailInternals(businessId string, internals []Internals) error {
+	if len(internals) == 0 {
+		return nil
+	}
+	return db.Transaction(func(tx *DB) error {
+		for _, internal := range internals {
+			if err := tx.Utxos.ReleaseUtxos(businessId, internal.Guid.String()); err != nil {
+				return err
+			}
+			if err := tx.CpfpReservations.ReleaseParentOutput(businessId, internal.Guid); err != nil {
+				return err
+			}
+		}
+		if err := tx.Internals.UpdateInternalStatus(businessId, TxStatusFail, internals); err != nil {
+			return err
+		}
+		return tx.NotifyApiCacheInvalidate(businessId, ApiCacheInternals, ApiCacheBalances)
+	})
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"math/big"
@@ -20,12 +21,16 @@ type Transactions struct {
 }
 
 type TransactionsView interface {
+	QueryTransactionsAfterBlock(requestId string, blockNumber *big.Int) ([]Transactions, error)
+	QueryTransactionByHash(requestId string, hash string) (*Transactions, error)
+	QueryTransactionsPage(requestId string, query TxQuery) ([]Transactions, string, error)
 }
 
 type TransactionsDB interface {
 	TransactionsView
 
 	StoreTransactions(string, []Transactions) error
+	DeleteTransactionsAfterBlock(requestId string, blockNumber *big.Int) error
 }
 
 type tansactionsDB struct {
@@ -40,3 +45,41 @@ func (db *tansactionsDB) StoreTransactions(requestId string, transactionsList []
 	result := db.gorm.Table("transactions_"+requestId).CreateInBatches(&transactionsList, len(transactionsList))
 	return result.Error
 }
+
+func (db *tansactionsDB) QueryTransactionsAfterBlock(requestId string, blockNumber *big.Int) ([]Transactions, error) {
+	var transactionsList []Transactions
+	err := db.gorm.Table("transactions_"+requestId).Where("block_number > ?", blockNumber.Uint64()).Find(&transactionsList).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactionsList, nil
+}
+
+func (db *tansactionsDB) DeleteTransactionsAfterBlock(requestId string, blockNumber *big.Int) error {
+	return db.gorm.Table("transactions_"+requestId).Where("block_number > ?", blockNumber.Uint64()).Delete(&Transactions{}).Error
+}
+
+func (db *tansactionsDB) QueryTransactionByHash(requestId string, hash string) (*Transactions, error) {
+	var transaction Transactions
+	err := db.gorm.Table("transactions_"+requestId).Where("hash = ?", hash).Take(&transaction).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// QueryTransactionsPage 按过滤条件分页查询扫到的交易流水，返回下一页的游标
+func (db *tansactionsDB) QueryTransactionsPage(requestId string, query TxQuery) ([]Transactions, string, error) {
+	tx := applyTxQuery(db.gorm.Table("transactions_"+requestId), requestId, query)
+	if query.TxType != "" {
+		tx = tx.Where("tx_type = ?", query.TxType)
+	}
+	transactionsList, nextCursor, err := queryPage(tx, query, func(transaction Transactions) (uint64, string) {
+		return transaction.Timestamp, transaction.GUID.String()
+	})
+	if err != nil {
+		log.Error("query transactions page fail", "requestId", requestId, "err", err)
+		return nil, "", err
+	}
+	return transactionsList, nextCursor, nil
+}
@@ -0,0 +1,294 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Utxos struct {
+	GUID          uuid.UUID `gorm:"primaryKey" json:"guid"`
+	TxId          string    `json:"tx_id"`
+	VoutIndex     uint32    `json:"vout_index"`
+	Address       string    `json:"address"`
+	AddressType   uint8     `json:"address_type"` //0:用户地址；1:热钱包地址(归集地址)；2:冷钱包地址
+	Amount        *big.Int  `gorm:"serializer:u256" json:"amount"`
+	Script        string    `json:"script"`
+	BlockHash     string    `json:"block_hash"`
+	CreatedHeight *big.Int  `gorm:"serializer:u256" json:"created_height"`
+	SpendTxHash   string    `json:"spend_tx_hash"`
+	SpentHeight   *big.Int  `gorm:"serializer:u256" json:"spent_height"`
+	IsSpent       bool      `json:"is_spent"`
+	ReservedBy    string    `json:"reserved_by"`
+	Timestamp     uint64    `json:"timestamp"`
+}
+
+// SpentOutpoint 扫到的交易输入，指向被花费的 (txid, vout index)
+type SpentOutpoint struct {
+	TxId        string
+	VoutIndex   uint32
+	SpendTxHash string
+	SpentHeight *big.Int
+}
+
+// UtxoBalance 按未花费 utxo 汇总的余额
+// Confirmed: 未被占用且确认数达到 finalized 的 utxo；Pending: 未被占用但确认数不足的 utxo；Locked: 被未上链的提现或内部交易占用的 utxo
+type UtxoBalance struct {
+	AddressType uint8
+	Confirmed   *big.Int
+	Pending     *big.Int
+	Locked      *big.Int
+	UtxoCount   uint64
+}
+
+// utxoBalanceRow 金额在 SQL 里转成文本，避免 UINT256 超出 int64
+type utxoBalanceRow struct {
+	AddressType uint8
+	Confirmed   string
+	Pending     string
+	Locked      string
+	UtxoCount   uint64
+}
+
+func (row utxoBalanceRow) toBalance() (UtxoBalance, error) {
+	balance := UtxoBalance{AddressType: row.AddressType, UtxoCount: row.UtxoCount}
+	var ok bool
+	if balance.Confirmed, ok = new(big.Int).SetString(row.Confirmed, 10); !ok {
+		return UtxoBalance{}, errors.New("invalid utxo balance: " + row.Confirmed)
+	}
+	if balance.Pending, ok = new(big.Int).SetString(row.Pending, 10); !ok {
+		return UtxoBalance{}, errors.New("invalid utxo balance: " + row.Pending)
+	}
+	if balance.Locked, ok = new(big.Int).SetString(row.Locked, 10); !ok {
+		return UtxoBalance{}, errors.New("invalid utxo balance: " + row.Locked)
+	}
+	return balance, nil
+}
+
+// utxoBalanceColumns created_height 不超过 confirmedHeight 的 utxo 视为已确认
+const utxoBalanceColumns = `COALESCE(SUM(amount) FILTER (WHERE reserved_by = '' AND created_height <= ?), 0)::TEXT AS confirmed,
+COALESCE(SUM(amount) FILTER (WHERE reserved_by = '' AND created_height > ?), 0)::TEXT AS pending,
+COALESCE(SUM(amount) FILTER (WHERE reserved_by <> ''), 0)::TEXT AS locked,
+COUNT(*) AS utxo_count`
+
+type UtxoView interface {
+	QueryUtxo(businessId string, txId string, voutIndex uint32) (*Utxos, error)
+	QueryUnspentUtxosByAddress(businessId string, address string) ([]Utxos, error)
+	QueryUnspentUtxosByAddressType(businessId string, addressType uint8) ([]Utxos, error)
+	QueryUnspentBalance(businessId string, address string) (*big.Int, error)
+	QueryReservedUtxos(businessId string, reservedBy string) ([]Utxos, error)
+	QueryUtxosPage(businessId string, address string, includeSpent bool, query TxQuery) ([]Utxos, string, error)
+	QueryAddressUtxoBalance(businessId string, address string, confirmedHeight uint64) (*UtxoBalance, error)
+	QueryUtxoBalancesByAddressType(businessId string, confirmedHeight uint64) ([]UtxoBalance, error)
+}
+
+type UtxoDB interface {
+	UtxoView
+
+	StoreUtxos(string, []Utxos) error
+	SpendUtxos(string, []SpentOutpoint) error
+	UnSpendUtxosBySpendTxHashes(string, []string) error
+	DeleteUtxosByTxIds(string, []string) error
+	LockAvailableUtxos(businessId string, address string) ([]Utxos, error)
+	ReserveUtxos(businessId string, reservedBy string, utxos []Utxos) error
+	ReleaseUtxos(businessId string, reservedBy string) error
+}
+
+type utxoDB struct {
+	gorm *gorm.DB
+}
+
+func NewUtxoDB(db *gorm.DB) UtxoDB {
+	return &utxoDB{gorm: db}
+}
+
+func (db *utxoDB) QueryUtxo(businessId string, txId string, voutIndex uint32) (*Utxos, error) {
+	var utxo Utxos
+	err := db.gorm.Table("utxos_"+businessId).Where("tx_id = ? AND vout_index = ?", txId, voutIndex).Take(&utxo).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &utxo, nil
+}
+
+func (db *utxoDB) QueryUnspentUtxosByAddress(businessId string, address string) ([]Utxos, error) {
+	var utxos []Utxos
+	err := db.gorm.Table("utxos_"+businessId).Where("address = ? AND is_spent = ?", address, false).Order("created_height ASC").Find(&utxos).Error
+	if err != nil {
+		return nil, err
+	}
+	return utxos, nil
+}
+
+func (db *utxoDB) QueryUnspentUtxosByAddressType(businessId string, addressType uint8) ([]Utxos, error) {
+	var utxos []Utxos
+	err := db.gorm.Table("utxos_"+businessId).Where("address_type = ? AND is_spent = ?", addressType, false).Order("created_height ASC").Find(&utxos).Error
+	if err != nil {
+		return nil, err
+	}
+	return utxos, nil
+}
+
+// QueryUnspentBalance 地址所有未花费 utxo 的金额之和
+func (db *utxoDB) QueryUnspentBalance(businessId string, address string) (*big.Int, error) {
+	var sum string
+	err := db.gorm.Table("utxos_"+businessId).Select("COALESCE(SUM(amount), 0)::TEXT").Where("address = ? AND is_spent = ?", address, false).Scan(&sum).Error
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := new(big.Int).SetString(sum, 10)
+	if !ok {
+		return nil, errors.New("invalid utxo balance: " + sum)
+	}
+	return balance, nil
+}
+
+// QueryReservedUtxos 查询交易占用且尚未花费的 utxo，也就是这笔交易的输入
+func (db *utxoDB) QueryReservedUtxos(businessId string, reservedBy string) ([]Utxos, error) {
+	var utxos []Utxos
+	err := db.gorm.Table("utxos_"+businessId).
+		Where("reserved_by = ? AND is_spent = ?", reservedBy, false).
+		Order("created_height ASC").Find(&utxos).Error
+	if err != nil {
+		return nil, err
+	}
+	return utxos, nil
+}
+
+// StoreUtxos 同一个输出重复扫描（比如重试批次）时忽略
+func (db *utxoDB) StoreUtxos(businessId string, utxos []Utxos) error {
+	if len(utxos) == 0 {
+		return nil
+	}
+	result := db.gorm.Table("utxos_"+businessId).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tx_id"}, {Name: "vout_index"}},
+		DoNothing: true,
+	}).CreateInBatches(&utxos, len(utxos))
+	return result.Error
+}
+
+// SpendUtxos 把被输入引用的 utxo 标记为已花费，不属于业务方的输入没有对应记录，直接跳过
+func (db *utxoDB) SpendUtxos(businessId string, outpoints []SpentOutpoint) error {
+	for _, outpoint := range outpoints {
+		updates := map[string]interface{}{
+			"is_spent":      true,
+			"spend_tx_hash": outpoint.SpendTxHash,
+			"spent_height":  outpoint.SpentHeight.String(),
+		}
+		err := db.gorm.Table("utxos_"+businessId).Where("tx_id = ? AND vout_index = ?", outpoint.TxId, outpoint.VoutIndex).Updates(updates).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnSpendUtxosBySpendTxHashes 花费交易被重组掉之后，恢复对应 utxo 为未花费
+func (db *utxoDB) UnSpendUtxosBySpendTxHashes(businessId string, spendTxHashes []string) error {
+	if len(spendTxHashes) == 0 {
+		return nil
+	}
+	updates := map[string]interface{}{
+		"is_spent":      false,
+		"spend_tx_hash": "",
+		"spent_height":  "0",
+	}
+	return db.gorm.Table("utxos_"+businessId).Where("spend_tx_hash IN ?", spendTxHashes).Updates(updates).Error
+}
+
+func (db *utxoDB) DeleteUtxosByTxIds(businessId string, txIds []string) error {
+	if len(txIds) == 0 {
+		return nil
+	}
+	return db.gorm.Table("utxos_"+businessId).Where("tx_id IN ?", txIds).Delete(&Utxos{}).Error
+}
+
+// LockAvailableUtxos 在事务里锁住地址未花费且未被占用的 utxo，并发构建交易时后来者会等前一个事务提交后再读
+func (db *utxoDB) LockAvailableUtxos(businessId string, address string) ([]Utxos, error) {
+	var utxos []Utxos
+	err := db.gorm.Table("utxos_"+businessId).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("address = ? AND is_spent = ? AND reserved_by = ?", address, false, "").
+		Order("created_height ASC").Find(&utxos).Error
+	if err != nil {
+		return nil, err
+	}
+	return utxos, nil
+}
+
+func (db *utxoDB) ReserveUtxos(businessId string, reservedBy string, utxos []Utxos) error {
+	for _, utxo := range utxos {
+		result := db.gorm.Table("utxos_"+businessId).
+			Where("tx_id = ? AND vout_index = ? AND reserved_by = ?", utxo.TxId, utxo.VoutIndex, "").
+			Update("reserved_by", reservedBy)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("utxo %s:%d already reserved", utxo.TxId, utxo.VoutIndex)
+		}
+	}
+	return nil
+}
+
+// ReleaseUtxos 交易作废后释放它占用的未花费 utxo
+func (db *utxoDB) ReleaseUtxos(businessId string, reservedBy string) error {
+	return db.gorm.Table("utxos_"+businessId).
+		Where("reserved_by = ? AND is_spent = ?", reservedBy, false).
+		Update("reserved_by", "").Error
+}
+
+// QueryUtxosPage 按 (timestamp, guid) 倒序分页查询地址的 utxo，includeSpent 为 false 时只返回未花费的
+func (db *utxoDB) QueryUtxosPage(businessId string, address string, includeSpent bool, query TxQuery) ([]Utxos, string, error) {
+	tx := db.gorm.Table("utxos_"+businessId).Where("address = ?", address)
+	if !includeSpent {
+		tx = tx.Where("is_spent = ?", false)
+	}
+	return queryPage(tx, query, func(utxo Utxos) (uint64, string) {
+		return utxo.Timestamp, utxo.GUID.String()
+	})
+}
+
+func (db *utxoDB) QueryAddressUtxoBalance(businessId string, address string, confirmedHeight uint64) (*UtxoBalance, error) {
+	var row utxoBalanceRow
+	err := db.gorm.Table("utxos_"+businessId).
+		Select(utxoBalanceColumns, confirmedHeight, confirmedHeight).
+		Where("address = ? AND is_spent = ?", address, false).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	balance, err := row.toBalance()
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// QueryUtxoBalancesByAddressType 按地址类型（用户、热钱包、冷钱包）汇总业务方的余额，在数据库里聚合
+func (db *utxoDB) QueryUtxoBalancesByAddressType(businessId string, confirmedHeight uint64) ([]UtxoBalance, error) {
+	var rows []utxoBalanceRow
+	err := db.gorm.Table("utxos_"+businessId).
+		Select("address_type, "+utxoBalanceColumns, confirmedHeight, confirmedHeight).
+		Where("is_spent = ?", false).
+		Group("address_type").Order("address_type").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]UtxoBalance, 0, len(rows))
+	for _, row := range rows {
+		balance, err := row.toBalance()
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUtxoBalanceRowToBalance(t *testing.T) {
+	// 金额超过 int64 时按文本解析，不会溢出
+	row := utxoBalanceRow{AddressType: 1, Confirmed: "123456789012345678901234567890", Pending: "0", Locked: "42", UtxoCount: 3}
+	balance, err := row.toBalance()
+	require.NoError(t, err)
+	confirmed, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.Equal(t, UtxoBalance{
+		AddressType: 1,
+		Confirmed:   confirmed,
+		Pending:     big.NewInt(0),
+		Locked:      big.NewInt(42),
+		UtxoCount:   3,
+	}, balance)
+
+	for _, invalid := range []utxoBalanceRow{
+		{Confirmed: "", Pending: "0", Locked: "0"},
+		{Confirmed: "0", Pending: "1.5", Locked: "0"},
+		{Confirmed: "0", Pending: "0", Locked: "abc"},
+	} {
+		_, err := invalid.toBalance()
+		require.Error(t, err)
+	}
+}
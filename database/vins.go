@@ -11,7 +11,7 @@ type Vins struct {
 	GUID             uuid.UUID `gorm:"primaryKey" json:"guid"`
 	Address          string    `json:"address"`
 	TxId             string    `json:"tx_id"`
-	Vout             uint8     `json:"vout"`
+	Vout             uint32    `json:"vout"`
 	Script           string    `json:"script"`
 	Witness          string    `json:"witness"`
 	Amount           *big.Int  `gorm:"serializer:u256" json:"amount"`
@@ -24,13 +24,16 @@ type Vins struct {
 type VinsView interface {
 	QueryVinByTxId(string, string, string) (*Vins, error)
 	QueryVinsByAddress(string, string) ([]Vins, error)
+	QueryVinsByTxIds(string, []string) ([]Vins, error)
+	QueryVinsBySpendTxHash(businessId string, spendTxHash string) ([]Vins, error)
 }
 
 type VinsDB interface {
 	VinsView
 
 	StoreVins(string, []Vins) error
-	UpdateVinsTx(requestId string, txId string, address string, IsSpend bool, spendTxHash string, spendBlockHeight *big.Int) error
+	DeleteVinsByTxIds(string, []string) error
+	UnSpendVinsBySpendTxHashes(string, []string) error
 }
 
 type vinsDB struct {
@@ -70,29 +73,44 @@ func (vin vinsDB) StoreVins(businessId string, vins []Vins) error {
 	return result.Error
 }
 
-func (vin vinsDB) UpdateVinsTx(requestId string, txId string, address string, IsSpend bool, spendTxHash string, spendBlockHeight *big.Int) error {
-
-	updates := map[string]interface{}{
-		"is_spend": false,
+func (vin vinsDB) QueryVinsByTxIds(businessId string, txIds []string) ([]Vins, error) {
+	var vinsEntry []Vins
+	if len(txIds) == 0 {
+		return vinsEntry, nil
 	}
-
-	if (spendTxHash != "" && spendBlockHeight != big.NewInt(0)) || IsSpend {
-		updates["spend_tx_hash"] = spendTxHash
-		updates["spend_block_height"] = spendBlockHeight
-		updates["is_spend"] = true
+	err := vin.gorm.Table("vins_"+businessId).Where("tx_id IN ?", txIds).Find(&vinsEntry).Error
+	if err != nil {
+		return nil, err
 	}
+	return vinsEntry, nil
+}
 
-	result := vin.gorm.Table("internals_"+requestId).
-		Where("txId = ? and address = ?", txId, address).
-		Updates(updates)
-
-	if result.Error != nil {
-		return result.Error
+func (vin vinsDB) DeleteVinsByTxIds(businessId string, txIds []string) error {
+	if len(txIds) == 0 {
+		return nil
 	}
+	return vin.gorm.Table("vins_"+businessId).Where("tx_id IN ?", txIds).Delete(&Vins{}).Error
+}
 
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+// UnSpendVinsBySpendTxHashes 被花费交易被重组掉之后，恢复对应 utxo 为未花费
+func (vin vinsDB) UnSpendVinsBySpendTxHashes(businessId string, spendTxHashes []string) error {
+	if len(spendTxHashes) == 0 {
+		return nil
+	}
+	updates := map[string]interface{}{
+		"is_spend":           false,
+		"spend_tx_hash":      "",
+		"spend_block_height": "0",
 	}
+	return vin.gorm.Table("vins_"+businessId).Where("spend_tx_hash IN ?", spendTxHashes).Updates(updates).Error
+}
 
-	return nil
+// QueryVinsBySpendTxHash 查询一笔交易花费的输入
+func (vin vinsDB) QueryVinsBySpendTxHash(businessId string, spendTxHash string) ([]Vins, error) {
+	var vinsEntry []Vins
+	err := vin.gorm.Table("vins_"+businessId).Where("spend_tx_hash = ?", spendTxHash).Find(&vinsEntry).Error
+	if err != nil {
+		return nil, err
+	}
+	return vinsEntry, nil
 }
@@ -8,20 +8,24 @@ import (
 
 type Vouts struct {
 	GUID      uuid.UUID `gorm:"primaryKey" json:"guid"`
+	TxId      string    `json:"tx_id"`
 	Address   string    `json:"address"`
-	N         uint8     `json:"n"`
+	N         uint32    `json:"n"`
 	Script    string    `json:"script"`
 	Amount    *big.Int  `gorm:"serializer:u256" json:"amount"`
 	Timestamp uint64    `json:"timestamp"`
 }
 
 type VoutsView interface {
+	QueryVoutsByTxId(businessId string, txId string) ([]Vouts, error)
+	QueryVoutsByTxIds(businessId string, txIds []string) ([]Vouts, error)
 }
 
 type VoutsDB interface {
 	VoutsView
 
 	StoreVouts(string, []Vouts) error
+	DeleteVoutsByTxIds(string, []string) error
 }
 
 type voutsDB struct {
@@ -33,6 +37,34 @@ func NewVoutsDB(db *gorm.DB) VoutsDB {
 }
 
 func (vout voutsDB) StoreVouts(businessId string, vouts []Vouts) error {
-	result := vout.gorm.Table("vouts"+businessId).CreateInBatches(&vouts, len(vouts))
+	result := vout.gorm.Table("vouts_"+businessId).CreateInBatches(&vouts, len(vouts))
 	return result.Error
 }
+
+func (vout voutsDB) DeleteVoutsByTxIds(businessId string, txIds []string) error {
+	if len(txIds) == 0 {
+		return nil
+	}
+	return vout.gorm.Table("vouts_"+businessId).Where("tx_id IN ?", txIds).Delete(&Vouts{}).Error
+}
+
+func (vout voutsDB) QueryVoutsByTxId(businessId string, txId string) ([]Vouts, error) {
+	var voutList []Vouts
+	err := vout.gorm.Table("vouts_"+businessId).Where("tx_id = ?", txId).Order("n").Find(&voutList).Error
+	if err != nil {
+		return nil, err
+	}
+	return voutList, nil
+}
+
+func (vout voutsDB) QueryVoutsByTxIds(businessId string, txIds []string) ([]Vouts, error) {
+	var voutList []Vouts
+	if len(txIds) == 0 {
+		return voutList, nil
+	}
+	err := vout.gorm.Table("vouts_"+businessId).Where("tx_id IN ?", txIds).Find(&voutList).Error
+	if err != nil {
+		return nil, err
+	}
+	return voutList, nil
+}
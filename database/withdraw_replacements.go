@@ -0,0 +1,139 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WithdrawReplacements 提现交易的 RBF 替换交易，花费和原交易相同的输入，费率更高
+type WithdrawReplacements struct {
+	GUID         uuid.UUID `gorm:"primaryKey" json:"guid"`
+	WithdrawGuid uuid.UUID `json:"withdraw_guid"`
+	ReplacedHash string    `json:"replaced_hash"`
+	Hash         string    `json:"hash"`
+	Fee          *big.Int  `gorm:"serializer:u256" json:"fee"`
+	FeeRate      int64     `json:"fee_rate"`
+	VSize        int64     `gorm:"column:vsize" json:"vsize"`
+	UnSignTx     string    `json:"un_sign_tx"`
+	TxData       string    `json:"tx_data"`
+	TxSignHex    string    `json:"tx_sign_hex"`
+	Status       TxStatus  `json:"status"`
+	Timestamp    uint64    `json:"timestamp"`
+}
+
+// PendingReplacementStatusList 还没有广播的替换交易状态，同一笔提现同时只允许有一笔
+var PendingReplacementStatusList = []TxStatus{
+	TxStatusWaitSign,
+	TxStatusInternalCallBack,
+	TxStatusUnSent,
+}
+
+type WithdrawReplacementsView interface {
+	QueryReplacementByGuid(businessId string, guid string) (*WithdrawReplacements, error)
+	QueryReplacementsByStatus(businessId string, status TxStatus) ([]WithdrawReplacements, error)
+	QueryPendingReplacement(businessId string, withdrawGuid uuid.UUID) (*WithdrawReplacements, error)
+	QueryFailedReplacement(businessId string, replacedHash string) (*WithdrawReplacements, error)
+}
+
+type WithdrawReplacementsDB interface {
+	WithdrawReplacementsView
+
+	StoreReplacement(string, *WithdrawReplacements) error
+	UpdateReplacementStatus(businessId string, status TxStatus, replacements []WithdrawReplacements) error
+	UpdateReplacementSigned(businessId string, guid string, txSignHex string) error
+	UpdateSentReplacement(businessId string, guid uuid.UUID, hash string) error
+}
+
+type withdrawReplacementsDB struct {
+	gorm *gorm.DB
+}
+
+func NewWithdrawReplacementsDB(db *gorm.DB) WithdrawReplacementsDB {
+	return &withdrawReplacementsDB{gorm: db}
+}
+
+func (db *withdrawReplacementsDB) StoreReplacement(businessId string, replacement *WithdrawReplacements) error {
+	return db.gorm.Table("withdraw_replacements_" + businessId).Create(replacement).Error
+}
+
+func (db *withdrawReplacementsDB) QueryReplacementByGuid(businessId string, guid string) (*WithdrawReplacements, error) {
+	var replacement WithdrawReplacements
+	err := db.gorm.Table("withdraw_replacements_"+businessId).Where("guid = ?", guid).Take(&replacement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &replacement, nil
+}
+
+func (db *withdrawReplacementsDB) QueryReplacementsByStatus(businessId string, status TxStatus) ([]WithdrawReplacements, error) {
+	var replacements []WithdrawReplacements
+	err := db.gorm.Table("withdraw_replacements_"+businessId).Where("status = ?", status).Order("timestamp ASC").Find(&replacements).Error
+	if err != nil {
+		return nil, fmt.Errorf("query withdraw replacements by status failed: %w", err)
+	}
+	return replacements, nil
+}
+
+func (db *withdrawReplacementsDB) QueryPendingReplacement(businessId string, withdrawGuid uuid.UUID) (*WithdrawReplacements, error) {
+	var replacement WithdrawReplacements
+	err := db.gorm.Table("withdraw_replacements_"+businessId).
+		Where("withdraw_guid = ? AND status IN ?", withdrawGuid, PendingReplacementStatusList).
+		Take(&replacement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &replacement, nil
+}
+
+// QueryFailedReplacement 查询替换同一笔原交易、已经作废的替换交易
+func (db *withdrawReplacementsDB) QueryFailedReplacement(businessId string, replacedHash string) (*WithdrawReplacements, error) {
+	var replacement WithdrawReplacements
+	err := db.gorm.Table("withdraw_replacements_"+businessId).
+		Where("replaced_hash = ? AND status = ?", replacedHash, TxStatusFail).
+		Take(&replacement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &replacement, nil
+}
+
+func (db *withdrawReplacementsDB) UpdateReplacementStatus(businessId string, status TxStatus, replacements []WithdrawReplacements) error {
+	if len(replacements) == 0 {
+		return nil
+	}
+	var guids []uuid.UUID
+	for _, replacement := range replacements {
+		guids = append(guids, replacement.GUID)
+	}
+	return db.gorm.Table("withdraw_replacements_"+businessId).Where("guid IN ?", guids).Update("status", status).Error
+}
+
+// UpdateReplacementSigned 业务方签名之后保存完整交易，等待广播
+func (db *withdrawReplacementsDB) UpdateReplacementSigned(businessId string, guid string, txSignHex string) error {
+	updates := map[string]interface{}{
+		"tx_sign_hex": txSignHex,
+		"status":      TxStatusUnSent,
+	}
+	return db.gorm.Table("withdraw_replacements_"+businessId).Where("guid = ?", guid).Updates(updates).Error
+}
+
+func (db *withdrawReplacementsDB) UpdateSentReplacement(businessId string, guid uuid.UUID, hash string) error {
+	updates := map[string]interface{}{
+		"hash":   hash,
+		"status": TxStatusSent,
+	}
+	return db.gorm.Table("withdraw_replacements_"+businessId).Where("guid = ?", guid).Updates(updates).Error
+}
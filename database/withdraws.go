@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 
 	"gorm.io/gorm"
@@ -11,20 +12,31 @@ import (
 )
 
 type Withdraws struct {
-	Guid        uuid.UUID `gorm:"primaryKey" json:"guid"`
-	BlockHash   string    `json:"block_hash"`
-	BlockNumber *big.Int  `gorm:"serializer:u256;check:block_number > 0" json:"block_number"`
-	Hash        string    `json:"hash"`
-	Fee         *big.Int  `gorm:"serializer:u256" json:"fee"`
-	LockTime    *big.Int  `gorm:"serializer:u256" json:"lock_time"`
-	Version     string    `json:"version"`
-	TxSignHex   string    `json:"tx_sign_hex"`
-	Status      TxStatus  `json:"status"`
-	Timestamp   uint64    `json:"timestamp"`
+	Guid         uuid.UUID `gorm:"primaryKey" json:"guid"`
+	BlockHash    string    `json:"block_hash"`
+	BlockNumber  *big.Int  `gorm:"serializer:u256;check:block_number > 0" json:"block_number"`
+	Hash         string    `json:"hash"`
+	Fee          *big.Int  `gorm:"serializer:u256" json:"fee"`
+	VSize        int64     `gorm:"column:vsize" json:"vsize"`
+	FeeRate      int64     `json:"fee_rate"`
+	LockTime     *big.Int  `gorm:"serializer:u256" json:"lock_time"`
+	Version      string    `json:"version"`
+	TxSignHex    string    `json:"tx_sign_hex"`
+	SentHeight   *big.Int  `gorm:"serializer:u256" json:"sent_height"`
+	ReplaceCount int       `json:"replace_count"`
+	Status       TxStatus  `json:"status"`
+	Timestamp    uint64    `json:"timestamp"`
 }
 
 type WithdrawsView interface {
 	QueryNotifyWithdraws(requestId string) ([]Withdraws, error)
+	QueryFallbackWithdraws(requestId string) ([]Withdraws, error)
+	QueryWithdrawsByStatus(requestId string, status TxStatus) ([]Withdraws, error)
+	QueryWithdrawByGuid(requestId string, guid uuid.UUID) (*Withdraws, error)
+	QueryWithdrawByHash(requestId string, hash string) (*Withdraws, error)
+	QueryStuckWithdraws(requestId string, maxSentHeight *big.Int) ([]Withdraws, error)
+	QueryExpiredWithdraws(requestId string, before uint64) ([]Withdraws, error)
+	QueryWithdrawsPage(requestId string, query TxQuery) ([]Withdraws, string, error)
 
 	UnSendWithdrawsList(requestId string) ([]Withdraws, error)
 }
@@ -35,6 +47,9 @@ type WithdrawsDB interface {
 	StoreWithdraws(string, *Withdraws) error
 	UpdateWithdrawStatus(requestId string, status TxStatus, withdrawsList []Withdraws) error
 	UpdateWithdrawByGuuid(requestId string, transactionId string, txSignedHex string) error
+	UpdateSentWithdraws(requestId string, withdrawsList []Withdraws) error
+	UpdateWithdrawStatusByTxHash(requestId string, status TxStatus, withdrawsList []Withdraws) error
+	ReplaceWithdraw(requestId string, replacement *WithdrawReplacements, sentHeight *big.Int) error
 }
 
 type withdrawsDB struct {
@@ -50,59 +65,53 @@ func (db *withdrawsDB) StoreWithdraws(requestId string, withdrawsList *Withdraws
 	return result.Error
 }
 
+// UpdateWithdrawStatus 只更新状态仍然是读取时的值的记录，状态已被其他流程改掉的记录返回 ErrStatusChanged
 func (db *withdrawsDB) UpdateWithdrawStatus(requestId string, status TxStatus, withdrawsList []Withdraws) error {
 	if len(withdrawsList) == 0 {
 		return nil
 	}
 	tableName := fmt.Sprintf("withdraws_%s", requestId)
 
-	return db.gorm.Transaction(func(tx *gorm.DB) error {
-		var guids []uuid.UUID
-		for _, withdraw := range withdrawsList {
-			guids = append(guids, withdraw.Guid)
-		}
-
-		result := tx.Table(tableName).
-			Where("guid IN ?", guids).
-			Where("status = ?", TxStatusWithdrawed).
-			Update("status", status)
-
-		if result.Error != nil {
-			return fmt.Errorf("batch update status failed: %w", result.Error)
-		}
-
-		if result.RowsAffected == 0 {
-			log.Warn("No withdraws updated",
-				"requestId", requestId,
-				"expectedCount", len(withdrawsList),
-			)
-		}
-
-		log.Info("Batch update withdraws status success",
+	guidsByStatus := make(map[TxStatus][]uuid.UUID)
+	for _, withdraw := range withdrawsList {
+		guidsByStatus[withdraw.Status] = append(guidsByStatus[withdraw.Status], withdraw.Guid)
+	}
+	updated, err := updateStatusFrom(db.gorm, tableName, status, guidsByStatus, len(withdrawsList))
+	if errors.Is(err, ErrStatusChanged) {
+		log.Warn("Some withdraws status changed concurrently",
 			"requestId", requestId,
-			"count", result.RowsAffected,
-			"status", status,
+			"count", updated,
+			"expectedCount", len(withdrawsList),
 		)
+		return err
+	} else if err != nil {
+		return err
+	}
 
-		return nil
-	})
+	log.Info("Batch update withdraws status success",
+		"requestId", requestId,
+		"count", updated,
+		"status", status,
+	)
+	return nil
 }
 
+// UpdateWithdrawByGuuid 写入签名交易并转为待广播，只更新还在等待签名的提现；提现不存在或者已经超时作废时返回 ErrStatusChanged，
+// 超时之后才到的签名不能把作废的提现拉回来，它占用的 utxo 可能已经被别的提现使用
 func (db *withdrawsDB) UpdateWithdrawByGuuid(requestId string, transactionId string, txSignedHex string) error {
-	tableName := fmt.Sprintf("withdraws_%s", requestId)
-	var withdrawItem Withdraws
-	result := db.gorm.Table(tableName).Where("guid = ?", transactionId).Take(&withdrawItem)
+	updates := map[string]interface{}{
+		"tx_sign_hex": txSignedHex,
+		"status":      TxStatusUnSent,
+	}
+	result := db.gorm.Table("withdraws_"+requestId).
+		Where("guid = ? AND status = ?", transactionId, TxStatusWaitSign).
+		Updates(updates)
 	if result.Error != nil {
-		log.Error("query fail", "err", result.Error)
+		log.Error("update tx fail", "err", result.Error)
+		return result.Error
 	}
-
-	withdrawItem.TxSignHex = txSignedHex
-	withdrawItem.Status = TxStatusUnSent
-
-	err := db.gorm.Table(tableName).Save(withdrawItem).Error
-	if err != nil {
-		log.Error("update tx fail", "err", err)
-		return err
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("withdraw %s is not waiting for signature: %w", transactionId, ErrStatusChanged)
 	}
 	return nil
 }
@@ -110,7 +119,7 @@ func (db *withdrawsDB) UpdateWithdrawByGuuid(requestId string, transactionId str
 func (db *withdrawsDB) QueryNotifyWithdraws(requestId string) ([]Withdraws, error) {
 	var notifyWithdraws []Withdraws
 	result := db.gorm.Table("withdraws_"+requestId).
-		Where("status = ?", TxStatusWithdrawed).
+		Where("status IN ?", []TxStatus{TxStatusWithdrawed, TxStatusWithdrawedNotifyFail, TxStatusFail, TxStatusFailNotifyFail, TxStatusFallback, TxStatusFallbackNotifyFail}).
 		Find(&notifyWithdraws)
 
 	if result.Error != nil {
@@ -132,3 +141,150 @@ func (db *withdrawsDB) UnSendWithdrawsList(requestId string) ([]Withdraws, error
 
 	return withdrawsList, nil
 }
+
+// UpdateSentWithdraws 交易广播成功之后记录交易哈希，扫链时通过哈希匹配到提现记录
+func (db *withdrawsDB) UpdateSentWithdraws(requestId string, withdrawsList []Withdraws) error {
+	tableName := fmt.Sprintf("withdraws_%s", requestId)
+	for _, withdraw := range withdrawsList {
+		updates := map[string]interface{}{
+			"hash":        withdraw.Hash,
+			"sent_height": withdraw.SentHeight.String(),
+			"status":      TxStatusSent,
+		}
+		if err := db.gorm.Table(tableName).Where("guid = ?", withdraw.Guid).Updates(updates).Error; err != nil {
+			return fmt.Errorf("update sent withdraw failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdateWithdrawStatusByTxHash 扫链扫到提现交易之后，按交易哈希更新提现记录的状态和所在区块，
+// 被 RBF 替换过的提现无论原交易还是哪一笔替换交易上链，都通过替换链匹配到同一条提现记录
+func (db *withdrawsDB) UpdateWithdrawStatusByTxHash(requestId string, status TxStatus, withdrawsList []Withdraws) error {
+	tableName := fmt.Sprintf("withdraws_%s", requestId)
+	for _, withdraw := range withdrawsList {
+		updates := map[string]interface{}{
+			"hash":         withdraw.Hash,
+			"status":       status,
+			"block_hash":   withdraw.BlockHash,
+			"block_number": withdraw.BlockNumber.Uint64(),
+		}
+		replacedGuids := db.gorm.Table("withdraw_replacements_"+requestId).
+			Select("withdraw_guid").
+			Where("hash = ? OR replaced_hash = ?", withdraw.Hash, withdraw.Hash)
+		result := db.gorm.Table(tableName).Where("hash = ? OR guid IN (?)", withdraw.Hash, replacedGuids).Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("update withdraw by tx hash failed: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			log.Warn("No withdraw matched tx hash", "requestId", requestId, "hash", withdraw.Hash)
+		}
+	}
+	return nil
+}
+
+// QueryFallbackWithdraws 查询所在区块已经被重组掉、还没有进入回滚流程的提现交易
+func (db *withdrawsDB) QueryFallbackWithdraws(requestId string) ([]Withdraws, error) {
+	var fallbackWithdraws []Withdraws
+	err := db.gorm.Table("withdraws_"+requestId).
+		Where(orphanedBlockHashSql).
+		Where("status NOT IN ?", FallbackStatusList).
+		Find(&fallbackWithdraws).Error
+	if err != nil {
+		return nil, fmt.Errorf("query fallback withdraws failed: %w", err)
+	}
+	return fallbackWithdraws, nil
+}
+
+func (db *withdrawsDB) QueryWithdrawsByStatus(requestId string, status TxStatus) ([]Withdraws, error) {
+	var withdrawsList []Withdraws
+	err := db.gorm.Table("withdraws_"+requestId).Where("status = ?", status).Find(&withdrawsList).Error
+	if err != nil {
+		return nil, fmt.Errorf("query withdraws by status failed: %w", err)
+	}
+	return withdrawsList, nil
+}
+
+// QueryExpiredWithdraws 构建时间早于 before 仍未广播的提现（等待签名或签名后一直没有发送成功）
+func (db *withdrawsDB) QueryExpiredWithdraws(requestId string, before uint64) ([]Withdraws, error) {
+	var withdrawsList []Withdraws
+	err := db.gorm.Table("withdraws_"+requestId).
+		Where("status IN ? AND timestamp < ?", []TxStatus{TxStatusWaitSign, TxStatusUnSent}, before).
+		Find(&withdrawsList).Error
+	if err != nil {
+		return nil, fmt.Errorf("query expired withdraws failed: %w", err)
+	}
+	return withdrawsList, nil
+}
+
+func (db *withdrawsDB) QueryWithdrawByGuid(requestId string, guid uuid.UUID) (*Withdraws, error) {
+	var withdraw Withdraws
+	err := db.gorm.Table("withdraws_"+requestId).Where("guid = ?", guid).Take(&withdraw).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &withdraw, nil
+}
+
+func (db *withdrawsDB) QueryWithdrawByHash(requestId string, hash string) (*Withdraws, error) {
+	var withdraw Withdraws
+	err := db.gorm.Table("withdraws_"+requestId).Where("hash = ?", hash).Take(&withdraw).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &withdraw, nil
+}
+
+// QueryStuckWithdraws 查询已经广播、但在 maxSentHeight 及之前广播之后一直没有上链的提现交易
+func (db *withdrawsDB) QueryStuckWithdraws(requestId string, maxSentHeight *big.Int) ([]Withdraws, error) {
+	var withdrawsList []Withdraws
+	err := db.gorm.Table("withdraws_"+requestId).
+		Where("status = ? AND sent_height > 0 AND sent_height <= ?", TxStatusSent, maxSentHeight.String()).
+		Find(&withdrawsList).Error
+	if err != nil {
+		return nil, fmt.Errorf("query stuck withdraws failed: %w", err)
+	}
+	return withdrawsList, nil
+}
+
+// ReplaceWithdraw 替换交易广播成功之后，提现记录指向最新的交易哈希，并重新开始计算未确认的区块数
+func (db *withdrawsDB) ReplaceWithdraw(requestId string, replacement *WithdrawReplacements, sentHeight *big.Int) error {
+	updates := map[string]interface{}{
+		"hash":          replacement.Hash,
+		"fee":           replacement.Fee.String(),
+		"fee_rate":      replacement.FeeRate,
+		"vsize":         replacement.VSize,
+		"tx_sign_hex":   replacement.TxSignHex,
+		"sent_height":   sentHeight.String(),
+		"replace_count": gorm.Expr("replace_count + 1"),
+	}
+	result := db.gorm.Table("withdraws_"+requestId).
+		Where("guid = ? AND status = ?", replacement.WithdrawGuid, TxStatusSent).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("replace withdraw failed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		log.Warn("No sent withdraw matched replacement", "requestId", requestId, "withdrawGuid", replacement.WithdrawGuid)
+	}
+	return nil
+}
+
+// QueryWithdrawsPage 按过滤条件分页查询提现记录，返回下一页的游标，没有下一页时游标为空
+func (db *withdrawsDB) QueryWithdrawsPage(requestId string, query TxQuery) ([]Withdraws, string, error) {
+	tx := applyTxQuery(db.gorm.Table("withdraws_"+requestId), requestId, query)
+	withdrawList, nextCursor, err := queryPage(tx, query, func(withdraw Withdraws) (uint64, string) {
+		return withdraw.Timestamp, withdraw.Guid.String()
+	})
+	if err != nil {
+		log.Error("query withdraws page fail", "requestId", requestId, "err", err)
+		return nil, "", err
+	}
+	return withdrawList, nextCursor, nil
+}
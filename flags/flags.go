@@ -13,6 +13,12 @@ func prefixEnvVars(name string) []string {
 }
 
 var (
+	ConfigFileFlag = &cli.StringFlag{
+		Name:    "config",
+		Usage:   "Path of a yaml or toml config file, command line flags and environment variables take precedence over it",
+		EnvVars: prefixEnvVars("CONFIG"),
+	}
+
 	MigrationsFlag = &cli.StringFlag{
 		Name:    "migrations-dir",
 		Value:   "./migrations",
@@ -20,25 +26,16 @@ var (
 		EnvVars: prefixEnvVars("MIGRATIONS_DIR"),
 	}
 
-	ChainIdFlag = &cli.StringFlag{
-		Name:     "chain-id",
-		Usage:    "chain id",
-		EnvVars:  prefixEnvVars("CHAIN_ID"),
-		Required: true,
-	}
-
 	ChainNameFlag = &cli.StringFlag{
-		Name:     "chain-name",
-		Usage:    "chain name",
-		EnvVars:  prefixEnvVars("CHAIN_NAME"),
-		Required: true,
+		Name:    "chain-name",
+		Usage:   "The utxo chain to sync: btc, ltc, doge or bch",
+		EnvVars: prefixEnvVars("CHAIN_NAME"),
 	}
 
 	RpcUrlFlag = &cli.StringFlag{
-		Name:     "rpc-url",
-		Usage:    "HTTP provider URL for chain",
-		EnvVars:  prefixEnvVars("RPC_RUL"),
-		Required: true,
+		Name:    "rpc-url",
+		Usage:   "HTTP provider URL for chain",
+		EnvVars: prefixEnvVars("RPC_RUL"),
 	}
 
 	StartingHeightFlag = &cli.UintFlag{
@@ -53,6 +50,17 @@ var (
 		EnvVars: prefixEnvVars("CONFIRMATIONS"),
 		Value:   64,
 	}
+	SafeConfirmationsFlag = &cli.UintFlag{
+		Name:    "safe-confirmations",
+		Usage:   "The default confirmations for a deposit to become safe",
+		EnvVars: prefixEnvVars("SAFE_CONFIRMATIONS"),
+		Value:   6,
+	}
+	FinalizedConfirmationsFlag = &cli.UintFlag{
+		Name:    "finalized-confirmations",
+		Usage:   "The default confirmations for a deposit to become finalized, defaults to the confirmation depth",
+		EnvVars: prefixEnvVars("FINALIZED_CONFIRMATIONS"),
+	}
 	SynchronizerIntervalFlag = &cli.DurationFlag{
 		Name:    "sync-interval",
 		Usage:   "The interval of l1 synchronization",
@@ -71,86 +79,199 @@ var (
 		EnvVars: prefixEnvVars("BLOCKS_STEP"),
 		Value:   500,
 	}
+	HeaderFetchConcurrencyFlag = &cli.UintFlag{
+		Name:    "header-fetch-concurrency",
+		Usage:   "The max number of block headers fetched concurrently",
+		EnvVars: prefixEnvVars("HEADER_FETCH_CONCURRENCY"),
+		Value:   8,
+	}
+	BlockFetchConcurrencyFlag = &cli.UintFlag{
+		Name:    "block-fetch-concurrency",
+		Usage:   "The max number of blocks fetched concurrently",
+		EnvVars: prefixEnvVars("BLOCK_FETCH_CONCURRENCY"),
+		Value:   8,
+	}
+	FeeBumpBlocksFlag = &cli.UintFlag{
+		Name:    "fee-bump-blocks",
+		Usage:   "Replace a sent withdraw by fee when it is not confirmed within this number of blocks",
+		EnvVars: prefixEnvVars("FEE_BUMP_BLOCKS"),
+		Value:   6,
+	}
+	FeeBumpPercentFlag = &cli.UintFlag{
+		Name:    "fee-bump-percent",
+		Usage:   "The min percent a replacement raises the fee rate of a stuck withdraw",
+		EnvVars: prefixEnvVars("FEE_BUMP_PERCENT"),
+		Value:   25,
+	}
+	ReservationTtlFlag = &cli.DurationFlag{
+		Name:    "reservation-ttl",
+		Usage:   "Fail a withdraw or cpfp transaction and release its utxos when it is still not sent after this duration",
+		EnvVars: prefixEnvVars("RESERVATION_TTL"),
+		Value:   time.Minute * 30,
+	}
+	MempoolEnableFlag = &cli.BoolFlag{
+		Name:    "mempool-enable",
+		Usage:   "Watch the mempool for zero-confirmation deposits",
+		EnvVars: prefixEnvVars("MEMPOOL_ENABLE"),
+		Value:   true,
+	}
+	MempoolIntervalFlag = &cli.DurationFlag{
+		Name:    "mempool-interval",
+		Usage:   "The interval of mempool polling",
+		EnvVars: prefixEnvVars("MEMPOOL_INTERVAL"),
+		Value:   time.Second * 10,
+	}
+
+	RpcAdminTokenFlag = &cli.StringFlag{
+		Name:    "rpc-admin-token",
+		Usage:   "The admin token for business management rpc apis",
+		EnvVars: prefixEnvVars("RPC_ADMIN_TOKEN"),
+	}
+
+	RpcRateLimitFlag = &cli.Float64Flag{
+		Name:    "rpc-rate-limit",
+		Usage:   "The requests per second allowed for each business, 0 disables rate limiting",
+		EnvVars: prefixEnvVars("RPC_RATE_LIMIT"),
+		Value:   50,
+	}
+	RpcRateBurstFlag = &cli.IntFlag{
+		Name:    "rpc-rate-burst",
+		Usage:   "The burst size of the per-business rate limit",
+		EnvVars: prefixEnvVars("RPC_RATE_BURST"),
+		Value:   100,
+	}
+	RpcPeerRateLimitFlag = &cli.Float64Flag{
+		Name:    "rpc-peer-rate-limit",
+		Usage:   "The requests per second allowed for each peer address before authentication, 0 disables rate limiting",
+		EnvVars: prefixEnvVars("RPC_PEER_RATE_LIMIT"),
+		Value:   200,
+	}
+	RpcPeerRateBurstFlag = &cli.IntFlag{
+		Name:    "rpc-peer-rate-burst",
+		Usage:   "The burst size of the per-peer rate limit",
+		EnvVars: prefixEnvVars("RPC_PEER_RATE_BURST"),
+		Value:   400,
+	}
+	RpcShutdownTimeoutFlag = &cli.DurationFlag{
+		Name:    "rpc-shutdown-timeout",
+		Usage:   "The deadline for in-flight rpc requests on shutdown",
+		EnvVars: prefixEnvVars("RPC_SHUTDOWN_TIMEOUT"),
+		Value:   time.Second * 10,
+	}
 
 	// RpcHostFlag rpc api flags
 	RpcHostFlag = &cli.StringFlag{
-		Name:     "rpc-host",
-		Usage:    "The host of the rpc",
-		EnvVars:  prefixEnvVars("RPC_HOST"),
-		Required: true,
+		Name:    "rpc-host",
+		Usage:   "The host of the rpc",
+		EnvVars: prefixEnvVars("RPC_HOST"),
 	}
 	RpcPortFlag = &cli.IntFlag{
-		Name:     "rpc-port",
-		Usage:    "The port of the rpc",
-		EnvVars:  prefixEnvVars("RPC_PORT"),
-		Value:    8987,
-		Required: true,
+		Name:    "rpc-port",
+		Usage:   "The port of the rpc",
+		EnvVars: prefixEnvVars("RPC_PORT"),
+		Value:   8987,
 	}
 	ChainBtcRpcFlag = &cli.StringFlag{
-		Name:     "btc-rpc",
-		Usage:    "The host of chain account rpc",
-		EnvVars:  prefixEnvVars("CHAIN_BTC_RPC"),
-		Required: true,
+		Name:    "btc-rpc",
+		Usage:   "The host of chain account rpc",
+		EnvVars: prefixEnvVars("CHAIN_BTC_RPC"),
 	}
 
 	// MetricsHostFlag Metrics flags
 	MetricsHostFlag = &cli.StringFlag{
-		Name:     "metrics-host",
-		Usage:    "The host of the metrics",
-		EnvVars:  prefixEnvVars("METRICS_HOST"),
-		Required: true,
+		Name:    "metrics-host",
+		Usage:   "The host of the metrics",
+		EnvVars: prefixEnvVars("METRICS_HOST"),
 	}
 	MetricsPortFlag = &cli.IntFlag{
-		Name:     "metrics-port",
-		Usage:    "The port of the metrics",
-		EnvVars:  prefixEnvVars("METRICS_PORT"),
-		Value:    7214,
-		Required: true,
+		Name:    "metrics-port",
+		Usage:   "The port of the metrics for the sync command",
+		EnvVars: prefixEnvVars("METRICS_PORT"),
+		Value:   7214,
+	}
+	RpcMetricsPortFlag = &cli.IntFlag{
+		Name:    "rpc-metrics-port",
+		Usage:   "The port of the metrics for the rpc command",
+		EnvVars: prefixEnvVars("RPC_METRICS_PORT"),
+		Value:   7216,
+	}
+	NotifyMetricsPortFlag = &cli.IntFlag{
+		Name:    "notify-metrics-port",
+		Usage:   "The port of the metrics for the notify command",
+		EnvVars: prefixEnvVars("NOTIFY_METRICS_PORT"),
+		Value:   7217,
+	}
+	ChainNetworkFlag = &cli.StringFlag{
+		Name:    "chain-network",
+		Usage:   "The network passed to the upstream utxo service, defaults to the chain profile network",
+		EnvVars: prefixEnvVars("CHAIN_NETWORK"),
+	}
+	HealthGrpcPortFlag = &cli.IntFlag{
+		Name:    "health-grpc-port",
+		Usage:   "The port of the grpc health service for the sync command, the rpc command serves it on the rpc port",
+		EnvVars: prefixEnvVars("HEALTH_GRPC_PORT"),
+		Value:   7215,
+	}
+	NotifyHealthGrpcPortFlag = &cli.IntFlag{
+		Name:    "notify-health-grpc-port",
+		Usage:   "The port of the grpc health service for the notify command",
+		EnvVars: prefixEnvVars("NOTIFY_HEALTH_GRPC_PORT"),
+		Value:   7218,
+	}
+	HealthCheckIntervalFlag = &cli.DurationFlag{
+		Name:    "health-check-interval",
+		Usage:   "How often the readiness checks are run",
+		EnvVars: prefixEnvVars("HEALTH_CHECK_INTERVAL"),
+		Value:   10 * time.Second,
+	}
+	ScannerMaxStallFlag = &cli.DurationFlag{
+		Name:    "scanner-max-stall",
+		Usage:   "The scanner is reported not ready when no new block is stored for this long while the chain tip has advanced",
+		EnvVars: prefixEnvVars("SCANNER_MAX_STALL"),
+		Value:   10 * time.Minute,
+	}
+	ChainsFlag = &cli.StringSliceFlag{
+		Name:    "chain",
+		Usage:   "An additional chain synced by the same process, fields are space separated, e.g. \"name=ltc rpc=127.0.0.1:8390 starting-height=2700000 confirmations=12\"",
+		EnvVars: prefixEnvVars("CHAINS"),
 	}
 
 	SlaveDbEnableFlag = &cli.BoolFlag{
-		Name:     "slave-db-enable",
-		Usage:    "Whether to use slave db",
-		EnvVars:  prefixEnvVars("SLAVE_DB_ENABLE"),
-		Required: true,
+		Name:    "slave-db-enable",
+		Usage:   "Whether to use slave db",
+		EnvVars: prefixEnvVars("SLAVE_DB_ENABLE"),
 	}
 	ApiCacheEnableFlag = &cli.BoolFlag{
-		Name:     "api-cache-enable",
-		Usage:    "api cache enable",
-		EnvVars:  prefixEnvVars("API_CACHE_ENABLE"),
-		Required: true,
+		Name:    "api-cache-enable",
+		Usage:   "api cache enable",
+		EnvVars: prefixEnvVars("API_CACHE_ENABLE"),
 	}
 
 	// MasterDb Flags
 	MasterDbHostFlag = &cli.StringFlag{
-		Name:     "master-db-host",
-		Usage:    "The host of the master database",
-		EnvVars:  prefixEnvVars("MASTER_DB_HOST"),
-		Required: true,
+		Name:    "master-db-host",
+		Usage:   "The host of the master database",
+		EnvVars: prefixEnvVars("MASTER_DB_HOST"),
 	}
 	MasterDbPortFlag = &cli.IntFlag{
-		Name:     "master-db-port",
-		Usage:    "The port of the master database",
-		EnvVars:  prefixEnvVars("MASTER_DB_PORT"),
-		Required: true,
+		Name:    "master-db-port",
+		Usage:   "The port of the master database",
+		EnvVars: prefixEnvVars("MASTER_DB_PORT"),
 	}
 	MasterDbUserFlag = &cli.StringFlag{
-		Name:     "master-db-user",
-		Usage:    "The user of the master database",
-		EnvVars:  prefixEnvVars("MASTER_DB_USER"),
-		Required: true,
+		Name:    "master-db-user",
+		Usage:   "The user of the master database",
+		EnvVars: prefixEnvVars("MASTER_DB_USER"),
 	}
 	MasterDbPasswordFlag = &cli.StringFlag{
-		Name:     "master-db-password",
-		Usage:    "The host of the master database",
-		EnvVars:  prefixEnvVars("MASTER_DB_PASSWORD"),
-		Required: true,
+		Name:    "master-db-password",
+		Usage:   "The host of the master database",
+		EnvVars: prefixEnvVars("MASTER_DB_PASSWORD"),
 	}
 	MasterDbNameFlag = &cli.StringFlag{
-		Name:     "master-db-name",
-		Usage:    "The db name of the master database",
-		EnvVars:  prefixEnvVars("MASTER_DB_NAME"),
-		Required: true,
+		Name:    "master-db-name",
+		Usage:   "The db name of the master database",
+		EnvVars: prefixEnvVars("MASTER_DB_NAME"),
 	}
 
 	// Slave DB  flags
@@ -180,6 +301,56 @@ var (
 		EnvVars: prefixEnvVars("SLAVE_DB_NAME"),
 	}
 
+	// 连接池和从库延迟
+	MasterDbMaxOpenConnsFlag = &cli.IntFlag{
+		Name:    "master-db-max-open-conns",
+		Usage:   "The max open connections of the master database, 0 means unlimited",
+		EnvVars: prefixEnvVars("MASTER_DB_MAX_OPEN_CONNS"),
+		Value:   50,
+	}
+	MasterDbMaxIdleConnsFlag = &cli.IntFlag{
+		Name:    "master-db-max-idle-conns",
+		Usage:   "The max idle connections of the master database",
+		EnvVars: prefixEnvVars("MASTER_DB_MAX_IDLE_CONNS"),
+		Value:   10,
+	}
+	MasterDbConnMaxLifetimeFlag = &cli.DurationFlag{
+		Name:    "master-db-conn-max-lifetime",
+		Usage:   "The max lifetime of a master database connection, 0 means reuse forever",
+		EnvVars: prefixEnvVars("MASTER_DB_CONN_MAX_LIFETIME"),
+		Value:   time.Hour,
+	}
+	SlaveDbMaxOpenConnsFlag = &cli.IntFlag{
+		Name:    "slave-db-max-open-conns",
+		Usage:   "The max open connections of the slave database, 0 means unlimited",
+		EnvVars: prefixEnvVars("SLAVE_DB_MAX_OPEN_CONNS"),
+		Value:   50,
+	}
+	SlaveDbMaxIdleConnsFlag = &cli.IntFlag{
+		Name:    "slave-db-max-idle-conns",
+		Usage:   "The max idle connections of the slave database",
+		EnvVars: prefixEnvVars("SLAVE_DB_MAX_IDLE_CONNS"),
+		Value:   10,
+	}
+	SlaveDbConnMaxLifetimeFlag = &cli.DurationFlag{
+		Name:    "slave-db-conn-max-lifetime",
+		Usage:   "The max lifetime of a slave database connection, 0 means reuse forever",
+		EnvVars: prefixEnvVars("SLAVE_DB_CONN_MAX_LIFETIME"),
+		Value:   time.Hour,
+	}
+	SlaveDbMaxLagFlag = &cli.DurationFlag{
+		Name:    "slave-db-max-lag",
+		Usage:   "Read queries fall back to the master database when the slave replay lag exceeds this threshold",
+		EnvVars: prefixEnvVars("SLAVE_DB_MAX_LAG"),
+		Value:   30 * time.Second,
+	}
+	SlaveDbLagCheckIntervalFlag = &cli.DurationFlag{
+		Name:    "slave-db-lag-check-interval",
+		Usage:   "How often the slave replay lag is checked",
+		EnvVars: prefixEnvVars("SLAVE_DB_LAG_CHECK_INTERVAL"),
+		Value:   5 * time.Second,
+	}
+
 	// cache flags
 	ApiCacheListSizeFlag = &cli.UintFlag{
 		Name:    "api-cache-list-size",
@@ -205,10 +376,10 @@ var (
 	}
 )
 
+// requireFlags 可以由命令行、环境变量或配置文件任一处提供，是否缺失在 config.LoadConfig 里统一校验
 var requireFlags = []cli.Flag{
 	MigrationsFlag,
 	RpcUrlFlag,
-	ChainIdFlag,
 	ChainNameFlag,
 	StartingHeightFlag,
 	ConfirmationsFlag,
@@ -219,8 +390,11 @@ var requireFlags = []cli.Flag{
 	RpcPortFlag,
 	ChainBtcRpcFlag,
 	MetricsPortFlag,
+	RpcMetricsPortFlag,
+	NotifyMetricsPortFlag,
 	MetricsHostFlag,
 	SlaveDbEnableFlag,
+	ApiCacheEnableFlag,
 	MasterDbHostFlag,
 	MasterDbPortFlag,
 	MasterDbUserFlag,
@@ -229,15 +403,45 @@ var requireFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	ConfigFileFlag,
+	ChainNetworkFlag,
 	SlaveDbHostFlag,
 	SlaveDbPortFlag,
 	SlaveDbUserFlag,
 	SlaveDbPasswordFlag,
 	SlaveDbNameFlag,
+	MasterDbMaxOpenConnsFlag,
+	MasterDbMaxIdleConnsFlag,
+	MasterDbConnMaxLifetimeFlag,
+	SlaveDbMaxOpenConnsFlag,
+	SlaveDbMaxIdleConnsFlag,
+	SlaveDbConnMaxLifetimeFlag,
+	SlaveDbMaxLagFlag,
+	SlaveDbLagCheckIntervalFlag,
 	ApiCacheListSizeFlag,
 	ApiCacheDetailSizeFlag,
 	ApiCacheListExpireTimeFlag,
 	ApiCacheDetailExpireTimeFlag,
+	HeaderFetchConcurrencyFlag,
+	BlockFetchConcurrencyFlag,
+	FeeBumpBlocksFlag,
+	FeeBumpPercentFlag,
+	ReservationTtlFlag,
+	MempoolEnableFlag,
+	MempoolIntervalFlag,
+	SafeConfirmationsFlag,
+	FinalizedConfirmationsFlag,
+	RpcAdminTokenFlag,
+	RpcRateLimitFlag,
+	RpcRateBurstFlag,
+	RpcPeerRateLimitFlag,
+	RpcPeerRateBurstFlag,
+	RpcShutdownTimeoutFlag,
+	HealthGrpcPortFlag,
+	NotifyHealthGrpcPortFlag,
+	HealthCheckIntervalFlag,
+	ScannerMaxStallFlag,
+	ChainsFlag,
 }
 
 func init() {
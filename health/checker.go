@@ -0,0 +1,184 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/clock"
+)
+
+const (
+	defaultInterval = 10 * time.Second
+	checkTimeout    = 3 * time.Second
+)
+
+// CheckFunc 单项就绪检查，返回错误表示未就绪
+type CheckFunc func(ctx context.Context) error
+
+type Check struct {
+	Name string
+	Fn   CheckFunc
+}
+
+type CheckResult struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Ready     bool          `json:"ready"`
+	CheckedAt int64         `json:"checked_at"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+// Checker 按 interval 周期执行就绪检查并缓存结果，/readyz 和 grpc health 服务都读取缓存的结果，
+// 探针请求不会直接打到数据库和上游服务
+type Checker struct {
+	checks   []Check
+	interval time.Duration
+	alive    func() bool
+
+	grpcHealth *health.Server
+	worker     *clock.LoopFn
+
+	mu      sync.RWMutex
+	report  Report
+	stopped atomic.Bool
+}
+
+// NewChecker alive 为 nil 时只要进程在运行就认为存活
+func NewChecker(interval time.Duration, alive func() bool, checks ...Check) *Checker {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	grpcHealth := health.NewServer()
+	grpcHealth.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return &Checker{
+		checks:     checks,
+		interval:   interval,
+		alive:      alive,
+		grpcHealth: grpcHealth,
+		report:     Report{Checks: []CheckResult{}},
+	}
+}
+
+// Start 先同步执行一轮检查，之后周期刷新
+func (c *Checker) Start(ctx context.Context) error {
+	c.run(ctx)
+	c.worker = clock.NewLoopFn(clock.SystemClock, c.run, nil, c.interval)
+	return nil
+}
+
+func (c *Checker) Stop(ctx context.Context) error {
+	c.grpcHealth.Shutdown()
+	var err error
+	if c.worker != nil {
+		err = c.worker.Close()
+	}
+	c.stopped.Store(true)
+	return err
+}
+
+func (c *Checker) Stopped() bool {
+	return c.stopped.Load()
+}
+
+// GrpcServer 标准 grpc.health.v1.Health 服务，服务名为空串时代表整个进程
+func (c *Checker) GrpcServer() healthpb.HealthServer {
+	return c.grpcHealth
+}
+
+func (c *Checker) Report() Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.report
+}
+
+func (c *Checker) run(ctx context.Context) {
+	report := Report{Ready: true, CheckedAt: time.Now().Unix(), Checks: make([]CheckResult, 0, len(c.checks))}
+	for _, check := range c.checks {
+		result := CheckResult{Name: check.Name, Ready: true}
+		if err := runCheck(ctx, check.Fn); err != nil {
+			result.Ready = false
+			result.Error = err.Error()
+			report.Ready = false
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	c.mu.Lock()
+	previous := c.report
+	c.report = report
+	c.mu.Unlock()
+
+	if report.Ready != previous.Ready || previous.CheckedAt == 0 {
+		if report.Ready {
+			log.Info("service is ready")
+		} else {
+			log.Warn("service is not ready", "checks", report.Checks)
+		}
+	}
+	status := healthpb.HealthCheckResponse_SERVING
+	if !report.Ready {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	c.grpcHealth.SetServingStatus("", status)
+}
+
+// runCheck 超过 checkTimeout 直接判为未就绪；检查函数要把 ctx 传给数据库和上游调用，超时后查询随 ctx 取消，
+// 不会在后台一直占着连接
+func runCheck(ctx context.Context, fn CheckFunc) error {
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(checkCtx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-checkCtx.Done():
+		return errors.New("check timeout")
+	}
+}
+
+// LivenessHandler /healthz，生命周期停止之后返回 503
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c.alive != nil && !c.alive() {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "stopped"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+}
+
+// ReadinessHandler /readyz，返回最近一轮检查的结果，任一检查未通过返回 503
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Report()
+		code := http.StatusOK
+		if !report.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("write health response fail", "err", err)
+	}
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// DatabaseCheck 数据库连接检查
+func DatabaseCheck(db Pinger) Check {
+	return Check{Name: "database", Fn: db.Ping}
+}
+
+// UpstreamCheck 上游 WalletUtxoService 可达性检查，能查到链上最新高度即认为可达
+func UpstreamCheck(chainTip HeightFunc) Check {
+	return Check{Name: "upstream", Fn: func(ctx context.Context) error {
+		_, err := chainTip(ctx)
+		return err
+	}}
+}
+
+// ScannerCheck 扫块新鲜度检查，见 ScannerFreshness
+func ScannerCheck(maxStall time.Duration, syncedHeight HeightFunc, chainTip HeightFunc) Check {
+	return Check{Name: "scanner", Fn: NewScannerFreshness(maxStall, syncedHeight, chainTip).Check}
+}
+
+// ForChain 给一条链的检查名加上链名前缀，例如 ltc/database
+func ForChain(chain string, checks ...Check) []Check {
+	prefixed := make([]Check, 0, len(checks))
+	for _, check := range checks {
+		check.Name = chain + "/" + check.Name
+		prefixed = append(prefixed, check)
+	}
+	return prefixed
+}
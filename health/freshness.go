@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// HeightFunc 返回一个区块高度，没有数据时返回 nil
+type HeightFunc func(ctx context.Context) (*big.Int, error)
+
+// ScannerFreshness 扫块新鲜度检查：链上最新高度已经超过库里的最新高度，并且库里的高度超过 maxStall 没有推进时判定为卡住；
+// 链上本身没有出新块时不论多久都算正常
+type ScannerFreshness struct {
+	maxStall     time.Duration
+	syncedHeight HeightFunc
+	chainTip     HeightFunc
+	now          func() time.Time
+
+	mu         sync.Mutex
+	lastHeight *big.Int
+	advancedAt time.Time
+}
+
+func NewScannerFreshness(maxStall time.Duration, syncedHeight HeightFunc, chainTip HeightFunc) *ScannerFreshness {
+	return &ScannerFreshness{
+		maxStall:     maxStall,
+		syncedHeight: syncedHeight,
+		chainTip:     chainTip,
+		now:          time.Now,
+	}
+}
+
+func (f *ScannerFreshness) Check(ctx context.Context) error {
+	synced, err := f.syncedHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("query synced height: %w", err)
+	}
+	if synced == nil {
+		synced = big.NewInt(0)
+	}
+
+	f.mu.Lock()
+	now := f.now()
+	if f.lastHeight == nil || synced.Cmp(f.lastHeight) != 0 {
+		f.lastHeight = synced
+		f.advancedAt = now
+	}
+	stalled := now.Sub(f.advancedAt)
+	f.mu.Unlock()
+
+	if f.maxStall <= 0 || stalled <= f.maxStall {
+		return nil
+	}
+	tip, err := f.chainTip(ctx)
+	if err != nil {
+		return fmt.Errorf("query chain tip: %w", err)
+	}
+	if tip != nil && tip.Cmp(synced) > 0 {
+		return fmt.Errorf("scanner stalled at height %s for %s, chain tip is %s", synced, stalled.Truncate(time.Second), tip)
+	}
+	return nil
+}
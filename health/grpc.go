@@ -0,0 +1,69 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// GrpcServer 没有业务 grpc 服务的命令（sync、notify）单独监听一个端口提供 grpc health 服务
+type GrpcServer struct {
+	host     string
+	port     int
+	checker  *Checker
+	server   *grpc.Server
+	done     chan struct{}
+	shutdown context.CancelCauseFunc
+	stopped  atomic.Bool
+}
+
+func NewGrpcServer(host string, port int, checker *Checker, shutdown context.CancelCauseFunc) *GrpcServer {
+	return &GrpcServer{
+		host:     host,
+		port:     port,
+		checker:  checker,
+		shutdown: shutdown,
+	}
+}
+
+func (s *GrpcServer) Start(ctx context.Context) error {
+	addr := net.JoinHostPort(s.host, fmt.Sprint(s.port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Error("Could not start health listener", "addr", addr, "err", err)
+		return err
+	}
+	s.server = grpc.NewServer()
+	healthpb.RegisterHealthServer(s.server, s.checker.GrpcServer())
+	s.done = make(chan struct{})
+	log.Info("start grpc health server", "addr", listener.Addr())
+	go func() {
+		defer close(s.done)
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Error("grpc health server fail", "err", err)
+			if s.shutdown != nil {
+				s.shutdown(fmt.Errorf("grpc health server: %w", err))
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *GrpcServer) Stop(ctx context.Context) error {
+	if s.server != nil {
+		s.server.Stop()
+		<-s.done
+	}
+	s.stopped.Store(true)
+	return nil
+}
+
+func (s *GrpcServer) Stopped() bool {
+	return s.stopped.Load()
+}
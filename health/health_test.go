@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestScannerFreshness(t *testing.T) {
+	synced, tip := big.NewInt(100), big.NewInt(100)
+	now := time.Unix(1_700_000_000, 0)
+	f := NewScannerFreshness(10*time.Minute,
+		func(ctx context.Context) (*big.Int, error) { return synced, nil },
+		func(ctx context.Context) (*big.Int, error) { return tip, nil },
+	)
+	f.now = func() time.Time { return now }
+	require.NoError(t, f.Check(context.Background()))
+
+	// 链上没有出新块，扫块停在原地不算卡住
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, f.Check(context.Background()))
+
+	// 链上出了新块，库里高度超过阈值没有推进
+	tip = big.NewInt(101)
+	require.Error(t, f.Check(context.Background()))
+
+	// 扫块推进之后恢复
+	synced = big.NewInt(101)
+	require.NoError(t, f.Check(context.Background()))
+	now = now.Add(5 * time.Minute)
+	tip = big.NewInt(102)
+	require.NoError(t, f.Check(context.Background()))
+}
+
+func TestCheckerReadiness(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	checker := NewChecker(time.Hour, nil,
+		Check{Name: "database", Fn: func(ctx context.Context) error { return dbErr }},
+		Check{Name: "upstream", Fn: func(ctx context.Context) error { return nil }},
+	)
+	checker.run(context.Background())
+
+	report := checker.Report()
+	require.False(t, report.Ready)
+	require.Equal(t, []CheckResult{
+		{Name: "database", Ready: false, Error: "connection refused"},
+		{Name: "upstream", Ready: true},
+	}, report.Checks)
+	resp, err := checker.GrpcServer().Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	rec := httptest.NewRecorder()
+	checker.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	dbErr = nil
+	checker.run(context.Background())
+	require.True(t, checker.Report().Ready)
+	resp, err = checker.GrpcServer().Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	rec = httptest.NewRecorder()
+	checker.LivenessHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
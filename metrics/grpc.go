@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// UpstreamUnaryInterceptor 记录调用某条链的 WalletUtxoService 每个方法的耗时和失败次数
+func UpstreamUnaryInterceptor(chain string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		RecordUpstreamCall(chain, MethodName(method), time.Since(start), err)
+		return err
+	}
+}
+
+// MethodName 从 /package.Service/method 中取出方法名
+func MethodName(fullMethod string) string {
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[i+1:]
+	}
+	return fullMethod
+}
@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+)
+
+const namespace = "multichain_sync"
+
+// registry 本进程的指标注册表，只包含本服务的指标
+var registry = NewRegistry()
+
+// Handler 以 prometheus 文本格式输出本进程的指标
+func Handler() http.Handler {
+	return registry.Handler()
+}
+
+var (
+	syncChainTip     = registry.Gauge("sync_chain_tip", "Latest block height reported by the chain node.", "chain")
+	syncSyncedHeight = registry.Gauge("sync_synced_height", "Highest block height stored by the scanner.", "chain")
+	syncLagBlocks    = registry.Gauge("sync_lag_blocks", "Blocks between the chain tip and the synced height.", "chain")
+
+	syncBatchBlocks       = registry.Histogram("sync_batch_blocks", "Blocks processed per sync batch.", sizeBuckets, "chain")
+	syncBatchTransactions = registry.Histogram("sync_batch_transactions", "Transactions processed per sync batch.", sizeBuckets, "chain")
+	syncBlocksTotal       = registry.Counter("sync_blocks_total", "Blocks processed by the scanner.", "chain")
+	syncTransactionsTotal = registry.Counter("sync_transactions_total", "Transactions processed by the scanner.", "chain")
+	syncClassifiedTotal   = registry.Counter("sync_classified_total", "Business transactions classified by type.", "chain", "tx_type")
+
+	upstreamDuration    = registry.Histogram("upstream_request_duration_seconds", "Latency of WalletUtxoService calls.", latencyBuckets, "chain", "method")
+	upstreamErrorsTotal = registry.Counter("upstream_errors_total", "Failed WalletUtxoService calls.", "chain", "method")
+
+	rpcRequestsTotal = registry.Counter("rpc_requests_total", "Business rpc requests by method and status code.", "chain", "method", "code")
+	rpcDuration      = registry.Histogram("rpc_request_duration_seconds", "Latency of business rpc requests.", latencyBuckets, "chain", "method")
+
+	notifyTotal = registry.Counter("notify_total", "Business notifications by result.", "chain", "business_id", "result")
+
+	withdrawQueueDepth = registry.Gauge("withdraw_queue_depth", "Withdrawals waiting to be broadcast across all businesses.", "chain")
+
+	dbTxRetriesTotal = registry.Counter("db_tx_retries_total", "Database transactions retried after a failure.", "chain", "component")
+
+	balanceUnderflowsTotal = registry.Counter("balance_underflows_total", "Address balances left unchanged on rollback because they were lower than the rolled back credit.", "chain", "business_id")
+)
+
+// RecordChainTip 记录轮询到的链上最新高度，没有新区块时也会更新，同步停滞时差值随之增长；chain 是链的配置名，下同
+func RecordChainTip(chain string, chainTip uint64) {
+	syncChainTip.Set(float64(chainTip), chain)
+	updateLag(chain)
+}
+
+// RecordSyncHeight 记录已入库的最新高度
+func RecordSyncHeight(chain string, syncedHeight uint64) {
+	syncSyncedHeight.Set(float64(syncedHeight), chain)
+	updateLag(chain)
+}
+
+// updateLag 按最近一次记录的最新高度和已同步高度计算差值
+func updateLag(chain string) {
+	lag := syncChainTip.Value(chain) - syncSyncedHeight.Value(chain)
+	if lag < 0 {
+		lag = 0
+	}
+	syncLagBlocks.Set(lag, chain)
+}
+
+// RecordSyncBatch 记录一个批次处理的区块数和交易数
+func RecordSyncBatch(chain string, blocks int, transactions int) {
+	syncBatchBlocks.Observe(float64(blocks), chain)
+	syncBatchTransactions.Observe(float64(transactions), chain)
+	syncBlocksTotal.Add(float64(blocks), chain)
+	syncTransactionsTotal.Add(float64(transactions), chain)
+}
+
+// RecordClassified 按交易类型累计分类结果
+func RecordClassified(chain string, txType string, count int) {
+	syncClassifiedTotal.Add(float64(count), chain, txType)
+}
+
+// RecordUpstreamCall 记录调用 WalletUtxoService 的耗时和失败次数
+func RecordUpstreamCall(chain string, method string, elapsed time.Duration, err error) {
+	upstreamDuration.Observe(elapsed.Seconds(), chain, method)
+	if err != nil {
+		upstreamErrorsTotal.Inc(chain, method)
+	}
+}
+
+// RecordRpcRequest 记录业务方 rpc 接口的请求数和耗时，code 是 grpc 返回码
+func RecordRpcRequest(chain string, method string, code string, elapsed time.Duration) {
+	rpcRequestsTotal.Inc(chain, method, code)
+	rpcDuration.Observe(elapsed.Seconds(), chain, method)
+}
+
+// RecordNotify 按业务方记录通知成功和失败次数
+func RecordNotify(chain string, businessId string, success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	notifyTotal.Inc(chain, businessId, result)
+}
+
+// RecordWithdrawQueue 记录一轮扫描中所有业务方待广播的提现数量
+func RecordWithdrawQueue(chain string, depth int) {
+	withdrawQueueDepth.Set(float64(depth), chain)
+}
+
+// RecordDbRetry 记录数据库事务失败后触发的重试，component 是发起事务的模块
+func RecordDbRetry(chain string, component string) {
+	dbTxRetriesTotal.Inc(chain, component)
+}
+
+// RecordBalanceUnderflow 回滚入账时余额不够扣的地址数，大于 0 时需要人工核对这个业务方的余额
+func RecordBalanceUnderflow(chain string, businessId string, count int) {
+	balanceUnderflowsTotal.Add(float64(count), chain, businessId)
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodName(t *testing.T) {
+	require.Equal(t, "getBlockByNumber", MethodName("/syncclient.utxo.WalletUtxoService/getBlockByNumber"))
+	require.Equal(t, "getBlockByNumber", MethodName("getBlockByNumber"))
+}
+
+func TestRegistryOutput(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("requests_total", "Requests.", "chain", "method").Inc("btc", `say "hi"`)
+	r.Gauge("height", "Height.").Set(42)
+	r.Histogram("latency_seconds", "Latency.", []float64{0.1, 1}, "chain").Observe(0.5, "btc")
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	require.Equal(t, `# HELP multichain_sync_height Height.
+# TYPE multichain_sync_height gauge
+multichain_sync_height 42
+# HELP multichain_sync_latency_seconds Latency.
+# TYPE multichain_sync_latency_seconds histogram
+multichain_sync_latency_seconds_bucket{chain="btc",le="0.1"} 0
+multichain_sync_latency_seconds_bucket{chain="btc",le="1"} 1
+multichain_sync_latency_seconds_bucket{chain="btc",le="+Inf"} 1
+multichain_sync_latency_seconds_sum{chain="btc"} 0.5
+multichain_sync_latency_seconds_count{chain="btc"} 1
+# HELP multichain_sync_requests_total Requests.
+# TYPE multichain_sync_requests_total counter
+multichain_sync_requests_total{chain="btc",method="say \"hi\""} 1
+`, string(body))
+}
+
+func TestPrometheusOutput(t *testing.T) {
+	RecordSyncHeight("btc", 100)
+	RecordChainTip("btc", 120)
+	RecordSyncHeight("ltc", 300)
+	RecordChainTip("ltc", 300)
+	RecordNotify("btc", "biz-01", false)
+	RecordUpstreamCall("btc", "sendTx", time.Millisecond, errors.New("unavailable"))
+	RecordRpcRequest("btc", "getBalance", "OK", 20*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "multichain_sync_sync_lag_blocks{chain=\"btc\"} 20\n")
+	require.Contains(t, string(body), "multichain_sync_sync_lag_blocks{chain=\"ltc\"} 0\n")
+	require.Contains(t, string(body), "multichain_sync_notify_total{chain=\"btc\",business_id=\"biz-01\",result=\"failure\"} 1\n")
+	require.Contains(t, string(body), "multichain_sync_upstream_errors_total{chain=\"btc\",method=\"sendTx\"} 1\n")
+	require.Contains(t, string(body), "multichain_sync_upstream_request_duration_seconds_count{chain=\"btc\",method=\"sendTx\"} 1\n")
+	require.Contains(t, string(body), "multichain_sync_rpc_requests_total{chain=\"btc\",method=\"getBalance\",code=\"OK\"} 1\n")
+	require.Contains(t, string(body), "multichain_sync_rpc_request_duration_seconds_bucket{chain=\"btc\",method=\"getBalance\",le=\"0.025\"} 1\n")
+}
+
+func TestLagFollowsChainTip(t *testing.T) {
+	RecordSyncHeight("doge", 500)
+	RecordChainTip("doge", 510)
+	require.Equal(t, float64(10), syncLagBlocks.Value("doge"))
+	// 同步停滞时只有最新高度在涨，落后的区块数照样增长
+	RecordChainTip("doge", 530)
+	require.Equal(t, float64(30), syncLagBlocks.Value("doge"))
+	RecordSyncHeight("doge", 530)
+	require.Equal(t, float64(0), syncLagBlocks.Value("doge"))
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	readHeaderTimeout      = 10 * time.Second
+	defaultShutdownTimeout = 5 * time.Second
+)
+
+// Server 以 prometheus 文本格式在 /metrics 暴露本进程的指标
+type Server struct {
+	host     string
+	port     int
+	mux      *http.ServeMux
+	server   *http.Server
+	done     chan struct{}
+	shutdown context.CancelCauseFunc
+	stopped  atomic.Bool
+}
+
+func NewServer(host string, port int, shutdown context.CancelCauseFunc) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &Server{
+		host:     host,
+		port:     port,
+		mux:      mux,
+		shutdown: shutdown,
+	}
+}
+
+// Handle 在同一个端口上挂载其他 http 接口，需要在 Start 之前调用
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start 监听失败直接返回错误；服务运行中出错时通过 shutdown 通知生命周期退出
+func (s *Server) Start(ctx context.Context) error {
+	addr := net.JoinHostPort(s.host, fmt.Sprint(s.port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Error("Could not start metrics listener", "addr", addr, "err", err)
+		return err
+	}
+	s.server = &http.Server{Handler: s.mux, ReadHeaderTimeout: readHeaderTimeout}
+	s.done = make(chan struct{})
+	log.Info("start metrics server", "addr", listener.Addr())
+	go func() {
+		defer close(s.done)
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("metrics server fail", "err", err)
+			if s.shutdown != nil {
+				s.shutdown(fmt.Errorf("metrics server: %w", err))
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		s.stopped.Store(true)
+		return nil
+	}
+	stopCtx, cancel := context.WithTimeout(ctx, defaultShutdownTimeout)
+	defer cancel()
+	err := s.server.Shutdown(stopCtx)
+	if err != nil {
+		_ = s.server.Close()
+	}
+	<-s.done
+	s.stopped.Store(true)
+	return err
+}
+
+func (s *Server) Stopped() bool {
+	return s.stopped.Load()
+}
@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS reorg_blocks;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS business;
+DROP DOMAIN IF EXISTS UINT256;
+
+-- +migrate tenant
+DROP TABLE IF EXISTS child_txs{{tenant}};
+DROP TABLE IF EXISTS transactions{{tenant}};
+DROP TABLE IF EXISTS internals{{tenant}};
+DROP TABLE IF EXISTS withdraws{{tenant}};
+DROP TABLE IF EXISTS deposits{{tenant}};
+DROP TABLE IF EXISTS vouts{{tenant}};
+DROP TABLE IF EXISTS vins{{tenant}};
+DROP TABLE IF EXISTS balances{{tenant}};
+DROP TABLE IF EXISTS addresses{{tenant}};
@@ -0,0 +1,20 @@
+-- reorg_blocks 中可能已经有同一高度的多条重组记录，唯一约束不再恢复
+
+-- +migrate tenant
+DROP INDEX IF EXISTS transactions{{tenant}}_block_number;
+DROP INDEX IF EXISTS deposits{{tenant}}_block_number;
+
+DROP INDEX IF EXISTS vouts{{tenant}}_tx_id;
+ALTER TABLE vouts{{tenant}} DROP COLUMN IF EXISTS tx_id;
+
+DROP INDEX IF EXISTS vins{{tenant}}_spend_tx_hash;
+DROP INDEX IF EXISTS vins{{tenant}}_tx_id;
+
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'vins{{tenant}}' AND column_name = 'tx_id') THEN
+            ALTER TABLE vins{{tenant}} RENAME COLUMN tx_id TO txid;
+        END IF;
+    END
+$$;
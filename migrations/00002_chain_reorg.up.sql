@@ -0,0 +1,32 @@
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'reorg_blocks' AND column_name = 'parent_hash') THEN
+            ALTER TABLE reorg_blocks RENAME COLUMN parent_hash TO prev_hash;
+        END IF;
+    END
+$$;
+
+-- 同一高度可能被多次重组, reorg_blocks 不能再对高度和父哈希做唯一约束
+ALTER TABLE reorg_blocks DROP CONSTRAINT IF EXISTS reorg_blocks_parent_hash_key;
+ALTER TABLE reorg_blocks DROP CONSTRAINT IF EXISTS reorg_blocks_prev_hash_key;
+ALTER TABLE reorg_blocks DROP CONSTRAINT IF EXISTS reorg_blocks_number_key;
+
+-- +migrate tenant
+DO
+$$
+    BEGIN
+        IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'vins{{tenant}}' AND column_name = 'txid') THEN
+            ALTER TABLE vins{{tenant}} RENAME COLUMN txid TO tx_id;
+        END IF;
+    END
+$$;
+
+CREATE INDEX IF NOT EXISTS vins{{tenant}}_tx_id ON vins{{tenant}} (tx_id);
+CREATE INDEX IF NOT EXISTS vins{{tenant}}_spend_tx_hash ON vins{{tenant}} (spend_tx_hash);
+
+ALTER TABLE vouts{{tenant}} ADD COLUMN IF NOT EXISTS tx_id VARCHAR NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS vouts{{tenant}}_tx_id ON vouts{{tenant}} (tx_id);
+
+CREATE INDEX IF NOT EXISTS deposits{{tenant}}_block_number ON deposits{{tenant}} (block_number);
+CREATE INDEX IF NOT EXISTS transactions{{tenant}}_block_number ON transactions{{tenant}} (block_number);
@@ -0,0 +1,14 @@
+-- +migrate tenant
+-- 状态已经是字符串，无法还原成 SMALLINT，只回退索引和默认值
+DROP INDEX IF EXISTS internals{{tenant}}_block_hash;
+DROP INDEX IF EXISTS withdraws{{tenant}}_block_hash;
+DROP INDEX IF EXISTS deposits{{tenant}}_block_hash;
+
+DROP INDEX IF EXISTS internals{{tenant}}_status;
+DROP INDEX IF EXISTS withdraws{{tenant}}_status;
+DROP INDEX IF EXISTS deposits{{tenant}}_status;
+
+ALTER TABLE transactions{{tenant}} ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE internals{{tenant}} ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE withdraws{{tenant}} ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE deposits{{tenant}} ALTER COLUMN status DROP DEFAULT;
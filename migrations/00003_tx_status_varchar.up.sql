@@ -0,0 +1,34 @@
+-- +migrate tenant
+-- 代码里交易状态是字符串 (unsafe / sent / fallback ...), 把 status 字段从 SMALLINT 改成 VARCHAR
+DO
+$$
+    DECLARE
+        tbl TEXT;
+    BEGIN
+        FOREACH tbl IN ARRAY ARRAY ['deposits{{tenant}}', 'withdraws{{tenant}}', 'internals{{tenant}}', 'transactions{{tenant}}']
+            LOOP
+                IF EXISTS (SELECT 1
+                           FROM information_schema.columns
+                           WHERE table_name = tbl
+                             AND column_name = 'status'
+                             AND data_type = 'smallint') THEN
+                    EXECUTE format('ALTER TABLE %I ALTER COLUMN status DROP DEFAULT', tbl);
+                    EXECUTE format('ALTER TABLE %I ALTER COLUMN status TYPE VARCHAR USING status::VARCHAR', tbl);
+                END IF;
+            END LOOP;
+    END
+$$;
+
+ALTER TABLE deposits{{tenant}} ALTER COLUMN status SET DEFAULT 'unsafe';
+ALTER TABLE withdraws{{tenant}} ALTER COLUMN status SET DEFAULT 'unsend';
+ALTER TABLE internals{{tenant}} ALTER COLUMN status SET DEFAULT 'unsend';
+ALTER TABLE transactions{{tenant}} ALTER COLUMN status SET DEFAULT 'unsafe';
+
+CREATE INDEX IF NOT EXISTS deposits{{tenant}}_status ON deposits{{tenant}} (status);
+CREATE INDEX IF NOT EXISTS withdraws{{tenant}}_status ON withdraws{{tenant}} (status);
+CREATE INDEX IF NOT EXISTS internals{{tenant}}_status ON internals{{tenant}} (status);
+
+-- 回滚流程按 block_hash 查找被重组掉的交易
+CREATE INDEX IF NOT EXISTS deposits{{tenant}}_block_hash ON deposits{{tenant}} (block_hash);
+CREATE INDEX IF NOT EXISTS withdraws{{tenant}}_block_hash ON withdraws{{tenant}} (block_hash);
+CREATE INDEX IF NOT EXISTS internals{{tenant}}_block_hash ON internals{{tenant}} (block_hash);
@@ -0,0 +1,2 @@
+-- +migrate tenant
+DROP TABLE IF EXISTS utxos{{tenant}};
@@ -0,0 +1,26 @@
+-- utxo 集合，按 (tx_id, vout_index) 唯一标识一个输出，记录创建和花费高度
+CREATE TABLE IF NOT EXISTS utxos
+(
+    guid           VARCHAR PRIMARY KEY,
+    tx_id          VARCHAR  NOT NULL,
+    vout_index     INTEGER  NOT NULL CHECK (vout_index >= 0),
+    address        VARCHAR  NOT NULL,
+    address_type   SMALLINT NOT NULL DEFAULT 0,
+    amount         UINT256  NOT NULL CHECK (amount >= 0),
+    script         VARCHAR  NOT NULL DEFAULT '',
+    block_hash     VARCHAR  NOT NULL,
+    created_height UINT256  NOT NULL,
+    spend_tx_hash  VARCHAR  NOT NULL DEFAULT '',
+    spent_height   UINT256  NOT NULL DEFAULT 0,
+    is_spent       BOOL     NOT NULL DEFAULT FALSE,
+    timestamp      INTEGER  NOT NULL CHECK (timestamp > 0),
+    UNIQUE (tx_id, vout_index)
+);
+CREATE INDEX IF NOT EXISTS utxos_address ON utxos (address, is_spent);
+CREATE INDEX IF NOT EXISTS utxos_spend_tx_hash ON utxos (spend_tx_hash);
+CREATE INDEX IF NOT EXISTS utxos_created_height ON utxos (created_height);
+CREATE INDEX IF NOT EXISTS utxos_timestamp ON utxos (timestamp);
+
+-- +migrate tenant
+-- 已经注册的业务方按模板补建 utxo 表（模板表本身已存在，语句直接跳过）
+CREATE TABLE IF NOT EXISTS utxos{{tenant}} (LIKE utxos INCLUDING ALL);
@@ -0,0 +1,5 @@
+ALTER TABLE business DROP COLUMN IF EXISTS coin_selection;
+
+-- +migrate tenant
+DROP INDEX IF EXISTS utxos{{tenant}}_reserved_by;
+ALTER TABLE utxos{{tenant}} DROP COLUMN IF EXISTS reserved_by;
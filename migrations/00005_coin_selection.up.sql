@@ -0,0 +1,7 @@
+-- 业务方默认的选币策略: largest_first / branch_and_bound / knapsack
+ALTER TABLE business ADD COLUMN IF NOT EXISTS coin_selection VARCHAR NOT NULL DEFAULT 'largest_first';
+
+-- +migrate tenant
+-- 构建提现交易时锁定选中的 utxo，reserved_by 是占用它的交易 guid，防止并发构建重复花费
+ALTER TABLE utxos{{tenant}} ADD COLUMN IF NOT EXISTS reserved_by VARCHAR NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS utxos{{tenant}}_reserved_by ON utxos{{tenant}} (reserved_by);
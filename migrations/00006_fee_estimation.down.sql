@@ -0,0 +1,9 @@
+ALTER TABLE business DROP COLUMN IF EXISTS multisig_n;
+ALTER TABLE business DROP COLUMN IF EXISTS multisig_m;
+ALTER TABLE business DROP COLUMN IF EXISTS max_fee_rate;
+ALTER TABLE business DROP COLUMN IF EXISTS min_fee_rate;
+ALTER TABLE business DROP COLUMN IF EXISTS fee_priority;
+
+-- +migrate tenant
+ALTER TABLE withdraws{{tenant}} DROP COLUMN IF EXISTS fee_rate;
+ALTER TABLE withdraws{{tenant}} DROP COLUMN IF EXISTS vsize;
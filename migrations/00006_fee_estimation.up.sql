@@ -0,0 +1,11 @@
+-- 业务方手续费配置: 默认档位 economy / normal / fast，费率上下限 sat/vbyte（0 表示不限制），热钱包多签参数
+ALTER TABLE business ADD COLUMN IF NOT EXISTS fee_priority VARCHAR NOT NULL DEFAULT 'normal';
+ALTER TABLE business ADD COLUMN IF NOT EXISTS min_fee_rate BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE business ADD COLUMN IF NOT EXISTS max_fee_rate BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE business ADD COLUMN IF NOT EXISTS multisig_m SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE business ADD COLUMN IF NOT EXISTS multisig_n SMALLINT NOT NULL DEFAULT 0;
+
+-- +migrate tenant
+-- 提现交易的预估大小和费率
+ALTER TABLE withdraws{{tenant}} ADD COLUMN IF NOT EXISTS vsize BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE withdraws{{tenant}} ADD COLUMN IF NOT EXISTS fee_rate BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+-- +migrate tenant
+DROP TABLE IF EXISTS withdraw_replacements{{tenant}};
+
+-- 已有的未上链提现 block_number 为 0，约束只对新写入的数据生效
+ALTER TABLE withdraws{{tenant}} DROP CONSTRAINT IF EXISTS withdraws_block_number_check;
+ALTER TABLE withdraws{{tenant}} ADD CONSTRAINT withdraws_block_number_check CHECK (block_number > 0) NOT VALID;
+ALTER TABLE withdraws{{tenant}} DROP COLUMN IF EXISTS replace_count;
+ALTER TABLE withdraws{{tenant}} DROP COLUMN IF EXISTS sent_height;
@@ -0,0 +1,30 @@
+-- 提现交易的替换链，每次加速生成一笔花费相同输入、费率更高的替换交易
+-- replaced_hash 是被替换的交易哈希，hash 是替换交易广播之后的哈希，扫链时任何一个版本确认都能匹配到提现记录
+CREATE TABLE IF NOT EXISTS withdraw_replacements
+(
+    guid          VARCHAR PRIMARY KEY,
+    withdraw_guid VARCHAR NOT NULL,
+    replaced_hash VARCHAR NOT NULL,
+    hash          VARCHAR NOT NULL DEFAULT '',
+    fee           UINT256 NOT NULL,
+    fee_rate      BIGINT  NOT NULL,
+    vsize         BIGINT  NOT NULL,
+    un_sign_tx    VARCHAR NOT NULL,
+    tx_data       VARCHAR NOT NULL,
+    tx_sign_hex   VARCHAR NOT NULL DEFAULT '',
+    status        VARCHAR NOT NULL,
+    timestamp     INTEGER NOT NULL CHECK (timestamp > 0)
+);
+CREATE INDEX IF NOT EXISTS withdraw_replacements_withdraw_guid ON withdraw_replacements (withdraw_guid);
+CREATE INDEX IF NOT EXISTS withdraw_replacements_replaced_hash ON withdraw_replacements (replaced_hash);
+CREATE INDEX IF NOT EXISTS withdraw_replacements_hash ON withdraw_replacements (hash);
+CREATE INDEX IF NOT EXISTS withdraw_replacements_status ON withdraw_replacements (status);
+
+-- +migrate tenant
+-- 提现广播时的区块高度，超过 N 个区块未确认的交易会被 RBF 加速；replace_count 记录被替换的次数
+ALTER TABLE withdraws{{tenant}} ADD COLUMN IF NOT EXISTS sent_height UINT256 NOT NULL DEFAULT 0;
+ALTER TABLE withdraws{{tenant}} ADD COLUMN IF NOT EXISTS replace_count SMALLINT NOT NULL DEFAULT 0;
+-- 未上链的提现交易没有区块高度
+ALTER TABLE withdraws{{tenant}} DROP CONSTRAINT IF EXISTS withdraws_block_number_check;
+
+CREATE TABLE IF NOT EXISTS withdraw_replacements{{tenant}} (LIKE withdraw_replacements INCLUDING ALL);
@@ -0,0 +1,6 @@
+-- +migrate tenant
+DROP TABLE IF EXISTS cpfp_reservations{{tenant}};
+ALTER TABLE internals{{tenant}} DROP CONSTRAINT IF EXISTS internals_block_number_check;
+ALTER TABLE internals{{tenant}} ADD CONSTRAINT internals_block_number_check CHECK (block_number > 0) NOT VALID;
+DROP INDEX IF EXISTS internals{{tenant}}_parent_hash;
+ALTER TABLE internals{{tenant}} DROP COLUMN IF EXISTS parent_hash;
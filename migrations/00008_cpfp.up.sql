@@ -0,0 +1,20 @@
+-- CPFP 子交易占用的父交易输出，按 (parent_hash, parent_vout_index) 唯一，同一个输出同时只能被一笔子交易花费；
+-- 子交易作废时删除对应的行，输出可以再次加速
+CREATE TABLE IF NOT EXISTS cpfp_reservations
+(
+    parent_hash       VARCHAR NOT NULL,
+    parent_vout_index INTEGER NOT NULL CHECK (parent_vout_index >= 0),
+    internal_guid     VARCHAR NOT NULL,
+    timestamp         INTEGER NOT NULL CHECK (timestamp > 0),
+    PRIMARY KEY (parent_hash, parent_vout_index)
+);
+CREATE INDEX IF NOT EXISTS cpfp_reservations_internal_guid ON cpfp_reservations (internal_guid);
+
+-- +migrate tenant
+-- CPFP 子交易作为内部交易记录，parent_hash 是被加速的未确认父交易
+ALTER TABLE internals{{tenant}} ADD COLUMN IF NOT EXISTS parent_hash VARCHAR NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS internals{{tenant}}_parent_hash ON internals{{tenant}} (parent_hash);
+-- 未上链的内部交易没有区块高度
+ALTER TABLE internals{{tenant}} DROP CONSTRAINT IF EXISTS internals_block_number_check;
+
+CREATE TABLE IF NOT EXISTS cpfp_reservations{{tenant}} (LIKE cpfp_reservations INCLUDING ALL);
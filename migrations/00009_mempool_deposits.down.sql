@@ -0,0 +1,4 @@
+-- +migrate tenant
+-- 索引由 00002 创建，这里只恢复约束
+ALTER TABLE deposits{{tenant}} DROP CONSTRAINT IF EXISTS deposits_block_number_check;
+ALTER TABLE deposits{{tenant}} ADD CONSTRAINT deposits_block_number_check CHECK (block_number > 0) NOT VALID;
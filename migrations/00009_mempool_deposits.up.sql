@@ -0,0 +1,4 @@
+-- +migrate tenant
+-- 内存池里扫到的充值先以 unsafe 状态入库，还没有所在区块，block_number 为 0
+ALTER TABLE deposits{{tenant}} DROP CONSTRAINT IF EXISTS deposits_block_number_check;
+CREATE INDEX IF NOT EXISTS deposits{{tenant}}_block_number ON deposits{{tenant}} (block_number);
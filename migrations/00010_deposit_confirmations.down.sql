@@ -0,0 +1,2 @@
+ALTER TABLE business DROP COLUMN IF EXISTS finalized_confirms;
+ALTER TABLE business DROP COLUMN IF EXISTS safe_confirms;
@@ -0,0 +1,3 @@
+-- 业务方充值确认阈值: 达到 safe_confirms 进入 safe，达到 finalized_confirms 进入 finalized（0 表示使用全局配置）
+ALTER TABLE business ADD COLUMN IF NOT EXISTS safe_confirms SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE business ADD COLUMN IF NOT EXISTS finalized_confirms SMALLINT NOT NULL DEFAULT 0;
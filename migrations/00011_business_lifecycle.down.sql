@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS business_status;
+ALTER TABLE business DROP COLUMN IF EXISTS status;
@@ -0,0 +1,3 @@
+-- 业务方状态: active 正常，suspended 暂停扫块、通知和提现，deregistered 已注销
+ALTER TABLE business ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'active';
+CREATE INDEX IF NOT EXISTS business_status ON business (status);
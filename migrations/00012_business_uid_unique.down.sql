@@ -0,0 +1 @@
+DROP INDEX IF EXISTS business_uid_registered;
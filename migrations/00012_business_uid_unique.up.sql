@@ -0,0 +1,2 @@
+-- 注销的业务方保留记录，同一个 business_uid 只能有一个没有注销的业务方
+CREATE UNIQUE INDEX IF NOT EXISTS business_uid_registered ON business (business_uid) WHERE status <> 'deregistered';
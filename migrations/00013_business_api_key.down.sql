@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS business_api_key_hash;
+ALTER TABLE business DROP COLUMN IF EXISTS api_key_hash;
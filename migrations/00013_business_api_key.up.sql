@@ -0,0 +1,3 @@
+-- 业务方 api key 的 sha256，注册时签发，可以轮换；已有业务方需要管理员轮换一次才能访问接口
+ALTER TABLE business ADD COLUMN IF NOT EXISTS api_key_hash VARCHAR NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS business_api_key_hash ON business (api_key_hash);
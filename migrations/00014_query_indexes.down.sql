@@ -0,0 +1,9 @@
+-- +migrate tenant
+DROP INDEX IF EXISTS child_txs{{tenant}}_to_address;
+DROP INDEX IF EXISTS child_txs{{tenant}}_from_address;
+DROP INDEX IF EXISTS child_txs{{tenant}}_tx_id;
+DROP INDEX IF EXISTS child_txs{{tenant}}_timestamp_guid;
+DROP INDEX IF EXISTS transactions{{tenant}}_timestamp_guid;
+DROP INDEX IF EXISTS internals{{tenant}}_timestamp_guid;
+DROP INDEX IF EXISTS withdraws{{tenant}}_timestamp_guid;
+DROP INDEX IF EXISTS deposits{{tenant}}_timestamp_guid;
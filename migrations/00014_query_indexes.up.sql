@@ -0,0 +1,10 @@
+-- +migrate tenant
+-- 查询接口按 (timestamp, guid) 倒序翻页，按地址过滤时通过 child_txs 关联
+CREATE INDEX IF NOT EXISTS deposits{{tenant}}_timestamp_guid ON deposits{{tenant}} (timestamp, guid);
+CREATE INDEX IF NOT EXISTS withdraws{{tenant}}_timestamp_guid ON withdraws{{tenant}} (timestamp, guid);
+CREATE INDEX IF NOT EXISTS internals{{tenant}}_timestamp_guid ON internals{{tenant}} (timestamp, guid);
+CREATE INDEX IF NOT EXISTS transactions{{tenant}}_timestamp_guid ON transactions{{tenant}} (timestamp, guid);
+CREATE INDEX IF NOT EXISTS child_txs{{tenant}}_timestamp_guid ON child_txs{{tenant}} (timestamp, guid);
+CREATE INDEX IF NOT EXISTS child_txs{{tenant}}_tx_id ON child_txs{{tenant}} (tx_id);
+CREATE INDEX IF NOT EXISTS child_txs{{tenant}}_from_address ON child_txs{{tenant}} (from_address);
+CREATE INDEX IF NOT EXISTS child_txs{{tenant}}_to_address ON child_txs{{tenant}} (to_address);
@@ -0,0 +1,3 @@
+-- +migrate tenant
+ALTER TABLE vouts{{tenant}} ALTER COLUMN n TYPE SMALLINT;
+ALTER TABLE vins{{tenant}} ALTER COLUMN vout TYPE SMALLINT;
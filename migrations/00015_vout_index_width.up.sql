@@ -0,0 +1,4 @@
+-- +migrate tenant
+-- 输出序号是 uint32，SMALLINT 放不下超过 32767 个输出的交易，和 utxos.vout_index 一样用 INTEGER
+ALTER TABLE vins{{tenant}} ALTER COLUMN vout TYPE INTEGER;
+ALTER TABLE vouts{{tenant}} ALTER COLUMN n TYPE INTEGER;
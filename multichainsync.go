@@ -2,7 +2,11 @@ package multichain_transaction_syncs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
 	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -11,36 +15,69 @@ import (
 
 	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/health"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
 	"github.com/dapplink-labs/multichain-sync-btc/worker"
 )
 
+// ChainSync 一条链的扫块和各个 worker，数据库连接指向这条链的 schema，BatchBlock、游标和确认数都是这条链自己的
+type ChainSync struct {
+	Chain config.ChainProfile
+
+	Deposit  *worker.Deposit
+	Withdraw *worker.Withdraw
+	Internal *worker.Internal
+	FallBack *worker.FallBack
+	FeeBump  *worker.FeeBump
+	Mempool  *worker.Mempool
+
+	db            *database.DB
+	accountClient *syncclient.WalletBtcAccountClient
+}
+
+// MultiChainSync 在一个进程里同时同步配置的所有链
 type MultiChainSync struct {
-	Synchronizer *worker.BaseSynchronizer
-	Deposit      *worker.Deposit
-	Withdraw     *worker.Withdraw
-	Internal     *worker.Internal
+	Chains []*ChainSync
 
 	shutdown context.CancelCauseFunc
 	stopped  atomic.Bool
 }
 
 func NewMultiChainSync(ctx context.Context, cfg *config.Config, shutdown context.CancelCauseFunc) (*MultiChainSync, error) {
+	out := &MultiChainSync{shutdown: shutdown}
+	for _, chain := range cfg.Chains {
+		chainCfg := cfg.ForChain(chain)
+		chainSync, err := NewChainSync(ctx, &chainCfg, shutdown)
+		if err != nil {
+			log.Error("init chain sync fail", "chain", chain.Key(), "err", err)
+			return nil, err
+		}
+		out.Chains = append(out.Chains, chainSync)
+	}
+	return out, nil
+}
+
+// NewChainSync cfg 是 Config.ForChain 返回的单条链的配置
+func NewChainSync(ctx context.Context, cfg *config.Config, shutdown context.CancelCauseFunc) (*ChainSync, error) {
 	db, err := database.NewDB(ctx, cfg.MasterDB)
 	if err != nil {
-		log.Error("init database fail", err)
+		log.Error("init database fail", "err", err)
 		return nil, err
 	}
 
-	log.Info("New deposit", "ChainAccountRpc", cfg.ChainBtcRpc)
-	conn, err := grpc.NewClient(cfg.ChainBtcRpc, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	log.Info("New deposit", "chain", cfg.Chain.Key, "ChainAccountRpc", cfg.ChainBtcRpc)
+	conn, err := grpc.NewClient(cfg.ChainBtcRpc,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(metrics.UpstreamUnaryInterceptor(cfg.Chain.Key)),
+	)
 	if err != nil {
 		log.Error("Connect to da retriever fail", "err", err)
 		return nil, err
 	}
 	client := utxo.NewWalletUtxoServiceClient(conn)
-	accountClient, err := syncclient.NewWalletBtcAccountClient(context.Background(), client, "Ethereum")
+	accountClient, err := syncclient.NewWalletBtcAccountClient(context.Background(), client, cfg.Chain)
 	if err != nil {
 		log.Error("new wallet account client fail", "err", err)
 		return nil, err
@@ -49,48 +86,137 @@ func NewMultiChainSync(ctx context.Context, cfg *config.Config, shutdown context
 	deposit, _ := worker.NewDeposit(cfg, db, accountClient, shutdown)
 	withdraw, _ := worker.NewWithdraw(cfg, db, accountClient, shutdown)
 	internal, _ := worker.NewInternal(cfg, db, accountClient, shutdown)
+	fallback, _ := worker.NewFallBack(cfg, db, accountClient, shutdown)
+	feeBump, _ := worker.NewFeeBump(cfg, db, accountClient, shutdown)
+	var mempool *worker.Mempool
+	if cfg.ChainNode.MempoolEnable {
+		mempool, _ = worker.NewMempool(cfg, db, accountClient, shutdown)
+	}
 
-	out := &MultiChainSync{
+	return &ChainSync{
+		Chain:    cfg.Chain,
 		Deposit:  deposit,
 		Withdraw: withdraw,
 		Internal: internal,
-		shutdown: shutdown,
+		FallBack: fallback,
+		FeeBump:  feeBump,
+		Mempool:  mempool,
+
+		db:            db,
+		accountClient: accountClient,
+	}, nil
+}
+
+// ReadinessChecks 扫块进程的就绪检查：每条链的数据库、上游 WalletUtxoService 和扫块新鲜度，检查名前面带上链名
+func (mcs *MultiChainSync) ReadinessChecks(maxStall time.Duration) []health.Check {
+	var checks []health.Check
+	for _, chainSync := range mcs.Chains {
+		checks = append(checks, health.ForChain(chainSync.Chain.Key, chainSync.ReadinessChecks(maxStall)...)...)
+	}
+	return checks
+}
+
+func (cs *ChainSync) ReadinessChecks(maxStall time.Duration) []health.Check {
+	syncedHeight := func(ctx context.Context) (*big.Int, error) {
+		latestBlock, err := cs.db.WithContext(ctx).Blocks.LatestBlocks()
+		if err != nil || latestBlock == nil {
+			return nil, err
+		}
+		return latestBlock.Number, nil
+	}
+	return []health.Check{
+		health.DatabaseCheck(cs.db),
+		health.UpstreamCheck(cs.accountClient.ChainTip),
+		health.ScannerCheck(maxStall, syncedHeight, cs.accountClient.ChainTip),
 	}
-	return out, nil
 }
 
 func (mcs *MultiChainSync) Start(ctx context.Context) error {
-	err := mcs.Deposit.Start()
+	for _, chainSync := range mcs.Chains {
+		if err := chainSync.Start(); err != nil {
+			log.Error("start chain sync fail", "chain", chainSync.Chain.Key, "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Stop 某条链停止失败时继续停止其他的链，错误一起返回
+func (mcs *MultiChainSync) Stop(ctx context.Context) error {
+	var result error
+	for _, chainSync := range mcs.Chains {
+		if err := chainSync.Stop(); err != nil {
+			result = errors.Join(result, fmt.Errorf("stop chain %s: %w", chainSync.Chain.Key, err))
+		}
+	}
+	if result != nil {
+		return result
+	}
+	mcs.stopped.Store(true)
+	return nil
+}
+
+func (mcs *MultiChainSync) Stopped() bool {
+	return mcs.stopped.Load()
+}
+
+func (cs *ChainSync) Start() error {
+	err := cs.Deposit.Start()
 	if err != nil {
 		return err
 	}
-	err = mcs.Withdraw.Start()
+	err = cs.Withdraw.Start()
 	if err != nil {
 		return err
 	}
-	err = mcs.Internal.Start()
+	err = cs.Internal.Start()
 	if err != nil {
 		return err
 	}
+	err = cs.FallBack.Start()
+	if err != nil {
+		return err
+	}
+	err = cs.FeeBump.Start()
+	if err != nil {
+		return err
+	}
+	if cs.Mempool != nil {
+		err = cs.Mempool.Start()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (mcs *MultiChainSync) Stop(ctx context.Context) error {
-	err := mcs.Deposit.Close()
+func (cs *ChainSync) Stop() error {
+	err := cs.Deposit.Close()
 	if err != nil {
 		return err
 	}
-	err = mcs.Withdraw.Close()
+	err = cs.Withdraw.Close()
 	if err != nil {
 		return err
 	}
-	err = mcs.Internal.Close()
+	err = cs.Internal.Close()
 	if err != nil {
 		return err
 	}
-	return nil
-}
-
-func (mcs *MultiChainSync) Stopped() bool {
-	return mcs.stopped.Load()
+	err = cs.FallBack.Close()
+	if err != nil {
+		return err
+	}
+	err = cs.FeeBump.Close()
+	if err != nil {
+		return err
+	}
+	if cs.Mempool != nil {
+		err = cs.Mempool.Close()
+		if err != nil {
+			return err
+		}
+	}
+	// worker 都停下后才关闭数据库连接
+	return cs.db.Close()
 }
@@ -12,12 +12,19 @@ import (
 	"github.com/dapplink-labs/multichain-sync-btc/common/retry"
 	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 )
 
+// ChainDB 一条链的数据库连接，Chain 是链的配置名，通知时带给业务方
+type ChainDB struct {
+	Chain string
+	DB    *database.DB
+}
+
 type Notifier struct {
-	db             *database.DB
-	businessIds    []string
+	chains         []ChainDB
 	notifyClient   map[string]*NotifyClient
+	notifyUrls     map[string]string
 	resourceCtx    context.Context
 	resourceCancel context.CancelFunc
 	tasks          tasks.Group
@@ -27,31 +34,16 @@ type Notifier struct {
 	stopped  atomic.Bool
 }
 
-func NewNotifier(db *database.DB, shutdown context.CancelCauseFunc) (*Notifier, error) {
-	businessList, err := db.Business.QueryBusinessList()
-	if err != nil {
-		log.Error("query business list fail", "err", err)
-		return nil, err
-	}
-
-	var businessIds []string
-	var notifyClient map[string]*NotifyClient
-	for _, business := range businessList {
-		log.Info("handle business id", "business", business.BusinessUid)
-		businessIds = append(businessIds, business.BusinessUid)
-		client, err := NewNotifierClient(business.NotifyUrl)
-		if err != nil {
-			log.Error("new notify client fail", "err", err)
-			return nil, err
-		}
-		notifyClient[business.BusinessUid] = client
+// NewNotifier 按顺序通知每条链的交易，业务方表所有链共用，从第一条链的连接读取
+func NewNotifier(chains []ChainDB, shutdown context.CancelCauseFunc) (*Notifier, error) {
+	if len(chains) == 0 {
+		return nil, errors.New("no chain to notify")
 	}
-
 	resCtx, resCancel := context.WithCancel(context.Background())
 	return &Notifier{
-		db:             db,
-		notifyClient:   notifyClient,
-		businessIds:    businessIds,
+		chains:         chains,
+		notifyClient:   make(map[string]*NotifyClient),
+		notifyUrls:     make(map[string]string),
 		resourceCtx:    resCtx,
 		resourceCancel: resCancel,
 		tasks: tasks.Group{HandleCrit: func(err error) {
@@ -62,57 +54,31 @@ func NewNotifier(db *database.DB, shutdown context.CancelCauseFunc) (*Notifier,
 }
 
 func (nf *Notifier) Start(ctx context.Context) error {
-	log.Info("start internals......")
+	log.Info("start notifier......")
 	nf.tasks.Go(func() error {
 		for {
 			select {
 			case <-nf.ticker.C:
-				var txn []Transaction
-				for _, businessId := range nf.businessIds {
-					log.Info("txn and businessId", "txn", txn, "businessId", businessId)
-
-					needNotifyDeposits, err := nf.db.Deposits.QueryNotifyDeposits(businessId)
-					if err != nil {
-						log.Error("Query notify deposits fail", "err", err)
-						return err
-					}
-
-					needNotifyWithdraws, err := nf.db.Withdraws.QueryNotifyWithdraws(businessId)
-					if err != nil {
-						log.Error("Query notify deposits fail", "err", err)
-						return err
-					}
-
-					needNotifyInternals, err := nf.db.Internals.QueryNotifyInternal(businessId)
-					if err != nil {
-						log.Error("Query notify deposits fail", "err", err)
-						return err
-					}
-					notifyRequest, err := nf.BuildNotifyTransaction(needNotifyDeposits, needNotifyWithdraws, needNotifyInternals)
-
-					// BeforeRequest
-					err = nf.BeforeAfterNotify(businessId, true, false, needNotifyDeposits, needNotifyWithdraws, needNotifyInternals)
+				// 每一轮重新加载业务方，暂停和注销的业务方不通知，通知地址修改之后使用新的地址
+				businessList, err := nf.chains[0].DB.Business.QueryActiveBusinessList()
+				if err != nil {
+					log.Error("query business list fail", "err", err)
+					continue
+				}
+				for _, business := range businessList {
+					client, err := nf.businessClient(business)
 					if err != nil {
-						log.Error("Before notify update status  fail", "err", err)
-						return err
+						log.Error("new notify client fail", "businessId", business.BusinessUid, "err", err)
+						continue
 					}
-
-					notify, err := nf.notifyClient[businessId].BusinessNotify(notifyRequest)
-					if err != nil {
-						log.Error("notify business platform fail", "err")
-						return err
+					for _, chain := range nf.chains {
+						if err := nf.notifyBusiness(chain, business.BusinessUid, client); err != nil {
+							log.Error("notify business fail", "chain", chain.Chain, "businessId", business.BusinessUid, "err", err)
+						}
 					}
-
-					// AfterRequest
-					err = nf.BeforeAfterNotify(businessId, true, notify, needNotifyDeposits, needNotifyWithdraws, needNotifyInternals)
-					if err != nil {
-						log.Error("After notify update status fail", "err", err)
-						return err
-					}
-
 				}
 			case <-nf.resourceCtx.Done():
-				log.Info("stop internals in worker")
+				log.Info("stop notifier in worker")
 				return nil
 			}
 		}
@@ -120,14 +86,29 @@ func (nf *Notifier) Start(ctx context.Context) error {
 	return nil
 }
 
+func (nf *Notifier) businessClient(business database.Business) (*NotifyClient, error) {
+	if client, ok := nf.notifyClient[business.BusinessUid]; ok && nf.notifyUrls[business.BusinessUid] == business.NotifyUrl {
+		return client, nil
+	}
+	client, err := NewNotifierClient(business.NotifyUrl)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("handle business id", "business", business.BusinessUid, "notifyUrl", business.NotifyUrl)
+	nf.notifyClient[business.BusinessUid] = client
+	nf.notifyUrls[business.BusinessUid] = business.NotifyUrl
+	return client, nil
+}
+
 func (nf *Notifier) Stop(ctx context.Context) error {
 	var result error
 	nf.resourceCancel()
 	nf.ticker.Stop()
 	if err := nf.tasks.Wait(); err != nil {
-		result = errors.Join(result, fmt.Errorf("failed to await notify %w"), err)
+		result = errors.Join(result, fmt.Errorf("failed to await notify %w", err))
 		return result
 	}
+	nf.stopped.Store(true)
 	log.Info("stop notify success")
 	return nil
 }
@@ -136,54 +117,97 @@ func (nf *Notifier) Stopped() bool {
 	return nf.stopped.Load()
 }
 
-func (nf *Notifier) BeforeAfterNotify(businessId string, isBefore bool, notifySuccess bool, deposits []database.Deposits, withdraws []database.Withdraws, internals []database.Internals) error {
-	var depositsNotifyStatus uint8
-	var withdrawNotifyStatus database.TxStatus
-	var internalNotifyStatus database.TxStatus
-	if isBefore {
-		depositsNotifyStatus = 2
-		withdrawNotifyStatus = database.TxStatusNotified
-		internalNotifyStatus = database.TxStatusNotified
-	} else {
-		if notifySuccess {
-			depositsNotifyStatus = 3
-			withdrawNotifyStatus = database.TxStatusSuccess
-			internalNotifyStatus = database.TxStatusSuccess
-		} else {
-			depositsNotifyStatus = 1
-			withdrawNotifyStatus = database.TxStatusWalletDone
-			internalNotifyStatus = database.TxStatusWalletDone
-		}
+func (nf *Notifier) notifyBusiness(chain ChainDB, businessId string, client *NotifyClient) error {
+	needNotifyDeposits, err := chain.DB.Deposits.QueryNotifyDeposits(businessId)
+	if err != nil {
+		log.Error("Query notify deposits fail", "err", err)
+		return err
+	}
+
+	needNotifyWithdraws, err := chain.DB.Withdraws.QueryNotifyWithdraws(businessId)
+	if err != nil {
+		log.Error("Query notify withdraws fail", "err", err)
+		return err
+	}
+
+	needNotifyInternals, err := chain.DB.Internals.QueryNotifyInternal(businessId)
+	if err != nil {
+		log.Error("Query notify internals fail", "err", err)
+		return err
+	}
+
+	needSignReplacements, err := chain.DB.WithdrawReplacements.QueryReplacementsByStatus(businessId, database.TxStatusWaitSign)
+	if err != nil {
+		log.Error("Query withdraw replacements fail", "err", err)
+		return err
 	}
-	// 过滤状态为 0 的交易
-	var updateStutusDepositTxn []database.Deposits
+	if len(needNotifyDeposits) == 0 && len(needNotifyWithdraws) == 0 && len(needNotifyInternals) == 0 && len(needSignReplacements) == 0 {
+		return nil
+	}
+
+	notifyRequest, err := nf.BuildNotifyTransaction(needNotifyDeposits, needNotifyWithdraws, needNotifyInternals, needSignReplacements)
+	if err != nil {
+		log.Error("build notify transaction fail", "err", err)
+		return err
+	}
+	notifyRequest.Chain = chain.Chain
+
+	notify, err := client.BusinessNotify(notifyRequest)
+	if err != nil {
+		log.Error("notify business platform fail", "chain", chain.Chain, "businessId", businessId, "err", err)
+		notify = false
+	}
+	metrics.RecordNotify(chain.Chain, businessId, notify)
+
+	return nf.AfterNotify(chain, businessId, notify, needNotifyDeposits, needNotifyWithdraws, needNotifyInternals, needSignReplacements)
+}
+
+// AfterNotify 根据通知结果把交易推进到对应的 *_notify_success 或 *_notify_fail 状态，通知失败的交易下一轮会重新通知；
+// 替换交易通知成功之后进入等待业务方签名状态，失败的保持不变下一轮重发
+func (nf *Notifier) AfterNotify(chain ChainDB, businessId string, notifySuccess bool, deposits []database.Deposits, withdraws []database.Withdraws, internals []database.Internals, replacements []database.WithdrawReplacements) error {
+	depositGroups := make(map[database.TxStatus][]database.Deposits)
 	for _, deposit := range deposits {
-		if deposit.Status != 0 {
-			updateStutusDepositTxn = append(updateStutusDepositTxn, deposit)
-		}
+		status := database.NotifyStatus(deposit.Status, notifySuccess)
+		depositGroups[status] = append(depositGroups[status], deposit)
+	}
+	withdrawGroups := make(map[database.TxStatus][]database.Withdraws)
+	for _, withdraw := range withdraws {
+		status := database.NotifyStatus(withdraw.Status, notifySuccess)
+		withdrawGroups[status] = append(withdrawGroups[status], withdraw)
 	}
+	internalGroups := make(map[database.TxStatus][]database.Internals)
+	for _, internal := range internals {
+		status := database.NotifyStatus(internal.Status, notifySuccess)
+		internalGroups[status] = append(internalGroups[status], internal)
+	}
+
 	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
 	if _, err := retry.Do[interface{}](nf.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
-		if err := nf.db.Transaction(func(tx *database.DB) error {
-			if len(deposits) > 0 {
-				if err := tx.Deposits.UpdateDepositsNotifyStatus(businessId, depositsNotifyStatus, updateStutusDepositTxn); err != nil {
+		if err := chain.DB.Transaction(func(tx *database.DB) error {
+			for status, depositList := range depositGroups {
+				if err := tx.Deposits.UpdateDepositsStatus(businessId, status, depositList); skipStatusChanged(err) != nil {
 					return err
 				}
 			}
-			if len(withdraws) > 0 {
-				if err := tx.Withdraws.UpdateWithdrawStatus(businessId, withdrawNotifyStatus, withdraws); err != nil {
+			for status, withdrawList := range withdrawGroups {
+				if err := tx.Withdraws.UpdateWithdrawStatus(businessId, status, withdrawList); skipStatusChanged(err) != nil {
 					return err
 				}
 			}
-
-			if len(internals) > 0 {
-				if err := tx.Internals.UpdateInternalStatus(businessId, internalNotifyStatus, internals); err != nil {
+			for status, internalList := range internalGroups {
+				if err := tx.Internals.UpdateInternalStatus(businessId, status, internalList); skipStatusChanged(err) != nil {
 					return err
 				}
 			}
-			return nil
+			if notifySuccess {
+				if err := tx.WithdrawReplacements.UpdateReplacementStatus(businessId, database.TxStatusInternalCallBack, replacements); err != nil {
+					return err
+				}
+			}
+			return tx.NotifyApiCacheInvalidate(businessId, database.ApiCacheDeposits, database.ApiCacheWithdraws, database.ApiCacheInternals)
 		}); err != nil {
 			log.Error("unable to persist batch", "err", err)
+			metrics.RecordDbRetry(chain.Chain, "notifier")
 			return nil, err
 		}
 		return nil, nil
@@ -193,7 +217,7 @@ func (nf *Notifier) BeforeAfterNotify(businessId string, isBefore bool, notifySu
 	return nil
 }
 
-func (nf *Notifier) BuildNotifyTransaction(deposits []database.Deposits, withdraws []database.Withdraws, internals []database.Internals) (*NotifyRequest, error) {
+func (nf *Notifier) BuildNotifyTransaction(deposits []database.Deposits, withdraws []database.Withdraws, internals []database.Internals, replacements []database.WithdrawReplacements) (*NotifyRequest, error) {
 	var notifyTransactions []Transaction
 	for _, deposit := range deposits {
 		txItem := Transaction{
@@ -202,6 +226,7 @@ func (nf *Notifier) BuildNotifyTransaction(deposits []database.Deposits, withdra
 			Hash:        deposit.Hash,
 			Fee:         deposit.Fee.String(),
 			TxType:      "deposit",
+			Status:      string(deposit.Status),
 			Confirms:    deposit.Confirms,
 		}
 		notifyTransactions = append(notifyTransactions, txItem)
@@ -215,6 +240,7 @@ func (nf *Notifier) BuildNotifyTransaction(deposits []database.Deposits, withdra
 			// todo:
 			//Fee:          withdraw.Fee,
 			TxType:   "withdraw",
+			Status:   string(withdraw.Status),
 			Confirms: 0,
 		}
 		notifyTransactions = append(notifyTransactions, txItem)
@@ -227,13 +253,37 @@ func (nf *Notifier) BuildNotifyTransaction(deposits []database.Deposits, withdra
 			Hash:        internal.Hash,
 			// todo:
 			//Fee:          withdraw.Fee,
-			TxType:   "withdraw",
+			TxType:   internal.TxType,
+			Status:   string(internal.Status),
 			Confirms: 0,
 		}
 		notifyTransactions = append(notifyTransactions, txItem)
 	}
+
+	var signRequests []SignRequest
+	for _, replacement := range replacements {
+		signRequests = append(signRequests, SignRequest{
+			TransactionUuid: replacement.GUID.String(),
+			UnSignTx:        replacement.UnSignTx,
+			TxData:          replacement.TxData,
+			TxType:          "withdraw_replacement",
+			ReplacedHash:    replacement.ReplacedHash,
+			Fee:             replacement.Fee.String(),
+			FeeRate:         replacement.FeeRate,
+		})
+	}
 	notifyReq := &NotifyRequest{
-		Txn: notifyTransactions,
+		Txn:          notifyTransactions,
+		SignRequests: signRequests,
 	}
 	return notifyReq, nil
 }
+
+// skipStatusChanged 通知期间交易状态被其他流程改掉（例如回滚检测改成 fallback），这些记录保留新状态留给下一轮通知，其余记录照常更新
+func skipStatusChanged(err error) error {
+	if errors.Is(err, database.ErrStatusChanged) {
+		log.Warn("skip notify status update", "err", err)
+		return nil
+	}
+	return err
+}
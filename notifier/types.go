@@ -1,7 +1,20 @@
 package notifier
 
 type NotifyRequest struct {
-	Txn []Transaction `json:"txn"`
+	Chain        string        `json:"chain"` // 交易所在的链，btc、ltc 等，同一个业务方每条链分别通知
+	Txn          []Transaction `json:"txn"`
+	SignRequests []SignRequest `json:"sign_requests,omitempty"`
+}
+
+// SignRequest 需要业务方签名的交易，签名之后通过 buildSignedTransaction 提交，transaction_uuid 原样带回
+type SignRequest struct {
+	TransactionUuid string `json:"transaction_uuid"`
+	UnSignTx        string `json:"un_sign_tx"`
+	TxData          string `json:"tx_data"`
+	TxType          string `json:"tx_type"`       // withdraw_replacement: 提现的 RBF 替换交易
+	ReplacedHash    string `json:"replaced_hash"` // 被替换的交易哈希
+	Fee             string `json:"fee"`
+	FeeRate         int64  `json:"fee_rate"`
 }
 
 type Transaction struct {
@@ -13,6 +26,7 @@ type Transaction struct {
 	Value       string `json:"value"`
 	Fee         string `json:"fee"`
 	TxType      string `json:"tx_type"` // 0: 充值，1:提现；2:归集，3:热转冷；4:冷转热
+	Status      string `json:"status"`  // fallback 表示交易所在区块已被重组掉，业务方需要扣回已入账的资金
 	Confirms    uint8  `json:"confirms"`
 }
 
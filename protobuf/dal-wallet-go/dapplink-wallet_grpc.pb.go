@@ -20,10 +20,25 @@ const _ = grpc.SupportPackageIsVersion7
 
 const (
 	BusinessMiddleWireServices_BusinessRegister_FullMethodName            = "/syncs.BusinessMiddleWireServices/businessRegister"
+	BusinessMiddleWireServices_UpdateBusiness_FullMethodName              = "/syncs.BusinessMiddleWireServices/updateBusiness"
+	BusinessMiddleWireServices_SuspendBusiness_FullMethodName             = "/syncs.BusinessMiddleWireServices/suspendBusiness"
+	BusinessMiddleWireServices_ResumeBusiness_FullMethodName              = "/syncs.BusinessMiddleWireServices/resumeBusiness"
+	BusinessMiddleWireServices_DeregisterBusiness_FullMethodName          = "/syncs.BusinessMiddleWireServices/deregisterBusiness"
+	BusinessMiddleWireServices_RotateApiKey_FullMethodName                = "/syncs.BusinessMiddleWireServices/rotateApiKey"
 	BusinessMiddleWireServices_ExportAddressesByPublicKeys_FullMethodName = "/syncs.BusinessMiddleWireServices/exportAddressesByPublicKeys"
 	BusinessMiddleWireServices_BuildUnSignTransaction_FullMethodName      = "/syncs.BusinessMiddleWireServices/buildUnSignTransaction"
 	BusinessMiddleWireServices_BuildSignedTransaction_FullMethodName      = "/syncs.BusinessMiddleWireServices/buildSignedTransaction"
+	BusinessMiddleWireServices_BuildCpfpTransaction_FullMethodName        = "/syncs.BusinessMiddleWireServices/buildCpfpTransaction"
 	BusinessMiddleWireServices_SubmitWithdraw_FullMethodName              = "/syncs.BusinessMiddleWireServices/submitWithdraw"
+	BusinessMiddleWireServices_QueryDeposits_FullMethodName               = "/syncs.BusinessMiddleWireServices/queryDeposits"
+	BusinessMiddleWireServices_QueryWithdraws_FullMethodName              = "/syncs.BusinessMiddleWireServices/queryWithdraws"
+	BusinessMiddleWireServices_QueryInternals_FullMethodName              = "/syncs.BusinessMiddleWireServices/queryInternals"
+	BusinessMiddleWireServices_QueryTransactions_FullMethodName           = "/syncs.BusinessMiddleWireServices/queryTransactions"
+	BusinessMiddleWireServices_QueryChildTxs_FullMethodName               = "/syncs.BusinessMiddleWireServices/queryChildTxs"
+	BusinessMiddleWireServices_GetTransaction_FullMethodName              = "/syncs.BusinessMiddleWireServices/getTransaction"
+	BusinessMiddleWireServices_GetAddressBalance_FullMethodName           = "/syncs.BusinessMiddleWireServices/getAddressBalance"
+	BusinessMiddleWireServices_ListUtxos_FullMethodName                   = "/syncs.BusinessMiddleWireServices/listUtxos"
+	BusinessMiddleWireServices_GetWalletBalances_FullMethodName           = "/syncs.BusinessMiddleWireServices/getWalletBalances"
 )
 
 // BusinessMiddleWireServicesClient is the client API for BusinessMiddleWireServices service.
@@ -31,11 +46,30 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BusinessMiddleWireServicesClient interface {
 	BusinessRegister(ctx context.Context, in *BusinessRegisterRequest, opts ...grpc.CallOption) (*BusinessRegisterResponse, error)
+	// --业务方生命周期: 修改回调地址、暂停、恢复、注销--
+	UpdateBusiness(ctx context.Context, in *UpdateBusinessRequest, opts ...grpc.CallOption) (*UpdateBusinessResponse, error)
+	SuspendBusiness(ctx context.Context, in *BusinessStatusRequest, opts ...grpc.CallOption) (*BusinessStatusResponse, error)
+	ResumeBusiness(ctx context.Context, in *BusinessStatusRequest, opts ...grpc.CallOption) (*BusinessStatusResponse, error)
+	DeregisterBusiness(ctx context.Context, in *DeregisterBusinessRequest, opts ...grpc.CallOption) (*DeregisterBusinessResponse, error)
+	RotateApiKey(ctx context.Context, in *RotateApiKeyRequest, opts ...grpc.CallOption) (*RotateApiKeyResponse, error)
 	ExportAddressesByPublicKeys(ctx context.Context, in *ExportAddressesRequest, opts ...grpc.CallOption) (*ExportAddressesResponse, error)
 	BuildUnSignTransaction(ctx context.Context, in *UnSignWithdrawTransactionRequest, opts ...grpc.CallOption) (*UnSignWithdrawTransactionResponse, error)
 	BuildSignedTransaction(ctx context.Context, in *SignedWithdrawTransactionRequest, opts ...grpc.CallOption) (*SignedWithdrawTransactionResponse, error)
+	// --子交易加速未确认的充值和找零输出--
+	BuildCpfpTransaction(ctx context.Context, in *CpfpTransactionRequest, opts ...grpc.CallOption) (*CpfpTransactionResponse, error)
 	// --提交提现交易--
 	SubmitWithdraw(ctx context.Context, in *SubmitWithdrawRequest, opts ...grpc.CallOption) (*SubmitWithdrawResponse, error)
+	// --分页查询充值、提现、内部交易、交易流水和子交易--
+	QueryDeposits(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error)
+	QueryWithdraws(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error)
+	QueryInternals(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error)
+	QueryTransactions(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error)
+	QueryChildTxs(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryChildTxsResponse, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
+	// --按地址和钱包角色查询余额和 utxo--
+	GetAddressBalance(ctx context.Context, in *AddressBalanceRequest, opts ...grpc.CallOption) (*AddressBalanceResponse, error)
+	ListUtxos(ctx context.Context, in *ListUtxosRequest, opts ...grpc.CallOption) (*ListUtxosResponse, error)
+	GetWalletBalances(ctx context.Context, in *WalletBalancesRequest, opts ...grpc.CallOption) (*WalletBalancesResponse, error)
 }
 
 type businessMiddleWireServicesClient struct {
@@ -55,6 +89,51 @@ func (c *businessMiddleWireServicesClient) BusinessRegister(ctx context.Context,
 	return out, nil
 }
 
+func (c *businessMiddleWireServicesClient) UpdateBusiness(ctx context.Context, in *UpdateBusinessRequest, opts ...grpc.CallOption) (*UpdateBusinessResponse, error) {
+	out := new(UpdateBusinessResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_UpdateBusiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) SuspendBusiness(ctx context.Context, in *BusinessStatusRequest, opts ...grpc.CallOption) (*BusinessStatusResponse, error) {
+	out := new(BusinessStatusResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_SuspendBusiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) ResumeBusiness(ctx context.Context, in *BusinessStatusRequest, opts ...grpc.CallOption) (*BusinessStatusResponse, error) {
+	out := new(BusinessStatusResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_ResumeBusiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) DeregisterBusiness(ctx context.Context, in *DeregisterBusinessRequest, opts ...grpc.CallOption) (*DeregisterBusinessResponse, error) {
+	out := new(DeregisterBusinessResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_DeregisterBusiness_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) RotateApiKey(ctx context.Context, in *RotateApiKeyRequest, opts ...grpc.CallOption) (*RotateApiKeyResponse, error) {
+	out := new(RotateApiKeyResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_RotateApiKey_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *businessMiddleWireServicesClient) ExportAddressesByPublicKeys(ctx context.Context, in *ExportAddressesRequest, opts ...grpc.CallOption) (*ExportAddressesResponse, error) {
 	out := new(ExportAddressesResponse)
 	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_ExportAddressesByPublicKeys_FullMethodName, in, out, opts...)
@@ -82,6 +161,15 @@ func (c *businessMiddleWireServicesClient) BuildSignedTransaction(ctx context.Co
 	return out, nil
 }
 
+func (c *businessMiddleWireServicesClient) BuildCpfpTransaction(ctx context.Context, in *CpfpTransactionRequest, opts ...grpc.CallOption) (*CpfpTransactionResponse, error) {
+	out := new(CpfpTransactionResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_BuildCpfpTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *businessMiddleWireServicesClient) SubmitWithdraw(ctx context.Context, in *SubmitWithdrawRequest, opts ...grpc.CallOption) (*SubmitWithdrawResponse, error) {
 	out := new(SubmitWithdrawResponse)
 	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_SubmitWithdraw_FullMethodName, in, out, opts...)
@@ -91,16 +179,116 @@ func (c *businessMiddleWireServicesClient) SubmitWithdraw(ctx context.Context, i
 	return out, nil
 }
 
+func (c *businessMiddleWireServicesClient) QueryDeposits(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error) {
+	out := new(QueryTransactionsResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_QueryDeposits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) QueryWithdraws(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error) {
+	out := new(QueryTransactionsResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_QueryWithdraws_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) QueryInternals(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error) {
+	out := new(QueryTransactionsResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_QueryInternals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) QueryTransactions(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryTransactionsResponse, error) {
+	out := new(QueryTransactionsResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_QueryTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) QueryChildTxs(ctx context.Context, in *QueryTransactionsRequest, opts ...grpc.CallOption) (*QueryChildTxsResponse, error) {
+	out := new(QueryChildTxsResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_QueryChildTxs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error) {
+	out := new(GetTransactionResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_GetTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) GetAddressBalance(ctx context.Context, in *AddressBalanceRequest, opts ...grpc.CallOption) (*AddressBalanceResponse, error) {
+	out := new(AddressBalanceResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_GetAddressBalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) ListUtxos(ctx context.Context, in *ListUtxosRequest, opts ...grpc.CallOption) (*ListUtxosResponse, error) {
+	out := new(ListUtxosResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_ListUtxos_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *businessMiddleWireServicesClient) GetWalletBalances(ctx context.Context, in *WalletBalancesRequest, opts ...grpc.CallOption) (*WalletBalancesResponse, error) {
+	out := new(WalletBalancesResponse)
+	err := c.cc.Invoke(ctx, BusinessMiddleWireServices_GetWalletBalances_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BusinessMiddleWireServicesServer is the server API for BusinessMiddleWireServices service.
 // All implementations should embed UnimplementedBusinessMiddleWireServicesServer
 // for forward compatibility
 type BusinessMiddleWireServicesServer interface {
 	BusinessRegister(context.Context, *BusinessRegisterRequest) (*BusinessRegisterResponse, error)
+	// --业务方生命周期: 修改回调地址、暂停、恢复、注销--
+	UpdateBusiness(context.Context, *UpdateBusinessRequest) (*UpdateBusinessResponse, error)
+	SuspendBusiness(context.Context, *BusinessStatusRequest) (*BusinessStatusResponse, error)
+	ResumeBusiness(context.Context, *BusinessStatusRequest) (*BusinessStatusResponse, error)
+	DeregisterBusiness(context.Context, *DeregisterBusinessRequest) (*DeregisterBusinessResponse, error)
+	RotateApiKey(context.Context, *RotateApiKeyRequest) (*RotateApiKeyResponse, error)
 	ExportAddressesByPublicKeys(context.Context, *ExportAddressesRequest) (*ExportAddressesResponse, error)
 	BuildUnSignTransaction(context.Context, *UnSignWithdrawTransactionRequest) (*UnSignWithdrawTransactionResponse, error)
 	BuildSignedTransaction(context.Context, *SignedWithdrawTransactionRequest) (*SignedWithdrawTransactionResponse, error)
+	// --子交易加速未确认的充值和找零输出--
+	BuildCpfpTransaction(context.Context, *CpfpTransactionRequest) (*CpfpTransactionResponse, error)
 	// --提交提现交易--
 	SubmitWithdraw(context.Context, *SubmitWithdrawRequest) (*SubmitWithdrawResponse, error)
+	// --分页查询充值、提现、内部交易、交易流水和子交易--
+	QueryDeposits(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error)
+	QueryWithdraws(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error)
+	QueryInternals(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error)
+	QueryTransactions(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error)
+	QueryChildTxs(context.Context, *QueryTransactionsRequest) (*QueryChildTxsResponse, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
+	// --按地址和钱包角色查询余额和 utxo--
+	GetAddressBalance(context.Context, *AddressBalanceRequest) (*AddressBalanceResponse, error)
+	ListUtxos(context.Context, *ListUtxosRequest) (*ListUtxosResponse, error)
+	GetWalletBalances(context.Context, *WalletBalancesRequest) (*WalletBalancesResponse, error)
 }
 
 // UnimplementedBusinessMiddleWireServicesServer should be embedded to have forward compatible implementations.
@@ -110,6 +298,21 @@ type UnimplementedBusinessMiddleWireServicesServer struct {
 func (UnimplementedBusinessMiddleWireServicesServer) BusinessRegister(context.Context, *BusinessRegisterRequest) (*BusinessRegisterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BusinessRegister not implemented")
 }
+func (UnimplementedBusinessMiddleWireServicesServer) UpdateBusiness(context.Context, *UpdateBusinessRequest) (*UpdateBusinessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBusiness not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) SuspendBusiness(context.Context, *BusinessStatusRequest) (*BusinessStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendBusiness not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) ResumeBusiness(context.Context, *BusinessStatusRequest) (*BusinessStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBusiness not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) DeregisterBusiness(context.Context, *DeregisterBusinessRequest) (*DeregisterBusinessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeregisterBusiness not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) RotateApiKey(context.Context, *RotateApiKeyRequest) (*RotateApiKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateApiKey not implemented")
+}
 func (UnimplementedBusinessMiddleWireServicesServer) ExportAddressesByPublicKeys(context.Context, *ExportAddressesRequest) (*ExportAddressesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportAddressesByPublicKeys not implemented")
 }
@@ -119,9 +322,39 @@ func (UnimplementedBusinessMiddleWireServicesServer) BuildUnSignTransaction(cont
 func (UnimplementedBusinessMiddleWireServicesServer) BuildSignedTransaction(context.Context, *SignedWithdrawTransactionRequest) (*SignedWithdrawTransactionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BuildSignedTransaction not implemented")
 }
+func (UnimplementedBusinessMiddleWireServicesServer) BuildCpfpTransaction(context.Context, *CpfpTransactionRequest) (*CpfpTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildCpfpTransaction not implemented")
+}
 func (UnimplementedBusinessMiddleWireServicesServer) SubmitWithdraw(context.Context, *SubmitWithdrawRequest) (*SubmitWithdrawResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitWithdraw not implemented")
 }
+func (UnimplementedBusinessMiddleWireServicesServer) QueryDeposits(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryDeposits not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) QueryWithdraws(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryWithdraws not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) QueryInternals(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryInternals not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) QueryTransactions(context.Context, *QueryTransactionsRequest) (*QueryTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryTransactions not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) QueryChildTxs(context.Context, *QueryTransactionsRequest) (*QueryChildTxsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryChildTxs not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) GetAddressBalance(context.Context, *AddressBalanceRequest) (*AddressBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressBalance not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) ListUtxos(context.Context, *ListUtxosRequest) (*ListUtxosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUtxos not implemented")
+}
+func (UnimplementedBusinessMiddleWireServicesServer) GetWalletBalances(context.Context, *WalletBalancesRequest) (*WalletBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletBalances not implemented")
+}
 
 // UnsafeBusinessMiddleWireServicesServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to BusinessMiddleWireServicesServer will
@@ -152,6 +385,96 @@ func _BusinessMiddleWireServices_BusinessRegister_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BusinessMiddleWireServices_UpdateBusiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBusinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).UpdateBusiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_UpdateBusiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).UpdateBusiness(ctx, req.(*UpdateBusinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_SuspendBusiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BusinessStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).SuspendBusiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_SuspendBusiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).SuspendBusiness(ctx, req.(*BusinessStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_ResumeBusiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BusinessStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).ResumeBusiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_ResumeBusiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).ResumeBusiness(ctx, req.(*BusinessStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_DeregisterBusiness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterBusinessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).DeregisterBusiness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_DeregisterBusiness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).DeregisterBusiness(ctx, req.(*DeregisterBusinessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_RotateApiKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateApiKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).RotateApiKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_RotateApiKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).RotateApiKey(ctx, req.(*RotateApiKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BusinessMiddleWireServices_ExportAddressesByPublicKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExportAddressesRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +529,24 @@ func _BusinessMiddleWireServices_BuildSignedTransaction_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BusinessMiddleWireServices_BuildCpfpTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CpfpTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).BuildCpfpTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_BuildCpfpTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).BuildCpfpTransaction(ctx, req.(*CpfpTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BusinessMiddleWireServices_SubmitWithdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitWithdrawRequest)
 	if err := dec(in); err != nil {
@@ -224,6 +565,168 @@ func _BusinessMiddleWireServices_SubmitWithdraw_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BusinessMiddleWireServices_QueryDeposits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).QueryDeposits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_QueryDeposits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).QueryDeposits(ctx, req.(*QueryTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_QueryWithdraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).QueryWithdraws(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_QueryWithdraws_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).QueryWithdraws(ctx, req.(*QueryTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_QueryInternals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).QueryInternals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_QueryInternals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).QueryInternals(ctx, req.(*QueryTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_QueryTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).QueryTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_QueryTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).QueryTransactions(ctx, req.(*QueryTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_QueryChildTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).QueryChildTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_QueryChildTxs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).QueryChildTxs(ctx, req.(*QueryTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_GetAddressBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).GetAddressBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_GetAddressBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).GetAddressBalance(ctx, req.(*AddressBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_ListUtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUtxosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).ListUtxos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_ListUtxos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).ListUtxos(ctx, req.(*ListUtxosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BusinessMiddleWireServices_GetWalletBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BusinessMiddleWireServicesServer).GetWalletBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BusinessMiddleWireServices_GetWalletBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BusinessMiddleWireServicesServer).GetWalletBalances(ctx, req.(*WalletBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BusinessMiddleWireServices_ServiceDesc is the grpc.ServiceDesc for BusinessMiddleWireServices service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -235,6 +738,26 @@ var BusinessMiddleWireServices_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "businessRegister",
 			Handler:    _BusinessMiddleWireServices_BusinessRegister_Handler,
 		},
+		{
+			MethodName: "updateBusiness",
+			Handler:    _BusinessMiddleWireServices_UpdateBusiness_Handler,
+		},
+		{
+			MethodName: "suspendBusiness",
+			Handler:    _BusinessMiddleWireServices_SuspendBusiness_Handler,
+		},
+		{
+			MethodName: "resumeBusiness",
+			Handler:    _BusinessMiddleWireServices_ResumeBusiness_Handler,
+		},
+		{
+			MethodName: "deregisterBusiness",
+			Handler:    _BusinessMiddleWireServices_DeregisterBusiness_Handler,
+		},
+		{
+			MethodName: "rotateApiKey",
+			Handler:    _BusinessMiddleWireServices_RotateApiKey_Handler,
+		},
 		{
 			MethodName: "exportAddressesByPublicKeys",
 			Handler:    _BusinessMiddleWireServices_ExportAddressesByPublicKeys_Handler,
@@ -247,10 +770,50 @@ var BusinessMiddleWireServices_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "buildSignedTransaction",
 			Handler:    _BusinessMiddleWireServices_BuildSignedTransaction_Handler,
 		},
+		{
+			MethodName: "buildCpfpTransaction",
+			Handler:    _BusinessMiddleWireServices_BuildCpfpTransaction_Handler,
+		},
 		{
 			MethodName: "submitWithdraw",
 			Handler:    _BusinessMiddleWireServices_SubmitWithdraw_Handler,
 		},
+		{
+			MethodName: "queryDeposits",
+			Handler:    _BusinessMiddleWireServices_QueryDeposits_Handler,
+		},
+		{
+			MethodName: "queryWithdraws",
+			Handler:    _BusinessMiddleWireServices_QueryWithdraws_Handler,
+		},
+		{
+			MethodName: "queryInternals",
+			Handler:    _BusinessMiddleWireServices_QueryInternals_Handler,
+		},
+		{
+			MethodName: "queryTransactions",
+			Handler:    _BusinessMiddleWireServices_QueryTransactions_Handler,
+		},
+		{
+			MethodName: "queryChildTxs",
+			Handler:    _BusinessMiddleWireServices_QueryChildTxs_Handler,
+		},
+		{
+			MethodName: "getTransaction",
+			Handler:    _BusinessMiddleWireServices_GetTransaction_Handler,
+		},
+		{
+			MethodName: "getAddressBalance",
+			Handler:    _BusinessMiddleWireServices_GetAddressBalance_Handler,
+		},
+		{
+			MethodName: "listUtxos",
+			Handler:    _BusinessMiddleWireServices_ListUtxos_Handler,
+		},
+		{
+			MethodName: "getWalletBalances",
+			Handler:    _BusinessMiddleWireServices_GetWalletBalances_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protobuf/dapplink-wallet.proto",
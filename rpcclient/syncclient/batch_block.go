@@ -9,10 +9,12 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/dapplink-labs/multichain-sync-btc/common/bigint"
+	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 )
 
 var (
-	ErrBatchBlockAheadOfProvider = errors.New("the BatchBlock's internal state is ahead of the provider")
+	ErrBatchBlockAheadOfProvider            = errors.New("the BatchBlock's internal state is ahead of the provider")
+	ErrBatchBlockAndProviderMismatchedState = errors.New("the BatchBlock and provider have diverged in state")
 )
 
 type BatchBlock struct {
@@ -22,13 +24,18 @@ type BatchBlock struct {
 	lastTraversedHeader *BlockHeader
 
 	blockConfirmationDepth *big.Int
+	fetchConcurrency       int
 }
 
-func NewBatchBlock(rpcClient *WalletBtcAccountClient, fromHeader *BlockHeader, confDepth *big.Int) *BatchBlock {
+func NewBatchBlock(rpcClient *WalletBtcAccountClient, fromHeader *BlockHeader, confDepth *big.Int, fetchConcurrency uint) *BatchBlock {
+	if fetchConcurrency == 0 {
+		fetchConcurrency = 1
+	}
 	return &BatchBlock{
 		rpcClient:              rpcClient,
 		lastTraversedHeader:    fromHeader,
 		blockConfirmationDepth: confDepth,
+		fetchConcurrency:       int(fetchConcurrency),
 	}
 }
 
@@ -40,6 +47,11 @@ func (f *BatchBlock) LastTraversedHeader() *BlockHeader {
 	return f.lastTraversedHeader
 }
 
+// Rewind 将遍历游标回退到指定区块（回滚到分叉点），下一批次从该区块的下一个高度开始
+func (f *BatchBlock) Rewind(header *BlockHeader) {
+	f.lastTraversedHeader = header
+}
+
 func (f *BatchBlock) NextHeaders(maxSize uint64) ([]BlockHeader, error) {
 	latestHeader, err := f.rpcClient.GetBlockHeader(nil)
 	if err != nil {
@@ -67,20 +79,32 @@ func (f *BatchBlock) NextHeaders(maxSize uint64) ([]BlockHeader, error) {
 	}
 	endHeight = bigint.Clamp(nextHeight, endHeight, maxSize)
 	count := new(big.Int).Sub(endHeight, nextHeight).Uint64() + 1
-	headers := make([]BlockHeader, count)
-	for i := uint64(0); i < count; i++ {
-		height := new(big.Int).Add(nextHeight, new(big.Int).SetUint64(i))
+	// 并发拉取区块头，结果按高度顺序排列，后面的连续性校验不受影响
+	headers, err := tasks.ParallelMap(int(count), f.fetchConcurrency, func(i int) (BlockHeader, error) {
+		height := new(big.Int).Add(nextHeight, big.NewInt(int64(i)))
 		blockHeader, err := f.rpcClient.GetBlockHeader(height)
 		if err != nil {
-			log.Error("get block info fail", "err", err)
-			return nil, err
+			log.Error("get block info fail", "height", height, "err", err)
+			return BlockHeader{}, err
 		}
-		headers[i] = *blockHeader
+		return *blockHeader, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	numHeaders := len(headers)
 	if numHeaders == 0 {
 		return nil, nil
+	} else if f.lastTraversedHeader != nil && headers[0].PrevHash != f.lastTraversedHeader.Hash {
+		log.Warn("first header of batch does not link to last traversed header", "number", headers[0].Number, "prevHash", headers[0].PrevHash, "lastHash", f.lastTraversedHeader.Hash)
+		return nil, ErrBatchBlockAndProviderMismatchedState
+	}
+	for i := 1; i < numHeaders; i++ {
+		if headers[i].PrevHash != headers[i-1].Hash {
+			log.Warn("headers of batch are not continuous", "number", headers[i].Number, "prevHash", headers[i].PrevHash, "parentHash", headers[i-1].Hash)
+			return nil, ErrBatchBlockAndProviderMismatchedState
+		}
 	}
 
 	f.lastTraversedHeader = &headers[numHeaders-1]
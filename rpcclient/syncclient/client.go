@@ -2,27 +2,44 @@ package syncclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/common"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
 )
 
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// WalletBtcAccountClient 上游 WalletUtxoService 客户端，请求里的链名、网络和币种取自所选链的配置
 type WalletBtcAccountClient struct {
 	Ctx          context.Context
 	ChainName    string
+	Network      string
+	CoinName     string
 	BtcRpcClient utxo.WalletUtxoServiceClient
 }
 
-func NewWalletBtcAccountClient(ctx context.Context, rpc utxo.WalletUtxoServiceClient, chainName string) (*WalletBtcAccountClient, error) {
-	log.Info("New account chain rpc client", "chainName", chainName)
-	return &WalletBtcAccountClient{Ctx: ctx, BtcRpcClient: rpc, ChainName: chainName}, nil
+func NewWalletBtcAccountClient(ctx context.Context, rpc utxo.WalletUtxoServiceClient, chain config.ChainProfile) (*WalletBtcAccountClient, error) {
+	log.Info("New account chain rpc client", "chainName", chain.ChainName, "network", chain.Network)
+	return &WalletBtcAccountClient{
+		Ctx:          ctx,
+		ChainName:    chain.ChainName,
+		Network:      chain.Network,
+		CoinName:     chain.CoinName,
+		BtcRpcClient: rpc,
+	}, nil
 }
 
 func (wac *WalletBtcAccountClient) ExportAddressByPubKey(format, publicKey string) string {
 	req := &utxo.ConvertAddressRequest{
+		Chain:     wac.ChainName,
+		Network:   wac.Network,
 		Format:    format,
 		PublicKey: publicKey,
 	}
@@ -35,9 +52,14 @@ func (wac *WalletBtcAccountClient) ExportAddressByPubKey(format, publicKey strin
 }
 
 func (wac *WalletBtcAccountClient) GetBlockHeader(number *big.Int) (*BlockHeader, error) {
+	var height int64
+	if number != nil {
+		height = number.Int64()
+	}
 	request := &utxo.BlockHeaderNumberRequest{
-		Network: "mainnet",
-		Height:  number.Int64(),
+		Chain:   wac.ChainName,
+		Network: wac.Network,
+		Height:  height,
 	}
 	blockHeader, err := wac.BtcRpcClient.GetBlockHeaderByNumber(context.Background(), request)
 	if err != nil {
@@ -52,8 +74,29 @@ func (wac *WalletBtcAccountClient) GetBlockHeader(number *big.Int) (*BlockHeader
 	}, nil
 }
 
+// ChainTip 查询链上最新高度，调用方通过 ctx 控制超时
+func (wac *WalletBtcAccountClient) ChainTip(ctx context.Context) (*big.Int, error) {
+	request := &utxo.BlockHeaderNumberRequest{
+		Chain:   wac.ChainName,
+		Network: wac.Network,
+	}
+	blockHeader, err := wac.BtcRpcClient.GetBlockHeaderByNumber(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if blockHeader.Code == common.ReturnCode_ERROR {
+		return nil, errors.New(blockHeader.Msg)
+	}
+	blockNumber, ok := new(big.Int).SetString(blockHeader.Number, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid block number %q", blockHeader.Number)
+	}
+	return blockNumber, nil
+}
+
 func (wac *WalletBtcAccountClient) GetBlockByNumber(blockNumber *big.Int) ([]*utxo.TransactionList, error) {
 	blockReq := &utxo.BlockNumberRequest{
+		Chain:  wac.ChainName,
 		Height: blockNumber.Int64(),
 	}
 	blockInfo, err := wac.BtcRpcClient.GetBlockByNumber(context.Background(), blockReq)
@@ -68,13 +111,90 @@ func (wac *WalletBtcAccountClient) GetBlockByNumber(blockNumber *big.Int) ([]*ut
 }
 
 func (wac *WalletBtcAccountClient) GetTransactionByHash(hash string) (*utxo.TxMessage, error) {
-	return nil, nil
+	txReq := &utxo.TxHashRequest{
+		Chain:   wac.ChainName,
+		Network: wac.Network,
+		Coin:    wac.CoinName,
+		Hash:    hash,
+	}
+	txResp, err := wac.BtcRpcClient.GetTxByHash(context.Background(), txReq)
+	if err != nil {
+		log.Error("get transaction by hash fail", "err", err)
+		return nil, err
+	}
+	if txResp.Code == common.ReturnCode_ERROR || txResp.Tx == nil {
+		return nil, ErrTransactionNotFound
+	}
+	return txResp.Tx, nil
+}
+
+// GetPendingTxByAddress 查询地址最近的交易，只返回还在内存池中未打包的
+func (wac *WalletBtcAccountClient) GetPendingTxByAddress(address string, pageSize uint32) ([]*utxo.TxMessage, error) {
+	txReq := &utxo.TxAddressRequest{
+		Chain:    wac.ChainName,
+		Network:  wac.Network,
+		Coin:     wac.CoinName,
+		Address:  address,
+		Page:     1,
+		Pagesize: pageSize,
+	}
+	txResp, err := wac.BtcRpcClient.GetTxByAddress(context.Background(), txReq)
+	if err != nil {
+		log.Error("get transaction by address fail", "address", address, "err", err)
+		return nil, err
+	}
+	if txResp.Code == common.ReturnCode_ERROR {
+		return nil, errors.New("get transaction by address fail: " + txResp.Msg)
+	}
+	var pendingTxList []*utxo.TxMessage
+	for _, tx := range txResp.Tx {
+		if tx.Status == utxo.TxStatus_Pending {
+			pendingTxList = append(pendingTxList, tx)
+		}
+	}
+	return pendingTxList, nil
 }
 
 func (wac *WalletBtcAccountClient) GetAccount(address string) (int, error) {
 	return 0, nil
 }
 
+func (wac *WalletBtcAccountClient) GetFee() (*utxo.FeeResponse, error) {
+	feeReq := &utxo.FeeRequest{
+		Chain:   wac.ChainName,
+		Network: wac.Network,
+		Coin:    wac.CoinName,
+	}
+	feeResp, err := wac.BtcRpcClient.GetFee(context.Background(), feeReq)
+	if err != nil {
+		log.Error("get fee fail", "err", err)
+		return nil, err
+	}
+	if feeResp.Code == common.ReturnCode_ERROR {
+		return nil, errors.New("get fee fail: " + feeResp.Msg)
+	}
+	return feeResp, nil
+}
+
+func (wac *WalletBtcAccountClient) CreateUnSignTransaction(fee int64, vins []*utxo.Vin, vouts []*utxo.Vout) (*utxo.UnSignTransactionResponse, error) {
+	unSignReq := &utxo.UnSignTransactionRequest{
+		Chain:   wac.ChainName,
+		Network: wac.Network,
+		Fee:     strconv.FormatInt(fee, 10),
+		Vin:     vins,
+		Vout:    vouts,
+	}
+	unSignTx, err := wac.BtcRpcClient.CreateUnSignTransaction(context.Background(), unSignReq)
+	if err != nil {
+		log.Error("create un sign transaction fail", "err", err)
+		return nil, err
+	}
+	if unSignTx.Code == common.ReturnCode_ERROR {
+		return nil, errors.New("create un sign transaction fail: " + unSignTx.Msg)
+	}
+	return unSignTx, nil
+}
+
 func (wac *WalletBtcAccountClient) SendTx(rawTx string) (string, error) {
 	return "", nil
 }
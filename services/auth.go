@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/apikey"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+const (
+	// ApiKeyHeader 业务方 api key，没有时使用请求里的 consumer_token 字段
+	ApiKeyHeader = "x-api-key"
+	// AdminTokenHeader 注册、暂停、恢复、注销业务方需要管理员 token
+	AdminTokenHeader = "x-admin-token"
+)
+
+var adminMethods = map[string]bool{
+	dal_wallet_go.BusinessMiddleWireServices_BusinessRegister_FullMethodName:   true,
+	dal_wallet_go.BusinessMiddleWireServices_SuspendBusiness_FullMethodName:    true,
+	dal_wallet_go.BusinessMiddleWireServices_ResumeBusiness_FullMethodName:     true,
+	dal_wallet_go.BusinessMiddleWireServices_DeregisterBusiness_FullMethodName: true,
+}
+
+// publicMethods 不需要鉴权的接口，探针调用 grpc health 服务不带 api key
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+}
+
+type authBusinessKey struct{}
+
+// AuthBusinessFromContext 返回鉴权拦截器绑定到请求上的业务方
+func AuthBusinessFromContext(ctx context.Context) (*database.Business, bool) {
+	business, ok := ctx.Value(authBusinessKey{}).(*database.Business)
+	return business, ok
+}
+
+// authUnaryInterceptor health 检查不鉴权，管理接口校验管理员 token，其余接口校验业务方 api key，并要求请求的 request_id 和 api key 所属业务方一致；
+// 管理员也可以调用 rotateApiKey 给丢失 api key 的业务方重新签发
+func (bws *BusinessMiddleWireServices) authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	isAdmin := bws.isAdmin(ctx)
+	if adminMethods[info.FullMethod] {
+		if !isAdmin {
+			return nil, status.Error(codes.PermissionDenied, "admin token is required")
+		}
+		return handler(ctx, req)
+	}
+	if isAdmin && info.FullMethod == dal_wallet_go.BusinessMiddleWireServices_RotateApiKey_FullMethodName {
+		return handler(ctx, req)
+	}
+
+	key := metadataValue(ctx, ApiKeyHeader)
+	if key == "" {
+		if tokenReq, ok := req.(interface{ GetConsumerToken() string }); ok {
+			key = tokenReq.GetConsumerToken()
+		}
+	}
+	if key == "" {
+		return nil, status.Error(codes.Unauthenticated, "api key is required")
+	}
+	business, err := bws.db.Business.QueryBusinessByApiKeyHash(apikey.Hash(key))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	} else if err != nil {
+		log.Error("query business by api key fail", "err", err)
+		return nil, status.Error(codes.Internal, "query business fail")
+	}
+	if businessReq, ok := req.(interface{ GetRequestId() string }); ok && businessReq.GetRequestId() != business.BusinessUid {
+		return nil, status.Error(codes.PermissionDenied, "request_id does not match api key")
+	}
+	if ctxInfo, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		ctxInfo.BusinessId = business.BusinessUid
+	}
+	return handler(context.WithValue(ctx, authBusinessKey{}, business), req)
+}
+
+func (bws *BusinessMiddleWireServices) isAdmin(ctx context.Context) bool {
+	if bws.AdminToken == "" {
+		return false
+	}
+	token := metadataValue(ctx, AdminTokenHeader)
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(bws.AdminToken)) == 1
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/apikey"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// fakeBusinessDB 按 api key 哈希查业务方，和数据库查询一样跳过已注销的业务方
+type fakeBusinessDB struct {
+	database.BusinessDB
+	businesses []database.Business
+}
+
+func (db *fakeBusinessDB) QueryBusinessByApiKeyHash(apiKeyHash string) (*database.Business, error) {
+	for i := range db.businesses {
+		business := db.businesses[i]
+		if business.ApiKeyHash == apiKeyHash && business.Status != database.BusinessStatusDeregistered {
+			return &business, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestAuthUnaryInterceptor(t *testing.T) {
+	const (
+		adminToken    = "admin-secret"
+		activeKey     = "active-key"
+		deregisterKey = "deregistered-key"
+	)
+	bws := &BusinessMiddleWireServices{
+		BusinessMiddleConfig: &BusinessMiddleConfig{AdminToken: adminToken},
+		db: &database.DB{Business: &fakeBusinessDB{businesses: []database.Business{
+			{BusinessUid: "biz", Status: database.BusinessStatusActive, ApiKeyHash: apikey.Hash(activeKey)},
+			{BusinessUid: "gone", Status: database.BusinessStatusDeregistered, ApiKeyHash: apikey.Hash(deregisterKey)},
+		}}},
+	}
+	exportMethod := dal_wallet_go.BusinessMiddleWireServices_ExportAddressesByPublicKeys_FullMethodName
+
+	tests := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		req      interface{}
+		code     codes.Code
+		business string
+	}{
+		{
+			name:   "missing api key",
+			method: exportMethod,
+			req:    &dal_wallet_go.ExportAddressesRequest{RequestId: "biz"},
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:    "unknown api key",
+			method:  exportMethod,
+			headers: map[string]string{ApiKeyHeader: "unknown-key"},
+			req:     &dal_wallet_go.ExportAddressesRequest{RequestId: "biz"},
+			code:    codes.Unauthenticated,
+		},
+		{
+			name:    "deregistered business",
+			method:  exportMethod,
+			headers: map[string]string{ApiKeyHeader: deregisterKey},
+			req:     &dal_wallet_go.ExportAddressesRequest{RequestId: "gone"},
+			code:    codes.Unauthenticated,
+		},
+		{
+			name:    "request_id mismatch",
+			method:  exportMethod,
+			headers: map[string]string{ApiKeyHeader: activeKey},
+			req:     &dal_wallet_go.ExportAddressesRequest{RequestId: "other"},
+			code:    codes.PermissionDenied,
+		},
+		{
+			name:     "api key in header",
+			method:   exportMethod,
+			headers:  map[string]string{ApiKeyHeader: activeKey},
+			req:      &dal_wallet_go.ExportAddressesRequest{RequestId: "biz"},
+			code:     codes.OK,
+			business: "biz",
+		},
+		{
+			name:     "api key in consumer_token",
+			method:   exportMethod,
+			req:      &dal_wallet_go.ExportAddressesRequest{RequestId: "biz", ConsumerToken: activeKey},
+			code:     codes.OK,
+			business: "biz",
+		},
+		{
+			name:    "admin method without admin token",
+			method:  dal_wallet_go.BusinessMiddleWireServices_BusinessRegister_FullMethodName,
+			headers: map[string]string{ApiKeyHeader: activeKey},
+			req:     &dal_wallet_go.BusinessRegisterRequest{RequestId: "biz"},
+			code:    codes.PermissionDenied,
+		},
+		{
+			name:    "admin method with wrong admin token",
+			method:  dal_wallet_go.BusinessMiddleWireServices_DeregisterBusiness_FullMethodName,
+			headers: map[string]string{AdminTokenHeader: "wrong"},
+			req:     &dal_wallet_go.DeregisterBusinessRequest{RequestId: "biz"},
+			code:    codes.PermissionDenied,
+		},
+		{
+			name:    "admin method with admin token",
+			method:  dal_wallet_go.BusinessMiddleWireServices_BusinessRegister_FullMethodName,
+			headers: map[string]string{AdminTokenHeader: adminToken},
+			req:     &dal_wallet_go.BusinessRegisterRequest{RequestId: "new"},
+			code:    codes.OK,
+		},
+		{
+			name:    "admin rotates api key",
+			method:  dal_wallet_go.BusinessMiddleWireServices_RotateApiKey_FullMethodName,
+			headers: map[string]string{AdminTokenHeader: adminToken},
+			req:     &dal_wallet_go.RotateApiKeyRequest{RequestId: "biz"},
+			code:    codes.OK,
+		},
+		{
+			name:    "rotate api key without api key",
+			method:  dal_wallet_go.BusinessMiddleWireServices_RotateApiKey_FullMethodName,
+			headers: map[string]string{AdminTokenHeader: "wrong"},
+			req:     &dal_wallet_go.RotateApiKeyRequest{RequestId: "biz"},
+			code:    codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(tt.headers))
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				business, ok := AuthBusinessFromContext(ctx)
+				if tt.business == "" {
+					require.False(t, ok)
+				} else {
+					require.True(t, ok)
+					require.Equal(t, tt.business, business.BusinessUid)
+				}
+				return nil, nil
+			}
+			_, err := bws.authUnaryInterceptor(ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.code, status.Code(err))
+			require.Equal(t, tt.code == codes.OK, called)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// walletRoles 地址类型对应的钱包角色，0:用户地址；1:热钱包地址；2:冷钱包地址
+var walletRoles = map[uint8]string{
+	0: "user",
+	1: "hot",
+	2: "cold",
+}
+
+// GetAddressBalance 查询地址的已确认、待确认和被占用余额，余额由未花费 utxo 汇总
+func (bws *BusinessMiddleWireServices) GetAddressBalance(ctx context.Context, request *dal_wallet_go.AddressBalanceRequest) (*dal_wallet_go.AddressBalanceResponse, error) {
+	resp := &dal_wallet_go.AddressBalanceResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "get address balance fail",
+	}
+	if request.Address == "" {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+	reader := bws.db.Reader()
+	exist, addressType := reader.Addresses.AddressExist(request.RequestId, request.Address)
+	if !exist {
+		resp.Msg = "address not exist"
+		return resp, nil
+	}
+	chainTip, confirmedHeight, err := bws.queryConfirmedHeight(reader, request.RequestId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "business not exist"
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	balance, err := reader.Utxos.QueryAddressUtxoBalance(request.RequestId, request.Address, confirmedHeight)
+	if err != nil {
+		log.Error("query address balance fail", "address", request.Address, "err", err)
+		return nil, err
+	}
+	resp.Address = request.Address
+	resp.AddressType = uint32(addressType)
+	resp.Confirmed = balance.Confirmed.String()
+	resp.Pending = balance.Pending.String()
+	resp.Locked = balance.Locked.String()
+	resp.UtxoCount = balance.UtxoCount
+	resp.ChainTip = chainTip
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "get address balance success"
+	return resp, nil
+}
+
+// ListUtxos 分页查询地址的 utxo，默认只返回未花费的
+func (bws *BusinessMiddleWireServices) ListUtxos(ctx context.Context, request *dal_wallet_go.ListUtxosRequest) (*dal_wallet_go.ListUtxosResponse, error) {
+	resp := &dal_wallet_go.ListUtxosResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "list utxos fail",
+	}
+	if request.Address == "" {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+	reader := bws.db.Reader()
+	chainTip, err := latestHeight(reader)
+	if err != nil {
+		return nil, err
+	}
+	query := database.TxQuery{Cursor: request.Cursor, Limit: int(request.Limit)}
+	utxoList, nextCursor, err := reader.Utxos.QueryUtxosPage(request.RequestId, request.Address, request.IncludeSpent, query)
+	if errors.Is(err, database.ErrInvalidCursor) {
+		resp.Msg = "invalid cursor"
+		return resp, nil
+	} else if err != nil {
+		log.Error("query utxos fail", "address", request.Address, "err", err)
+		return nil, err
+	}
+	for _, utxo := range utxoList {
+		var confirms uint64
+		if utxo.CreatedHeight != nil && utxo.CreatedHeight.Sign() > 0 && chainTip >= utxo.CreatedHeight.Uint64() {
+			confirms = chainTip - utxo.CreatedHeight.Uint64() + 1
+		}
+		resp.Utxos = append(resp.Utxos, &dal_wallet_go.UtxoRecord{
+			TxId:          utxo.TxId,
+			VoutIndex:     utxo.VoutIndex,
+			Address:       utxo.Address,
+			AddressType:   uint32(utxo.AddressType),
+			Amount:        bigIntString(utxo.Amount),
+			Script:        utxo.Script,
+			BlockHash:     utxo.BlockHash,
+			CreatedHeight: bigIntString(utxo.CreatedHeight),
+			Confirms:      confirms,
+			IsSpent:       utxo.IsSpent,
+			SpendTxHash:   utxo.SpendTxHash,
+			ReservedBy:    utxo.ReservedBy,
+			Timestamp:     utxo.Timestamp,
+		})
+	}
+	resp.NextCursor = nextCursor
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "list utxos success"
+	return resp, nil
+}
+
+// GetWalletBalances 按钱包角色（用户、热钱包、冷钱包）汇总业务方的余额，没有 utxo 的角色返回 0
+func (bws *BusinessMiddleWireServices) GetWalletBalances(ctx context.Context, request *dal_wallet_go.WalletBalancesRequest) (*dal_wallet_go.WalletBalancesResponse, error) {
+	resp := &dal_wallet_go.WalletBalancesResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "get wallet balances fail",
+	}
+	reader := bws.db.Reader()
+	chainTip, confirmedHeight, err := bws.queryConfirmedHeight(reader, request.RequestId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "business not exist"
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	balanceList, err := reader.Utxos.QueryUtxoBalancesByAddressType(request.RequestId, confirmedHeight)
+	if err != nil {
+		log.Error("query wallet balances fail", "requestId", request.RequestId, "err", err)
+		return nil, err
+	}
+	balanceMap := make(map[uint8]database.UtxoBalance, len(balanceList))
+	for _, balance := range balanceList {
+		balanceMap[balance.AddressType] = balance
+	}
+	for addressType := uint8(0); addressType < uint8(len(walletRoles)); addressType++ {
+		walletBalance := &dal_wallet_go.WalletBalance{
+			AddressType: uint32(addressType),
+			Role:        walletRoles[addressType],
+			Confirmed:   "0",
+			Pending:     "0",
+			Locked:      "0",
+		}
+		if balance, ok := balanceMap[addressType]; ok {
+			walletBalance.Confirmed = balance.Confirmed.String()
+			walletBalance.Pending = balance.Pending.String()
+			walletBalance.Locked = balance.Locked.String()
+			walletBalance.UtxoCount = balance.UtxoCount
+		}
+		resp.Balances = append(resp.Balances, walletBalance)
+	}
+	resp.ChainTip = chainTip
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "get wallet balances success"
+	return resp, nil
+}
+
+// latestHeight 已经同步到的最新高度，还没有同步区块时为 0
+func latestHeight(reader *database.ViewDB) (uint64, error) {
+	latestBlock, err := reader.Blocks.LatestBlocks()
+	if err != nil {
+		log.Error("query latest block fail", "err", err)
+		return 0, err
+	}
+	if latestBlock == nil || latestBlock.Number == nil {
+		return 0, nil
+	}
+	return latestBlock.Number.Uint64(), nil
+}
+
+// queryConfirmedHeight 返回已同步的最新高度和确认数达到业务方 finalized 阈值的最高区块，业务方没有配置时使用全局配置
+func (bws *BusinessMiddleWireServices) queryConfirmedHeight(reader *database.ViewDB, requestId string) (uint64, uint64, error) {
+	business, err := reader.Business.QueryBusinessByUuid(requestId)
+	if err != nil {
+		return 0, 0, err
+	}
+	chainTip, err := latestHeight(reader)
+	if err != nil {
+		return 0, 0, err
+	}
+	finalizedConfirms := bws.FinalizedConfirms
+	if business.FinalizedConfirms > 0 {
+		finalizedConfirms = business.FinalizedConfirms
+	}
+	return chainTip, confirmedHeight(chainTip, finalizedConfirms, bws.ScannerLag), nil
+}
+
+// confirmedHeight 扫块落后链上最新高度 lag 个区块，已同步的最新区块 storedTip 已经有 lag+1 个确认，
+// 区块 h 的确认数为 storedTip+lag+1-h，达到 finalizedConfirms 的最高区块为 storedTip - max(0, finalizedConfirms-lag-1)
+func confirmedHeight(storedTip uint64, finalizedConfirms uint64, lag uint64) uint64 {
+	if finalizedConfirms <= lag+1 {
+		return storedTip
+	}
+	depth := finalizedConfirms - lag - 1
+	if storedTip < depth {
+		return 0
+	}
+	return storedTip - depth
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmedHeight(t *testing.T) {
+	tests := []struct {
+		name              string
+		storedTip         uint64
+		finalizedConfirms uint64
+		lag               uint64
+		want              uint64
+	}{
+		{"no threshold", 100, 0, 0, 100},
+		{"no lag", 100, 6, 0, 95},
+		{"single confirmation", 100, 1, 0, 100},
+		// 已同步的最新区块已经有 lag+1 个确认，只需要再往回退 finalized-lag-1 个区块
+		{"lag below threshold", 100, 6, 2, 97},
+		{"lag covers threshold", 100, 6, 5, 100},
+		{"lag above threshold", 100, 6, 10, 100},
+		{"chain shorter than threshold", 3, 10, 2, 0},
+		{"chain equals depth", 7, 10, 2, 0},
+		{"empty chain", 0, 6, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, confirmedHeight(tt.storedTip, tt.finalizedConfirms, tt.lag))
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/apikey"
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/database/dynamic"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// UpdateBusiness 修改业务方的通知地址和回调地址，通知流程下一轮使用新的地址
+func (bws *BusinessMiddleWireServices) UpdateBusiness(ctx context.Context, request *dal_wallet_go.UpdateBusinessRequest) (*dal_wallet_go.UpdateBusinessResponse, error) {
+	resp := &dal_wallet_go.UpdateBusinessResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "update business fail",
+	}
+	if request.RequestId == "" || !validUrl(request.NotifyUrl) || (request.CallBackUrl != "" && !validUrl(request.CallBackUrl)) {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+	err := bws.db.Business.UpdateBusinessUrls(request.RequestId, request.NotifyUrl, request.CallBackUrl)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "business not exist"
+		return resp, nil
+	} else if err != nil {
+		log.Error("update business fail", "err", err)
+		return nil, err
+	}
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "update business success"
+	return resp, nil
+}
+
+// SuspendBusiness 暂停业务方，通知、提现和手续费追加会跳过该业务方；扫块和内存池照常入库，恢复之后补发通知
+func (bws *BusinessMiddleWireServices) SuspendBusiness(ctx context.Context, request *dal_wallet_go.BusinessStatusRequest) (*dal_wallet_go.BusinessStatusResponse, error) {
+	return bws.changeBusinessStatus(request, database.BusinessStatusActive, database.BusinessStatusSuspended)
+}
+
+func (bws *BusinessMiddleWireServices) ResumeBusiness(ctx context.Context, request *dal_wallet_go.BusinessStatusRequest) (*dal_wallet_go.BusinessStatusResponse, error) {
+	return bws.changeBusinessStatus(request, database.BusinessStatusSuspended, database.BusinessStatusActive)
+}
+
+func (bws *BusinessMiddleWireServices) changeBusinessStatus(request *dal_wallet_go.BusinessStatusRequest, from string, to string) (*dal_wallet_go.BusinessStatusResponse, error) {
+	resp := &dal_wallet_go.BusinessStatusResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "change business status fail",
+	}
+	business, err := bws.db.Business.QueryBusinessByUuid(request.RequestId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "business not exist"
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	resp.Status = business.Status
+	if business.Status != from {
+		resp.Msg = "business is " + business.Status
+		return resp, nil
+	}
+	if err := bws.db.Business.UpdateBusinessStatus(request.RequestId, to); err != nil {
+		log.Error("update business status fail", "err", err)
+		return nil, err
+	}
+	if to == database.BusinessStatusSuspended {
+		cache.RemoveBusiness(request.RequestId)
+	}
+	log.Info("business status changed", "requestId", request.RequestId, "from", from, "to", to)
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "business is " + to
+	resp.Status = to
+	return resp, nil
+}
+
+// DeregisterBusiness 注销业务方，默认把业务方的表归档到 archived schema，drop_tables 为 true 时直接删除
+func (bws *BusinessMiddleWireServices) DeregisterBusiness(ctx context.Context, request *dal_wallet_go.DeregisterBusinessRequest) (*dal_wallet_go.DeregisterBusinessResponse, error) {
+	resp := &dal_wallet_go.DeregisterBusinessResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "deregister business fail",
+	}
+	if _, err := bws.db.Business.QueryBusinessByUuid(request.RequestId); errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "business not exist"
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err := bws.db.Transaction(func(tx *database.DB) error {
+		if err := tx.Business.UpdateBusinessStatus(request.RequestId, database.BusinessStatusDeregistered); err != nil {
+			return err
+		}
+		archiveTag := strconv.FormatInt(time.Now().Unix(), 10)
+		for _, schema := range bws.tenantSchemas() {
+			err := tx.InSchema(schema, func(chainTx *database.DB) error {
+				if request.DropTables {
+					return dynamic.DropTenantTables(request.RequestId, chainTx)
+				}
+				// 所有链归档到同一个 archived schema，其他链的表名带上链的 schema 避免重名
+				if schema != "" {
+					return dynamic.ArchiveTenantTables(request.RequestId, archiveTag+"_"+schema, chainTx)
+				}
+				return dynamic.ArchiveTenantTables(request.RequestId, archiveTag, chainTx)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("deregister business fail", "requestId", request.RequestId, "err", err)
+		return nil, err
+	}
+	cache.RemoveBusiness(request.RequestId)
+	log.Info("business deregistered", "requestId", request.RequestId, "dropTables", request.DropTables)
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "deregister business success"
+	return resp, nil
+}
+
+// tenantSchemas 业务方的表所在的所有 schema，只有一条链时就是当前连接的 schema
+func (bws *BusinessMiddleWireServices) tenantSchemas() []string {
+	if len(bws.TenantSchemas) == 0 {
+		return []string{bws.db.Schema()}
+	}
+	return bws.TenantSchemas
+}
+
+// RotateApiKey 给业务方重新签发 api key，旧的 api key 立即失效
+func (bws *BusinessMiddleWireServices) RotateApiKey(ctx context.Context, request *dal_wallet_go.RotateApiKeyRequest) (*dal_wallet_go.RotateApiKeyResponse, error) {
+	resp := &dal_wallet_go.RotateApiKeyResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "rotate api key fail",
+	}
+	apiKey, apiKeyHash, err := apikey.Generate()
+	if err != nil {
+		log.Error("generate api key fail", "err", err)
+		return nil, err
+	}
+	err = bws.db.Business.UpdateBusinessApiKeyHash(request.RequestId, apiKeyHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "business not exist"
+		return resp, nil
+	} else if err != nil {
+		log.Error("update api key fail", "err", err)
+		return nil, err
+	}
+	log.Info("api key rotated", "requestId", request.RequestId)
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "rotate api key success"
+	resp.ApiKey = apiKey
+	return resp, nil
+}
+
+func validUrl(rawUrl string) bool {
+	parsed, err := url.Parse(rawUrl)
+	return err == nil && parsed.Scheme != "" && parsed.Host != ""
+}
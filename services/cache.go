@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// cacheRoute 查询接口对应的缓存类别，detail 为 true 时使用详情缓存的容量和过期时间
+type cacheRoute struct {
+	kind   string
+	detail bool
+}
+
+var cacheRoutes = map[string]cacheRoute{
+	dal_wallet_go.BusinessMiddleWireServices_QueryDeposits_FullMethodName:     {kind: database.ApiCacheDeposits},
+	dal_wallet_go.BusinessMiddleWireServices_QueryWithdraws_FullMethodName:    {kind: database.ApiCacheWithdraws},
+	dal_wallet_go.BusinessMiddleWireServices_QueryInternals_FullMethodName:    {kind: database.ApiCacheInternals},
+	dal_wallet_go.BusinessMiddleWireServices_QueryTransactions_FullMethodName: {kind: database.ApiCacheTransactions},
+	dal_wallet_go.BusinessMiddleWireServices_QueryChildTxs_FullMethodName:     {kind: database.ApiCacheTransactions},
+	dal_wallet_go.BusinessMiddleWireServices_GetTransaction_FullMethodName:    {kind: database.ApiCacheTransactions, detail: true},
+	dal_wallet_go.BusinessMiddleWireServices_ListUtxos_FullMethodName:         {kind: database.ApiCacheBalances},
+	dal_wallet_go.BusinessMiddleWireServices_GetAddressBalance_FullMethodName: {kind: database.ApiCacheBalances, detail: true},
+	dal_wallet_go.BusinessMiddleWireServices_GetWalletBalances_FullMethodName: {kind: database.ApiCacheBalances, detail: true},
+}
+
+func newCacheEntries(apiCache *cache.ApiCache) map[string]cache.Entry[proto.Message] {
+	entries := make(map[string]cache.Entry[proto.Message], len(cacheRoutes))
+	for method, route := range cacheRoutes {
+		if route.detail {
+			entries[method] = cache.DetailEntry[proto.Message](apiCache, route.kind)
+		} else {
+			entries[method] = cache.ListEntry[proto.Message](apiCache, route.kind)
+		}
+	}
+	return entries
+}
+
+// cacheUnaryInterceptor 缓存查询接口成功的响应，放在鉴权之后，按鉴权得到的业务方隔离缓存。
+// 开启从库时，失效通知可能先于从库回放到达：从库没有追平主库，或者失效之后还在从库最大延迟内时，查询结果不写缓存
+func (bws *BusinessMiddleWireServices) cacheUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	entry, ok := bws.cacheEntries[info.FullMethod]
+	if !ok || bws.apiCache == nil {
+		return handler(ctx, req)
+	}
+	business, ok := AuthBusinessFromContext(ctx)
+	request, isMessage := req.(proto.Message)
+	if !ok || !isMessage {
+		return handler(ctx, req)
+	}
+	requestKey, err := requestCacheKey(request)
+	if err != nil {
+		log.Warn("build cache key fail", "method", info.FullMethod, "err", err)
+		return handler(ctx, req)
+	}
+	// 查询之前生成 key，查询期间发生的失效不会让旧数据被后续请求读到；不同链的同一个请求分开缓存
+	cacheKey := entry.Key(business.BusinessUid, chainFromContext(ctx, bws).Chain.Key+"\x00"+requestKey)
+	if cached, ok := entry.Get(cacheKey); ok {
+		return cached, nil
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	if result, ok := resp.(interface {
+		proto.Message
+		GetCode() dal_wallet_go.ReturnCode
+	}); ok && result.GetCode() == dal_wallet_go.ReturnCode_SUCCESS && entry.Cacheable(business.BusinessUid) && !bws.db.ReplicaLagging() {
+		entry.Set(cacheKey, result)
+	}
+	return resp, nil
+}
+
+// requestCacheKey 请求去掉 consumer_token 之后的确定性序列化结果
+func requestCacheKey(request proto.Message) (string, error) {
+	keyRequest := proto.Clone(request)
+	if field := keyRequest.ProtoReflect().Descriptor().Fields().ByName("consumer_token"); field != nil {
+		keyRequest.ProtoReflect().Clear(field)
+	}
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(keyRequest)
+	if err != nil {
+		return "", err
+	}
+	return string(key), nil
+}
+
+// listenCacheInvalidation 接收扫块、通知等进程写库后发出的失效通知，直到 ctx 取消
+func (bws *BusinessMiddleWireServices) listenCacheInvalidation(ctx context.Context) {
+	defer close(bws.cacheDone)
+	err := bws.db.ListenApiCacheInvalidate(ctx, func(businessId string, kinds []string) {
+		bws.apiCache.Invalidate(businessId, kinds...)
+	})
+	if err != nil {
+		log.Error("listen api cache invalidation fail", "err", err)
+	}
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// ChainHeader 请求操作的链，btc、ltc 等链的配置名，不传时使用主链
+const ChainHeader = "x-chain"
+
+type chainKey struct{}
+
+// AddChain 主链的服务挂上另一条链的服务，grpc 服务只由主链监听，按 x-chain 分发到对应链
+func (bws *BusinessMiddleWireServices) AddChain(chain *BusinessMiddleWireServices) {
+	bws.chains[chain.Chain.Key] = chain
+}
+
+// chainUnaryInterceptor 按 x-chain 选出处理请求的链，未知的链直接拒绝
+func (bws *BusinessMiddleWireServices) chainUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	chain := bws
+	if name := metadataValue(ctx, ChainHeader); name != "" {
+		served, ok := bws.chains[strings.ToLower(name)]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "chain %q is not served", name)
+		}
+		chain = served
+	}
+	if ctxInfo, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		ctxInfo.Chain = chain.Chain.Key
+	}
+	return handler(context.WithValue(ctx, chainKey{}, chain), req)
+}
+
+// chainFromContext 返回请求所在链的服务，没有经过 chainUnaryInterceptor 时返回 fallback
+func chainFromContext(ctx context.Context, fallback *BusinessMiddleWireServices) *BusinessMiddleWireServices {
+	if chain, ok := ctx.Value(chainKey{}).(*BusinessMiddleWireServices); ok {
+		return chain
+	}
+	return fallback
+}
+
+// chainRouter 注册到 grpc 上的服务，业务方管理接口操作共用的业务方表，始终由主链处理；其余接口分发到请求所在的链
+type chainRouter struct {
+	primary *BusinessMiddleWireServices
+}
+
+var _ dal_wallet_go.BusinessMiddleWireServicesServer = (*chainRouter)(nil)
+
+func (r *chainRouter) chain(ctx context.Context) *BusinessMiddleWireServices {
+	return chainFromContext(ctx, r.primary)
+}
+
+func (r *chainRouter) BusinessRegister(ctx context.Context, request *dal_wallet_go.BusinessRegisterRequest) (*dal_wallet_go.BusinessRegisterResponse, error) {
+	return r.primary.BusinessRegister(ctx, request)
+}
+
+func (r *chainRouter) UpdateBusiness(ctx context.Context, request *dal_wallet_go.UpdateBusinessRequest) (*dal_wallet_go.UpdateBusinessResponse, error) {
+	return r.primary.UpdateBusiness(ctx, request)
+}
+
+func (r *chainRouter) SuspendBusiness(ctx context.Context, request *dal_wallet_go.BusinessStatusRequest) (*dal_wallet_go.BusinessStatusResponse, error) {
+	return r.primary.SuspendBusiness(ctx, request)
+}
+
+func (r *chainRouter) ResumeBusiness(ctx context.Context, request *dal_wallet_go.BusinessStatusRequest) (*dal_wallet_go.BusinessStatusResponse, error) {
+	return r.primary.ResumeBusiness(ctx, request)
+}
+
+func (r *chainRouter) DeregisterBusiness(ctx context.Context, request *dal_wallet_go.DeregisterBusinessRequest) (*dal_wallet_go.DeregisterBusinessResponse, error) {
+	return r.primary.DeregisterBusiness(ctx, request)
+}
+
+func (r *chainRouter) RotateApiKey(ctx context.Context, request *dal_wallet_go.RotateApiKeyRequest) (*dal_wallet_go.RotateApiKeyResponse, error) {
+	return r.primary.RotateApiKey(ctx, request)
+}
+
+func (r *chainRouter) ExportAddressesByPublicKeys(ctx context.Context, request *dal_wallet_go.ExportAddressesRequest) (*dal_wallet_go.ExportAddressesResponse, error) {
+	return r.chain(ctx).ExportAddressesByPublicKeys(ctx, request)
+}
+
+func (r *chainRouter) BuildUnSignTransaction(ctx context.Context, request *dal_wallet_go.UnSignWithdrawTransactionRequest) (*dal_wallet_go.UnSignWithdrawTransactionResponse, error) {
+	return r.chain(ctx).BuildUnSignTransaction(ctx, request)
+}
+
+func (r *chainRouter) BuildSignedTransaction(ctx context.Context, request *dal_wallet_go.SignedWithdrawTransactionRequest) (*dal_wallet_go.SignedWithdrawTransactionResponse, error) {
+	return r.chain(ctx).BuildSignedTransaction(ctx, request)
+}
+
+func (r *chainRouter) BuildCpfpTransaction(ctx context.Context, request *dal_wallet_go.CpfpTransactionRequest) (*dal_wallet_go.CpfpTransactionResponse, error) {
+	return r.chain(ctx).BuildCpfpTransaction(ctx, request)
+}
+
+func (r *chainRouter) SubmitWithdraw(ctx context.Context, request *dal_wallet_go.SubmitWithdrawRequest) (*dal_wallet_go.SubmitWithdrawResponse, error) {
+	return r.chain(ctx).SubmitWithdraw(ctx, request)
+}
+
+func (r *chainRouter) QueryDeposits(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return r.chain(ctx).QueryDeposits(ctx, request)
+}
+
+func (r *chainRouter) QueryWithdraws(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return r.chain(ctx).QueryWithdraws(ctx, request)
+}
+
+func (r *chainRouter) QueryInternals(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return r.chain(ctx).QueryInternals(ctx, request)
+}
+
+func (r *chainRouter) QueryTransactions(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return r.chain(ctx).QueryTransactions(ctx, request)
+}
+
+func (r *chainRouter) QueryChildTxs(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryChildTxsResponse, error) {
+	return r.chain(ctx).QueryChildTxs(ctx, request)
+}
+
+func (r *chainRouter) GetTransaction(ctx context.Context, request *dal_wallet_go.GetTransactionRequest) (*dal_wallet_go.GetTransactionResponse, error) {
+	return r.chain(ctx).GetTransaction(ctx, request)
+}
+
+func (r *chainRouter) GetAddressBalance(ctx context.Context, request *dal_wallet_go.AddressBalanceRequest) (*dal_wallet_go.AddressBalanceResponse, error) {
+	return r.chain(ctx).GetAddressBalance(ctx, request)
+}
+
+func (r *chainRouter) ListUtxos(ctx context.Context, request *dal_wallet_go.ListUtxosRequest) (*dal_wallet_go.ListUtxosResponse, error) {
+	return r.chain(ctx).ListUtxos(ctx, request)
+}
+
+func (r *chainRouter) GetWalletBalances(ctx context.Context, request *dal_wallet_go.WalletBalancesRequest) (*dal_wallet_go.WalletBalancesResponse, error) {
+	return r.chain(ctx).GetWalletBalances(ctx, request)
+}
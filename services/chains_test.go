@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// chainBusinessDB 记录业务方查询落在哪条链的连接上
+type chainBusinessDB struct {
+	database.BusinessDB
+	chain   string
+	queried *[]string
+}
+
+func (db *chainBusinessDB) QueryBusinessByUuid(string) (*database.Business, error) {
+	*db.queried = append(*db.queried, db.chain)
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestChainRouter(t *testing.T) {
+	var queried []string
+	newChain := func(key string) *BusinessMiddleWireServices {
+		profile, err := config.LookupChainProfile(key, "mainnet")
+		require.NoError(t, err)
+		return &BusinessMiddleWireServices{
+			BusinessMiddleConfig: &BusinessMiddleConfig{Chain: profile},
+			db:                   &database.DB{Business: &chainBusinessDB{chain: key, queried: &queried}},
+			chains:               make(map[string]*BusinessMiddleWireServices),
+		}
+	}
+	primary := newChain("btc")
+	primary.chains["btc"] = primary
+	primary.AddChain(newChain("doge"))
+	router := &chainRouter{primary: primary}
+
+	// 经过 chainUnaryInterceptor 选出链后交给 router 处理
+	call := func(chain string, handler func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+		ctx := context.Background()
+		if chain != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ChainHeader, chain))
+		}
+		return primary.chainUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return handler(ctx)
+		})
+	}
+	// 地址格式按处理请求的链校验，错误信息里带着链名
+	export := func(ctx context.Context) (interface{}, error) {
+		return router.ExportAddressesByPublicKeys(ctx, &dal_wallet_go.ExportAddressesRequest{
+			PublicKeys: []*dal_wallet_go.PublicKey{{Format: "cashaddr"}},
+		})
+	}
+
+	tests := []struct {
+		name  string
+		chain string
+		msg   string
+	}{
+		{name: "no header uses primary", chain: "", msg: "Bitcoin"},
+		{name: "selected chain", chain: "doge", msg: "Dogecoin"},
+		{name: "header is case insensitive", chain: "DOGE", msg: "Dogecoin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := call(tt.chain, export)
+			require.NoError(t, err)
+			out := resp.(*dal_wallet_go.ExportAddressesResponse)
+			require.Equal(t, dal_wallet_go.ReturnCode_ERROR, out.Code)
+			require.Contains(t, out.Msg, "not supported on "+tt.msg+",")
+		})
+	}
+
+	_, err := call("eth", export)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// 业务方管理接口操作共用的业务方表，不管请求哪条链都由主链处理
+	resp, err := call("doge", func(ctx context.Context) (interface{}, error) {
+		return router.SuspendBusiness(ctx, &dal_wallet_go.BusinessStatusRequest{RequestId: "biz"})
+	})
+	require.NoError(t, err)
+	require.Equal(t, "business not exist", resp.(*dal_wallet_go.BusinessStatusResponse).Msg)
+	require.Equal(t, []string{"btc"}, queried)
+}
@@ -0,0 +1,87 @@
+package coinselect
+
+const defaultBnbMaxTries = 100_000
+
+// branchAndBound 深度优先搜索一组 utxo，使有效金额落在 [目标, 目标+找零成本] 之间，
+// 这样不需要找零输出，多出来的零头直接作为手续费，比多建一个找零输出更便宜
+type branchAndBound struct {
+	maxTries int
+}
+
+func (s *branchAndBound) Name() string {
+	return BranchAndBound
+}
+
+func (s *branchAndBound) Select(req *Request) (*Result, error) {
+	utxos := req.candidates()
+	sortByAmountDesc(utxos)
+
+	target := req.Target + req.BaseVSize*req.FeeRate
+	// 找零成本包括找零输出本身和以后花费它的输入
+	upper := target + req.changeFee() + req.inputFee()
+
+	var remaining int64
+	values := make([]int64, len(utxos))
+	for i, utxo := range utxos {
+		values[i] = req.effectiveValue(utxo)
+		remaining += values[i]
+	}
+	if remaining < target {
+		return nil, ErrInsufficientFunds
+	}
+
+	var (
+		tries     int
+		current   []int
+		best      []int
+		bestWaste int64 = -1
+	)
+	var search func(depth int, value int64, remaining int64)
+	search = func(depth int, value int64, remaining int64) {
+		tries++
+		if tries > s.maxTries || value > upper || value+remaining < target {
+			return
+		}
+		if value >= target {
+			if waste := value - target; bestWaste < 0 || waste < bestWaste {
+				bestWaste = waste
+				best = append(best[:0], current...)
+			}
+			return
+		}
+		if depth == len(utxos) {
+			return
+		}
+		current = append(current, depth)
+		search(depth+1, value+values[depth], remaining-values[depth])
+		current = current[:len(current)-1]
+		if bestWaste == 0 {
+			return
+		}
+		// 排除当前 utxo 时，后面金额相同的 utxo 也一起排除，包含它们的组合和上面的分支是等价的
+		remaining -= values[depth]
+		next := depth + 1
+		for next < len(utxos) && values[next] == values[depth] {
+			remaining -= values[next]
+			next++
+		}
+		search(next, value, remaining)
+	}
+	search(0, 0, remaining)
+
+	if best == nil {
+		return nil, ErrNoExactMatch
+	}
+	selected := make([]Utxo, 0, len(best))
+	for _, index := range best {
+		selected = append(selected, utxos[index])
+	}
+	result, err := req.finalize(selected)
+	if err != nil {
+		return nil, err
+	}
+	// 命中区间时找零不值得建，统一并入手续费
+	result.Fee += result.Change
+	result.Change = 0
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package coinselect
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	LargestFirst   = "largest_first"
+	BranchAndBound = "branch_and_bound"
+	Knapsack       = "knapsack"
+
+	// DefaultDustThreshold 低于这个金额的找零输出不上链，直接并入手续费
+	DefaultDustThreshold int64 = 546
+)
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds for withdraw amount and fee")
+	ErrNoExactMatch      = errors.New("no utxo combination matches the target without change")
+	ErrUnknownStrategy   = errors.New("unknown coin selection strategy")
+)
+
+type Utxo struct {
+	TxId    string
+	Index   uint32
+	Address string
+	Amount  int64
+}
+
+// Request 选币参数，金额单位是聪，大小单位是 vbyte，FeeRate 单位是 sat/vbyte
+type Request struct {
+	Utxos         []Utxo
+	Target        int64 // 所有提现输出金额之和
+	FeeRate       int64
+	BaseVSize     int64 // 不含输入和找零输出的交易大小（版本号、locktime、提现输出）
+	InputVSize    int64
+	ChangeVSize   int64
+	DustThreshold int64
+}
+
+type Result struct {
+	Selected    []Utxo
+	InputAmount int64
+	Fee         int64
+	Change      int64 // 0 表示没有找零输出
+}
+
+type Strategy interface {
+	Name() string
+	Select(req *Request) (*Result, error)
+}
+
+// NewStrategy 按名字创建选币策略，名字为空时使用 largest_first
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case "", LargestFirst:
+		return &largestFirst{}, nil
+	case BranchAndBound:
+		return &branchAndBound{maxTries: defaultBnbMaxTries}, nil
+	case Knapsack:
+		return newKnapsack(nil), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStrategy, name)
+	}
+}
+
+func (req *Request) inputFee() int64 {
+	return req.InputVSize * req.FeeRate
+}
+
+func (req *Request) changeFee() int64 {
+	return req.ChangeVSize * req.FeeRate
+}
+
+func (req *Request) feeWithoutChange(inputs int) int64 {
+	return (req.BaseVSize + int64(inputs)*req.InputVSize) * req.FeeRate
+}
+
+func (req *Request) dustThreshold() int64 {
+	if req.DustThreshold > 0 {
+		return req.DustThreshold
+	}
+	return DefaultDustThreshold
+}
+
+func (req *Request) effectiveValue(utxo Utxo) int64 {
+	return utxo.Amount - req.inputFee()
+}
+
+// candidates 过滤掉花费成本不低于自身金额的 utxo，这类 utxo 放进交易只会亏手续费
+func (req *Request) candidates() []Utxo {
+	var utxos []Utxo
+	for _, utxo := range req.Utxos {
+		if req.effectiveValue(utxo) > 0 {
+			utxos = append(utxos, utxo)
+		}
+	}
+	return utxos
+}
+
+// finalize 根据选中的 utxo 计算手续费和找零，找零低于粉尘阈值时不建找零输出，差额并入手续费
+func (req *Request) finalize(selected []Utxo) (*Result, error) {
+	var inputAmount int64
+	for _, utxo := range selected {
+		inputAmount += utxo.Amount
+	}
+	feeWithoutChange := req.feeWithoutChange(len(selected))
+	feeWithChange := feeWithoutChange + req.changeFee()
+	if change := inputAmount - req.Target - feeWithChange; change >= req.dustThreshold() {
+		return &Result{Selected: selected, InputAmount: inputAmount, Fee: feeWithChange, Change: change}, nil
+	}
+	if inputAmount-req.Target >= feeWithoutChange {
+		return &Result{Selected: selected, InputAmount: inputAmount, Fee: inputAmount - req.Target}, nil
+	}
+	return nil, ErrInsufficientFunds
+}
+
+func sortByAmountDesc(utxos []Utxo) {
+	sort.SliceStable(utxos, func(i, j int) bool {
+		return utxos[i].Amount > utxos[j].Amount
+	})
+}
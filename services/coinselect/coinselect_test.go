@@ -0,0 +1,120 @@
+package coinselect
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRequest(target int64, amounts ...int64) *Request {
+	req := &Request{
+		Target:      target,
+		FeeRate:     1,
+		BaseVSize:   42,
+		InputVSize:  68,
+		ChangeVSize: 31,
+	}
+	for i, amount := range amounts {
+		req.Utxos = append(req.Utxos, Utxo{TxId: "tx", Index: uint32(i), Address: "hot", Amount: amount})
+	}
+	return req
+}
+
+func requireBalanced(t *testing.T, req *Request, result *Result) {
+	var inputAmount int64
+	for _, utxo := range result.Selected {
+		inputAmount += utxo.Amount
+	}
+	require.Equal(t, inputAmount, result.InputAmount)
+	require.Equal(t, result.InputAmount, req.Target+result.Fee+result.Change)
+	require.GreaterOrEqual(t, result.Fee, req.feeWithoutChange(len(result.Selected)))
+	if result.Change > 0 {
+		require.GreaterOrEqual(t, result.Change, req.dustThreshold())
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	for _, name := range []string{"", LargestFirst, BranchAndBound, Knapsack} {
+		strategy, err := NewStrategy(name)
+		require.NoError(t, err)
+		if name != "" {
+			require.Equal(t, name, strategy.Name())
+		}
+	}
+	_, err := NewStrategy("fifo")
+	require.ErrorIs(t, err, ErrUnknownStrategy)
+}
+
+func TestLargestFirst(t *testing.T) {
+	req := newRequest(150_000, 10_000, 100_000, 80_000, 300)
+	result, err := (&largestFirst{}).Select(req)
+	require.NoError(t, err)
+	require.Len(t, result.Selected, 2)
+	require.Equal(t, int64(100_000), result.Selected[0].Amount)
+	require.Equal(t, int64(80_000), result.Selected[1].Amount)
+	requireBalanced(t, req, result)
+	require.Positive(t, result.Change)
+}
+
+func TestLargestFirstDustChange(t *testing.T) {
+	// 找零只有 100 聪，低于粉尘阈值，并入手续费
+	req := newRequest(100_000, 100_000+42+68+31+100)
+	result, err := (&largestFirst{}).Select(req)
+	require.NoError(t, err)
+	require.Zero(t, result.Change)
+	require.Equal(t, int64(42+68+31+100), result.Fee)
+	requireBalanced(t, req, result)
+}
+
+func TestLargestFirstInsufficientFunds(t *testing.T) {
+	_, err := (&largestFirst{}).Select(newRequest(100_000, 50_000, 40_000))
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	// 花费成本不低于自身金额的 utxo 不会被选中
+	_, err = (&largestFirst{}).Select(newRequest(10, 60, 68))
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestBranchAndBoundExactMatch(t *testing.T) {
+	// 30_000 + 20_000 扣掉两个输入的成本正好覆盖目标，没有找零
+	target := int64(50_000 - 42 - 2*68)
+	req := newRequest(target, 70_000, 30_000, 45_000, 20_000)
+	result, err := (&branchAndBound{maxTries: defaultBnbMaxTries}).Select(req)
+	require.NoError(t, err)
+	require.Zero(t, result.Change)
+	require.Equal(t, int64(50_000), result.InputAmount)
+	requireBalanced(t, req, result)
+}
+
+func TestBranchAndBoundNoMatch(t *testing.T) {
+	req := newRequest(50_000, 100_000, 200_000)
+	_, err := (&branchAndBound{maxTries: defaultBnbMaxTries}).Select(req)
+	require.ErrorIs(t, err, ErrNoExactMatch)
+
+	_, err = (&branchAndBound{maxTries: defaultBnbMaxTries}).Select(newRequest(500_000, 100_000))
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestKnapsack(t *testing.T) {
+	amounts := []int64{5_000, 12_000, 7_500, 30_000, 18_000, 2_500, 9_000, 60_000}
+	for seed := int64(0); seed < 20; seed++ {
+		req := newRequest(40_000, amounts...)
+		result, err := newKnapsack(rand.New(rand.NewSource(seed))).Select(req)
+		require.NoError(t, err)
+		requireBalanced(t, req, result)
+	}
+
+	_, err := newKnapsack(rand.New(rand.NewSource(1))).Select(newRequest(1_000_000, amounts...))
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestKnapsackLowestLarger(t *testing.T) {
+	// 小额 utxo 加起来不够，使用比目标大的最小 utxo
+	req := newRequest(40_000, 1_000, 2_000, 90_000, 60_000)
+	result, err := newKnapsack(rand.New(rand.NewSource(1))).Select(req)
+	require.NoError(t, err)
+	require.Len(t, result.Selected, 1)
+	require.Equal(t, int64(60_000), result.Selected[0].Amount)
+	requireBalanced(t, req, result)
+}
@@ -0,0 +1,120 @@
+package coinselect
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+const knapsackIterations = 1000
+
+// knapsack 随机逼近最优子集（random improve），尽量让输入总额贴近 提现金额+手续费+最小找零，
+// 找不到更好的组合时退回到单个比目标大的最小 utxo
+type knapsack struct {
+	rand *rand.Rand
+}
+
+func newKnapsack(rng *rand.Rand) *knapsack {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return &knapsack{rand: rng}
+}
+
+func (s *knapsack) Name() string {
+	return Knapsack
+}
+
+func (s *knapsack) Select(req *Request) (*Result, error) {
+	utxos := req.candidates()
+	// 目标按有效金额计算，默认有找零输出
+	target := req.Target + req.BaseVSize*req.FeeRate + req.changeFee()
+	targetWithChange := target + req.dustThreshold()
+
+	var (
+		smaller      []Utxo
+		smallerTotal int64
+		lowestLarger *Utxo
+	)
+	for i := range utxos {
+		value := req.effectiveValue(utxos[i])
+		switch {
+		case value == target:
+			return req.finalize([]Utxo{utxos[i]})
+		case value < targetWithChange:
+			smaller = append(smaller, utxos[i])
+			smallerTotal += value
+		case lowestLarger == nil || value < req.effectiveValue(*lowestLarger):
+			lowestLarger = &utxos[i]
+		}
+	}
+
+	if smallerTotal == target {
+		return req.finalize(smaller)
+	}
+	if smallerTotal < target {
+		if lowestLarger == nil {
+			return nil, ErrInsufficientFunds
+		}
+		return req.finalize([]Utxo{*lowestLarger})
+	}
+
+	sort.SliceStable(smaller, func(i, j int) bool {
+		return smaller[i].Amount > smaller[j].Amount
+	})
+	values := make([]int64, len(smaller))
+	for i := range smaller {
+		values[i] = req.effectiveValue(smaller[i])
+	}
+	best, bestValue := s.approximateBestSubset(values, smallerTotal, target)
+	if bestValue != target && smallerTotal >= targetWithChange {
+		best, bestValue = s.approximateBestSubset(values, smallerTotal, targetWithChange)
+	}
+
+	// 单个更大的 utxo 更接近目标时优先用它，输入更少
+	if lowestLarger != nil && ((bestValue != target && bestValue < targetWithChange) || req.effectiveValue(*lowestLarger) <= bestValue) {
+		return req.finalize([]Utxo{*lowestLarger})
+	}
+	var selected []Utxo
+	for i, included := range best {
+		if included {
+			selected = append(selected, smaller[i])
+		}
+	}
+	return req.finalize(selected)
+}
+
+func (s *knapsack) approximateBestSubset(values []int64, total int64, target int64) ([]bool, int64) {
+	best := make([]bool, len(values))
+	for i := range best {
+		best[i] = true
+	}
+	bestValue := total
+	included := make([]bool, len(values))
+	for rep := 0; rep < knapsackIterations && bestValue != target; rep++ {
+		for i := range included {
+			included[i] = false
+		}
+		var value int64
+		reachedTarget := false
+		for pass := 0; pass < 2 && !reachedTarget; pass++ {
+			for i := range values {
+				// 第一轮随机挑选，第二轮只补第一轮没选的
+				if (pass == 0 && s.rand.Intn(2) == 1) || (pass == 1 && !included[i]) {
+					value += values[i]
+					included[i] = true
+					if value >= target {
+						reachedTarget = true
+						if value < bestValue {
+							bestValue = value
+							copy(best, included)
+						}
+						value -= values[i]
+						included[i] = false
+					}
+				}
+			}
+		}
+	}
+	return best, bestValue
+}
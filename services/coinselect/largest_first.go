@@ -0,0 +1,19 @@
+package coinselect
+
+// largestFirst 按金额从大到小选 utxo，直到覆盖提现金额和手续费，输入数最少
+type largestFirst struct{}
+
+func (s *largestFirst) Name() string {
+	return LargestFirst
+}
+
+func (s *largestFirst) Select(req *Request) (*Result, error) {
+	utxos := req.candidates()
+	sortByAmountDesc(utxos)
+	for i := range utxos {
+		if result, err := req.finalize(utxos[:i+1]); err == nil {
+			return result, nil
+		}
+	}
+	return nil, ErrInsufficientFunds
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/common"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+	"github.com/dapplink-labs/multichain-sync-btc/services/coinselect"
+	"github.com/dapplink-labs/multichain-sync-btc/services/feeestimator"
+)
+
+// BuildCpfpTransaction 花费一笔未确认交易中属于业务方的输出（充值或找零），必要时合并热钱包 utxo，
+// 把父子交易整体的费率提到目标费率；子交易记为 cpfp 类型的内部交易，签名和广播走内部交易的流程
+func (bws *BusinessMiddleWireServices) BuildCpfpTransaction(ctx context.Context, request *dal_wallet_go.CpfpTransactionRequest) (*dal_wallet_go.CpfpTransactionResponse, error) {
+	resp := &dal_wallet_go.CpfpTransactionResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "build cpfp transaction fail",
+	}
+	if request.ParentTxHash == "" || request.ParentFee < 0 || request.ParentVsize < 0 || request.TargetFeeRate < 0 {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+
+	business, err := bws.db.Business.QueryBusinessByUuid(request.RequestId)
+	if err != nil {
+		log.Error("query business fail", "err", err)
+		resp.Msg = "business not exist"
+		return resp, nil
+	}
+	if !business.Active() {
+		resp.Msg = "business is " + business.Status
+		return resp, nil
+	}
+	hotWalletInfo, err := bws.db.Addresses.QueryHotWalletInfo(request.RequestId)
+	if err != nil {
+		log.Error("query hot wallet info fail", "err", err)
+		return nil, err
+	}
+	if hotWalletInfo == nil {
+		resp.Msg = "hot wallet not exist"
+		return resp, nil
+	}
+
+	// 父交易上链之后输出会进入 utxo 集合，不需要再加速
+	confirmedUtxo, err := bws.db.Utxos.QueryUtxo(request.RequestId, request.ParentTxHash, request.ParentVoutIndex)
+	if err != nil {
+		log.Error("query parent utxo fail", "err", err)
+		return nil, err
+	}
+	if confirmedUtxo != nil {
+		resp.Msg = "parent transaction already confirmed"
+		return resp, nil
+	}
+
+	parentAddress, parentAmount, err := bws.queryParentOutput(request.ParentTxHash, request.ParentVoutIndex)
+	if err != nil {
+		log.Error("query parent transaction fail", "hash", request.ParentTxHash, "err", err)
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+	owner, err := bws.db.Addresses.QueryAddressesByToAddress(request.RequestId, parentAddress)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "parent output not belong to business"
+		return resp, nil
+	} else if err != nil {
+		log.Error("query parent output address fail", "err", err)
+		return nil, err
+	}
+
+	// 父交易是本系统构建的提现时直接使用记录的手续费和大小，否则由业务方传入
+	parentFee, parentVSize := request.ParentFee, request.ParentVsize
+	parentWithdraw, err := bws.db.Withdraws.QueryWithdrawByHash(request.RequestId, request.ParentTxHash)
+	if err != nil {
+		log.Error("query parent withdraw fail", "err", err)
+		return nil, err
+	}
+	if parentWithdraw != nil && parentWithdraw.VSize > 0 {
+		parentFee, parentVSize = parentWithdraw.Fee.Int64(), parentWithdraw.VSize
+	}
+	if parentFee <= 0 || parentVSize <= 0 {
+		resp.Msg = "parent fee and vsize are required"
+		return resp, nil
+	}
+
+	targetFeeRate := request.TargetFeeRate
+	if targetFeeRate == 0 {
+		priority, err := feeestimator.ParsePriority(business.FeePriority)
+		if err != nil {
+			resp.Msg = err.Error()
+			return resp, nil
+		}
+		feeReq := &utxo.FeeRequest{
+			ConsumerToken: ConsumerToken,
+			Chain:         bws.Chain.ChainName,
+			Network:       bws.Chain.Network,
+			Coin:          bws.Chain.CoinName,
+		}
+		utxoFee, err := bws.syncClient.BtcRpcClient.GetFee(context.Background(), feeReq)
+		if err != nil {
+			log.Error("get btc fee fail", "err", err)
+			return nil, err
+		}
+		estimator := &feeestimator.Estimator{
+			Priority:   priority,
+			MinFeeRate: max(business.MinFeeRate, bws.Chain.MinFeeRate),
+			MaxFeeRate: business.MaxFeeRate,
+		}
+		targetFeeRate = estimator.FeeRate(feeestimator.FeeRatesFromResponse(utxoFee))
+	}
+	if parentFee >= feeestimator.Fee(parentVSize, targetFeeRate) {
+		resp.Msg = "parent fee rate already reaches target"
+		return resp, nil
+	}
+
+	hotInput, err := feeestimator.HotWalletInput(hotWalletInfo.Address, business.MultisigM, business.MultisigN, bws.Chain)
+	if err != nil {
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+	parentInput := hotInput
+	if parentAddress != hotWalletInfo.Address {
+		scriptType, err := feeestimator.ScriptTypeFromAddress(parentAddress, bws.Chain)
+		if err != nil {
+			resp.Msg = err.Error()
+			return resp, nil
+		}
+		parentInput = feeestimator.Input{ScriptType: scriptType}
+	}
+	changeOutputs := []feeestimator.Output{{ScriptType: hotInput.ScriptType}}
+
+	internalUuid := uuid.New()
+	var (
+		childFee   int64
+		childVSize int64
+		internal   *database.Internals
+		utr        *utxo.UnSignTransactionRequest
+	)
+	// 占用父交易输出、锁定合并的热钱包 utxo 和保存子交易放在一个短事务里，提交后再调用上游构建交易
+	err = bws.db.Transaction(func(tx *database.DB) error {
+		if err := tx.CpfpReservations.ReserveParentOutput(request.RequestId, &database.CpfpReservations{
+			ParentHash:      request.ParentTxHash,
+			ParentVoutIndex: request.ParentVoutIndex,
+			InternalGuid:    internalUuid,
+			Timestamp:       uint64(time.Now().Unix()),
+		}); err != nil {
+			return err
+		}
+
+		inputs := []feeestimator.Input{parentInput}
+		utxoVins := []*utxo.Vin{{
+			Hash:    request.ParentTxHash,
+			Index:   request.ParentVoutIndex,
+			Amount:  parentAmount,
+			Address: parentAddress,
+		}}
+		inputAmount := parentAmount
+
+		// 父输出不够支付子交易手续费时，从大到小合并热钱包 utxo
+		var (
+			hotUtxos []database.Utxos
+			reserved []database.Utxos
+			loaded   bool
+		)
+		for {
+			childVSize, err = feeestimator.VSize(inputs, changeOutputs)
+			if err != nil {
+				return err
+			}
+			childFee = feeestimator.CpfpFee(parentFee, parentVSize, childVSize, targetFeeRate)
+			if inputAmount-childFee >= bws.Chain.DustLimit {
+				break
+			}
+			if !loaded {
+				hotUtxos, err = tx.Utxos.LockAvailableUtxos(request.RequestId, hotWalletInfo.Address)
+				if err != nil {
+					log.Error("query hot wallet utxos fail", "err", err)
+					return err
+				}
+				sort.Slice(hotUtxos, func(i, j int) bool {
+					return hotUtxos[i].Amount.Cmp(hotUtxos[j].Amount) > 0
+				})
+				loaded = true
+			}
+			if len(reserved) == len(hotUtxos) {
+				return coinselect.ErrInsufficientFunds
+			}
+			hotUtxo := hotUtxos[len(reserved)]
+			reserved = append(reserved, hotUtxo)
+			inputs = append(inputs, hotInput)
+			inputAmount += hotUtxo.Amount.Int64()
+			utxoVins = append(utxoVins, &utxo.Vin{
+				Hash:    hotUtxo.TxId,
+				Index:   hotUtxo.VoutIndex,
+				Amount:  hotUtxo.Amount.Int64(),
+				Address: hotUtxo.Address,
+			})
+		}
+		if err := tx.Utxos.ReserveUtxos(request.RequestId, internalUuid.String(), reserved); err != nil {
+			log.Error("reserve utxo fail", "err", err)
+			return err
+		}
+
+		utr = &utxo.UnSignTransactionRequest{
+			ConsumerToken: ConsumerToken,
+			Chain:         bws.Chain.ChainName,
+			Network:       bws.Chain.Network,
+			Fee:           strconv.FormatInt(childFee, 10),
+			Vin:           utxoVins,
+			Vout: []*utxo.Vout{{
+				Address: hotWalletInfo.Address,
+				Amount:  inputAmount - childFee,
+				Index:   0,
+			}},
+		}
+		internal = &database.Internals{
+			Guid:        internalUuid,
+			BlockHash:   "0x0",
+			BlockNumber: big.NewInt(0),
+			Hash:        "0x0",
+			Fee:         big.NewInt(childFee),
+			LockTime:    big.NewInt(0),
+			Version:     "0x0",
+			TxType:      database.InternalTxTypeCpfp,
+			ParentHash:  request.ParentTxHash,
+			TxSignHex:   "0x0",
+			Status:      database.TxStatusWaitSign,
+			Timestamp:   uint64(time.Now().Unix()),
+		}
+		if err := tx.Internals.StoreInternal(request.RequestId, internal); err != nil {
+			log.Error("store cpfp internal fail", "err", err)
+			return err
+		}
+		return tx.NotifyApiCacheInvalidate(request.RequestId, database.ApiCacheInternals, database.ApiCacheBalances)
+	})
+	if errors.Is(err, coinselect.ErrInsufficientFunds) || errors.Is(err, database.ErrParentOutputReserved) {
+		resp.Msg = err.Error()
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	txMessageHash, err := bws.syncClient.BtcRpcClient.CreateUnSignTransaction(context.Background(), utr)
+	if err != nil {
+		log.Error("create un sign transaction fail", "err", err)
+		// 上游构建失败，子交易作废并释放父交易输出和合并的 utxo
+		if failErr := bws.db.FailInternals(request.RequestId, []database.Internals{*internal}); failErr != nil {
+			log.Error("release cpfp reservation fail", "internalUuid", internalUuid, "err", failErr)
+		}
+		return nil, err
+	}
+	log.Info("build cpfp transaction", "parentHash", request.ParentTxHash, "ownerType", owner.AddressType, "inputs", len(utr.Vin), "vsize", childVSize, "targetFeeRate", targetFeeRate, "fee", childFee)
+
+	var signHashStr string
+	for _, signHash := range txMessageHash.SignHashes {
+		signHashStr += string(signHash) + "|"
+	}
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "create cpfp tx message hash success"
+	resp.ReturnTxHash = &dal_wallet_go.ReturnTransactionHashes{
+		TransactionUuid: internalUuid.String(),
+		UnSignTx:        signHashStr,
+		TxData:          string(txMessageHash.TxData),
+	}
+	resp.Fee = childFee
+	resp.PackageFeeRate = feeestimator.PackageFeeRate(parentFee, parentVSize, childFee, childVSize)
+	return resp, nil
+}
+
+// queryParentOutput 从链上查询未确认父交易指定输出的地址和金额
+func (bws *BusinessMiddleWireServices) queryParentOutput(txHash string, voutIndex uint32) (string, int64, error) {
+	txReq := &utxo.TxHashRequest{
+		ConsumerToken: ConsumerToken,
+		Chain:         bws.Chain.ChainName,
+		Network:       bws.Chain.Network,
+		Coin:          bws.Chain.CoinName,
+		Hash:          txHash,
+	}
+	txResp, err := bws.syncClient.BtcRpcClient.GetTxByHash(context.Background(), txReq)
+	if err != nil {
+		return "", 0, err
+	}
+	if txResp.Code == common.ReturnCode_ERROR || txResp.Tx == nil {
+		return "", 0, errors.New("parent transaction not found")
+	}
+	if int(voutIndex) >= len(txResp.Tx.Tos) || int(voutIndex) >= len(txResp.Tx.Values) {
+		return "", 0, errors.New("parent vout index out of range")
+	}
+	amount, err := strconv.ParseInt(txResp.Tx.Values[voutIndex].Value, 10, 64)
+	if err != nil || amount <= 0 {
+		return "", 0, errors.New("invalid parent output amount")
+	}
+	return txResp.Tx.Tos[voutIndex].Address, amount, nil
+}
@@ -0,0 +1,270 @@
+package feeestimator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+)
+
+const (
+	bech32Charset  = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// errAddressFormat 地址不是这种格式，继续尝试下一种
+var errAddressFormat = errors.New("address format mismatch")
+
+// ScriptTypeFromAddress 按链的地址参数解析地址并判断脚本类型：bech32 地址看 HRP 和见证程序，cashaddr 地址看类型位，
+// 其余按 base58check 的版本字节区分 P2PKH 和 P2SH，校验和不对或者属于别的链的地址返回错误。
+// 链支持隔离见证时 P2SH 地址默认按 P2SH-P2WPKH 处理，不支持时（DOGE、BCH）按传统 P2SH 处理；
+// 32 字节见证程序的 bech32 地址默认是 P2WSH 多签，需要配合多签参数使用
+func ScriptTypeFromAddress(address string, chain config.ChainProfile) (ScriptType, error) {
+	if address == "" {
+		return "", fmt.Errorf("empty address")
+	}
+	parsers := []func(string, config.ChainProfile) (ScriptType, error){parseSegwitAddress, parseCashAddress, parseBase58Address}
+	for _, parse := range parsers {
+		scriptType, err := parse(address, chain)
+		if errors.Is(err, errAddressFormat) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid %s address %s: %w", chain.ChainName, address, err)
+		}
+		return scriptType, nil
+	}
+	return "", fmt.Errorf("unsupported %s address: %s", chain.ChainName, address)
+}
+
+// parseSegwitAddress BIP173/BIP350，见证版本 0 用 bech32 校验和，版本 1 及以上用 bech32m
+func parseSegwitAddress(address string, chain config.ChainProfile) (ScriptType, error) {
+	separator := strings.LastIndexByte(address, '1')
+	if separator < 1 || !containsFold(chain.Bech32HRPs, address[:separator]) {
+		return "", errAddressFormat
+	}
+	if !chain.Segwit {
+		return "", errors.New("segwit is not supported on this chain")
+	}
+	lower := strings.ToLower(address)
+	if lower != address && strings.ToUpper(address) != address {
+		return "", errors.New("mixed case")
+	}
+	hrp := lower[:separator]
+	data, err := decodeBase32(lower[separator+1:])
+	if err != nil {
+		return "", err
+	}
+	if len(data) < 7 {
+		return "", errors.New("too short")
+	}
+	checksum := bech32Polymod(append(bech32HrpExpand(hrp), data...))
+	version := data[0]
+	program, err := convertBits(data[1:len(data)-6], 5, 8, false)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case version == 0 && checksum != bech32Const, version > 0 && checksum != bech32mConst:
+		return "", errors.New("checksum mismatch")
+	case version == 0 && len(program) == 20:
+		return P2WPKH, nil
+	case version == 0 && len(program) == 32:
+		return P2WSHMultisig, nil
+	case version == 1 && len(program) == 32:
+		return P2TR, nil
+	}
+	return "", fmt.Errorf("unsupported witness version %d with %d byte program", version, len(program))
+}
+
+// parseCashAddress BCH cashaddr，前缀可以省略，省略时逐个前缀校验；版本字节的类型位 0 是 P2PKH，1 是 P2SH
+func parseCashAddress(address string, chain config.ChainProfile) (ScriptType, error) {
+	if len(chain.CashAddrPrefixes) == 0 {
+		return "", errAddressFormat
+	}
+	lower := strings.ToLower(address)
+	prefixes := chain.CashAddrPrefixes
+	payload := lower
+	if separator := strings.IndexByte(lower, ':'); separator >= 0 {
+		if !containsFold(prefixes, lower[:separator]) {
+			return "", errAddressFormat
+		}
+		prefixes = []string{lower[:separator]}
+		payload = lower[separator+1:]
+	}
+	data, err := decodeBase32(payload)
+	if err != nil || len(data) < 9 {
+		return "", errAddressFormat
+	}
+	for _, prefix := range prefixes {
+		if cashAddrPolymod(append(cashAddrPrefixExpand(prefix), data...)) != 0 {
+			continue
+		}
+		decoded, err := convertBits(data[:len(data)-8], 5, 8, false)
+		if err != nil {
+			return "", err
+		}
+		// 版本字节低 3 位是哈希长度，0 代表 20 字节
+		if len(decoded) != 21 || decoded[0]&0x07 != 0 {
+			return "", errors.New("unsupported hash size")
+		}
+		switch decoded[0] >> 3 {
+		case 0:
+			return P2PKH, nil
+		case 1:
+			return P2SHP2PKH, nil
+		}
+		return "", fmt.Errorf("unsupported cashaddr type %d", decoded[0]>>3)
+	}
+	if strings.Contains(lower, ":") {
+		return "", errors.New("checksum mismatch")
+	}
+	return "", errAddressFormat
+}
+
+// parseBase58Address base58check 地址，1 字节版本加 20 字节哈希再加 4 字节校验和
+func parseBase58Address(address string, chain config.ChainProfile) (ScriptType, error) {
+	decoded, err := decodeBase58(address)
+	if err != nil {
+		return "", errAddressFormat
+	}
+	if len(decoded) != 25 {
+		return "", fmt.Errorf("invalid length %d", len(decoded))
+	}
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], decoded[21:]) {
+		return "", errors.New("checksum mismatch")
+	}
+	version := decoded[0]
+	switch {
+	case bytes.IndexByte(chain.PubKeyHashAddrIds, version) >= 0:
+		return P2PKH, nil
+	case bytes.IndexByte(chain.ScriptHashAddrIds, version) >= 0:
+		if !chain.Segwit {
+			return P2SHP2PKH, nil
+		}
+		return P2SHP2WPKH, nil
+	}
+	return "", fmt.Errorf("version byte 0x%02x does not belong to this chain", version)
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeBase58(address string) ([]byte, error) {
+	value := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range address {
+		digit := strings.IndexRune(base58Alphabet, r)
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		value.Mul(value, radix)
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+	zeros := 0
+	for zeros < len(address) && address[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), value.Bytes()...), nil
+}
+
+// decodeBase32 bech32 和 cashaddr 共用的 32 个字符，每个字符 5 位
+func decodeBase32(payload string) ([]byte, error) {
+	data := make([]byte, len(payload))
+	for i := 0; i < len(payload); i++ {
+		digit := strings.IndexByte(bech32Charset, payload[i])
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid character %q", payload[i])
+		}
+		data[i] = byte(digit)
+	}
+	return data, nil
+}
+
+func convertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc    uint32
+		bits   uint
+		result []byte
+	)
+	maxValue := uint32(1)<<toBits - 1
+	for _, value := range data {
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxValue))
+		}
+	}
+	if pad && bits > 0 {
+		result = append(result, byte(acc<<(toBits-bits)&maxValue))
+	} else if !pad && (bits >= fromBits || acc<<(toBits-bits)&maxValue != 0) {
+		return nil, errors.New("invalid padding")
+	}
+	return result, nil
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, value := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if top>>i&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func cashAddrPrefixExpand(prefix string) []byte {
+	expanded := make([]byte, 0, len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		expanded = append(expanded, prefix[i]&31)
+	}
+	return append(expanded, 0)
+}
+
+func cashAddrPolymod(values []byte) uint64 {
+	generator := [5]uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+	chk := uint64(1)
+	for _, value := range values {
+		top := chk >> 35
+		chk = (chk&0x07ffffffff)<<5 ^ uint64(value)
+		for i := 0; i < 5; i++ {
+			if top>>i&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk ^ 1
+}
@@ -0,0 +1,38 @@
+package feeestimator
+
+import (
+	"errors"
+)
+
+// MinRelayFeeRate 节点默认的最低转发费率，BIP125 要求替换交易多付的手续费至少覆盖自身大小
+const MinRelayFeeRate int64 = 1
+
+var ErrFeeRateCapReached = errors.New("fee rate cap reached, can not bump")
+
+// BumpFeeRate 计算替换交易的费率：在原费率基础上至少提高 percent%，且不低于当前市场费率，
+// maxFeeRate 为业务方配置的上限，0 表示不限制
+func BumpFeeRate(oldFeeRate int64, percent int64, marketFeeRate int64, maxFeeRate int64) (int64, error) {
+	rate := oldFeeRate * (100 + percent) / 100
+	if rate < oldFeeRate+MinRelayFeeRate {
+		rate = oldFeeRate + MinRelayFeeRate
+	}
+	if rate < marketFeeRate {
+		rate = marketFeeRate
+	}
+	if maxFeeRate > 0 && rate > maxFeeRate {
+		rate = maxFeeRate
+	}
+	if rate <= oldFeeRate {
+		return 0, ErrFeeRateCapReached
+	}
+	return rate, nil
+}
+
+// BumpFee 替换交易的手续费，按新费率计算并满足 BIP125 规则 4：新手续费 >= 原手续费 + 最低转发费率 * 新交易大小
+func BumpFee(oldFee int64, vsize int64, feeRate int64) int64 {
+	fee := Fee(vsize, feeRate)
+	if minFee := oldFee + Fee(vsize, MinRelayFeeRate); fee < minFee {
+		fee = minFee
+	}
+	return fee
+}
@@ -0,0 +1,20 @@
+package feeestimator
+
+// PackageFeeRate 父子交易打包之后的整体费率，向上取整
+func PackageFeeRate(parentFee int64, parentVSize int64, childFee int64, childVSize int64) int64 {
+	vsize := parentVSize + childVSize
+	if vsize <= 0 {
+		return 0
+	}
+	return (parentFee + childFee + vsize - 1) / vsize
+}
+
+// CpfpFee 子交易需要支付的手续费，使父子交易整体达到 targetFeeRate：
+// childFee = targetFeeRate * (parentVSize + childVSize) - parentFee，子交易自身至少满足最低转发费率
+func CpfpFee(parentFee int64, parentVSize int64, childVSize int64, targetFeeRate int64) int64 {
+	fee := Fee(parentVSize+childVSize, targetFeeRate) - parentFee
+	if minFee := Fee(childVSize, MinRelayFeeRate); fee < minFee {
+		fee = minFee
+	}
+	return fee
+}
@@ -0,0 +1,102 @@
+package feeestimator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+)
+
+type Priority string
+
+const (
+	PriorityEconomy Priority = "economy"
+	PriorityNormal  Priority = "normal"
+	PriorityFast    Priority = "fast"
+)
+
+// FeeRates 各档位的费率，单位 sat/vbyte
+type FeeRates struct {
+	Economy int64
+	Normal  int64
+	Fast    int64
+}
+
+// Estimator 按档位选取费率，并限制在业务方配置的上下限之内，上下限为 0 表示不限制
+type Estimator struct {
+	Priority   Priority
+	MinFeeRate int64
+	MaxFeeRate int64
+}
+
+func ParsePriority(priority string) (Priority, error) {
+	switch Priority(priority) {
+	case "":
+		return PriorityNormal, nil
+	case PriorityEconomy, PriorityNormal, PriorityFast:
+		return Priority(priority), nil
+	default:
+		return "", fmt.Errorf("unknown fee priority: %s", priority)
+	}
+}
+
+// satPerKvBytePerBtc fee_rate 是节点 estimatesmartfee 的结果，单位 BTC/kvB，1 BTC/kvB = 1e8 / 1000 sat/vbyte
+const satPerKvBytePerBtc = 1e8 / 1000
+
+// FeeRatesFromResponse 从链上服务的 GetFee 结果中取各档位费率，统一换算成 sat/vbyte：
+// hd_wallet 里的 slow/normal/fast 是浏览器给出的 sat/vbyte，档位缺失时退回到 fee_rate（BTC/kvB）
+func FeeRatesFromResponse(resp *utxo.FeeResponse) FeeRates {
+	baseRate := ceilFeeRate(float64(resp.FeeRate) * satPerKvBytePerBtc)
+	rates := FeeRates{Economy: baseRate, Normal: baseRate, Fast: baseRate}
+	if resp.HdWallet != nil {
+		rates.Economy = parseFeeRate(resp.HdWallet.SlowFee, rates.Economy)
+		rates.Normal = parseFeeRate(resp.HdWallet.NormalFee, rates.Normal)
+		rates.Fast = parseFeeRate(resp.HdWallet.FastFee, rates.Fast)
+	}
+	return rates
+}
+
+// FeeRate 返回档位对应并经过上下限修正的费率，至少 1 sat/vbyte
+func (e *Estimator) FeeRate(rates FeeRates) int64 {
+	var rate int64
+	switch e.Priority {
+	case PriorityEconomy:
+		rate = rates.Economy
+	case PriorityFast:
+		rate = rates.Fast
+	default:
+		rate = rates.Normal
+	}
+	if e.MinFeeRate > 0 && rate < e.MinFeeRate {
+		rate = e.MinFeeRate
+	}
+	if e.MaxFeeRate > 0 && rate > e.MaxFeeRate {
+		rate = e.MaxFeeRate
+	}
+	if rate < 1 {
+		rate = 1
+	}
+	return rate
+}
+
+// Fee 交易手续费 = vsize * 费率
+func Fee(vsize int64, feeRate int64) int64 {
+	return vsize * feeRate
+}
+
+func parseFeeRate(value string, fallback int64) int64 {
+	if value == "" {
+		return fallback
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate <= 0 {
+		return fallback
+	}
+	return ceilFeeRate(rate)
+}
+
+// ceilFeeRate 向上取整到整数 sat/vbyte，先按千分之一聪舍入，避免 float32 的 fee_rate 换算后出现 12.0000005 这样的误差被多进一位
+func ceilFeeRate(rate float64) int64 {
+	return int64(math.Ceil(math.Round(rate*1000) / 1000))
+}
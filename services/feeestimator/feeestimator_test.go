@@ -0,0 +1,245 @@
+package feeestimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+)
+
+func TestInputVSize(t *testing.T) {
+	cases := []struct {
+		input Input
+		vsize int64
+	}{
+		{Input{ScriptType: P2PKH}, 148},
+		{Input{ScriptType: P2SHP2PKH}, 174},
+		{Input{ScriptType: P2SHP2WPKH}, 91},
+		{Input{ScriptType: P2WPKH}, 68},
+		{Input{ScriptType: P2TR}, 58},
+		{Input{ScriptType: P2SHMultisig, M: 2, N: 3}, 297},
+		{Input{ScriptType: P2WSHMultisig, M: 2, N: 3}, 105},
+	}
+	for _, c := range cases {
+		vsize, err := InputVSize(c.input)
+		require.NoError(t, err)
+		require.Equal(t, c.vsize, vsize, c.input.ScriptType)
+	}
+
+	_, err := InputVSize(Input{ScriptType: P2WSHMultisig, M: 3, N: 2})
+	require.Error(t, err)
+	_, err = InputVSize(Input{ScriptType: "p2pk"})
+	require.Error(t, err)
+}
+
+func TestOutputVSize(t *testing.T) {
+	cases := map[ScriptType]int64{
+		P2PKH:         34,
+		P2SHP2WPKH:    32,
+		P2WPKH:        31,
+		P2TR:          43,
+		P2WSHMultisig: 43,
+	}
+	for scriptType, expected := range cases {
+		vsize, err := OutputVSize(Output{ScriptType: scriptType})
+		require.NoError(t, err)
+		require.Equal(t, expected, vsize, scriptType)
+	}
+}
+
+func TestVSize(t *testing.T) {
+	// 1 进 2 出的 P2WPKH 交易: 10.5 + 68 + 2 * 31 = 140.5
+	vsize, err := VSize([]Input{{ScriptType: P2WPKH}}, []Output{{ScriptType: P2WPKH}, {ScriptType: P2WPKH}})
+	require.NoError(t, err)
+	require.Equal(t, int64(141), vsize)
+
+	// 1 进 2 出的 legacy 交易: 10 + 148 + 2 * 34 = 226
+	vsize, err = VSize([]Input{{ScriptType: P2PKH}}, []Output{{ScriptType: P2PKH}, {ScriptType: P2PKH}})
+	require.NoError(t, err)
+	require.Equal(t, int64(226), vsize)
+
+	// 混合输入时非见证输入也要带一个空见证
+	vsize, err = VSize([]Input{{ScriptType: P2PKH}, {ScriptType: P2TR}}, []Output{{ScriptType: P2TR}})
+	require.NoError(t, err)
+	require.Equal(t, int64((40+2+148*4+1+230+43*4+3)/4), vsize)
+}
+
+func chainProfile(t *testing.T, name string) config.ChainProfile {
+	profile, err := config.LookupChainProfile(name, "")
+	require.NoError(t, err)
+	return profile
+}
+
+func TestScriptTypeFromAddress(t *testing.T) {
+	cases := map[string]map[string]ScriptType{
+		"btc": {
+			"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2":                             P2PKH,
+			"mfWyW5fc9NUj75YAnFgoRLrjxgLDn2MMth":                             P2PKH,
+			"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy":                             P2SHP2WPKH,
+			"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq":                     P2WPKH,
+			"BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ":                     P2WPKH,
+			"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3": P2WSHMultisig,
+			"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297": P2TR,
+			"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx":                     P2WPKH,
+		},
+		"ltc": {
+			"LKDyUEtTR1HXamkiEphisSiBJu6o3ZPE34":                              P2PKH,
+			"M7uBSTV2qNDHDe2tHfNMqhFkZucgRMpJQk":                              P2SHP2WPKH,
+			"31h38a54tFMrR8kzBnP2241MFD2EUHtGha":                              P2SHP2WPKH,
+			"ltc1qqqqsyqcyq5rqwzqfpg9scrgwpugpzysn3s44dy":                     P2WPKH,
+			"tltc1qqqqsyqcyq5rqwzqfpg9scrgwpugpzysnxzku7w":                    P2WPKH,
+			"ltc1qqqqsyqcyq5rqwzqfpg9scrgwpugpzysnzs23v9ccrydpk8qarc0sp89z3m": P2WSHMultisig,
+		},
+		// 不支持隔离见证的链上 P2SH 地址按传统 P2SH 处理
+		"doge": {
+			"DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L": P2PKH,
+			"nUCBUJGBZjQUjwpLq6MSPbQKDgr7DPLQiL": P2PKH,
+			"9rSHsR8xxKEkKW8Tbv3SGBdiwnQGWZ4bdM": P2SHP2PKH,
+		},
+		"bch": {
+			"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a": P2PKH,
+			"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a":             P2PKH,
+			"bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq": P2SHP2PKH,
+			"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2":                     P2PKH,
+			"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy":                     P2SHP2PKH,
+		},
+	}
+	for chain, addresses := range cases {
+		profile := chainProfile(t, chain)
+		for address, expected := range addresses {
+			scriptType, err := ScriptTypeFromAddress(address, profile)
+			require.NoError(t, err, "%s %s", chain, address)
+			require.Equal(t, expected, scriptType, "%s %s", chain, address)
+		}
+	}
+
+	// 别的链的地址、校验和不对的地址、不支持隔离见证的链上的 bech32 地址都无效
+	invalid := map[string][]string{
+		"btc": {
+			"",
+			"LKDyUEtTR1HXamkiEphisSiBJu6o3ZPE34",
+			"DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L",
+			"ltc1qqqqsyqcyq5rqwzqfpg9scrgwpugpzysn3s44dy",
+			"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3",
+			"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdr",
+			"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+		},
+		"ltc":  {"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"},
+		"doge": {"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", "A8Bcqg7ayDTvLvU5tLzxZKMjZUbS2EHqyw", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"},
+		"bch":  {"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b", "bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"},
+	}
+	for chain, addresses := range invalid {
+		profile := chainProfile(t, chain)
+		for _, address := range addresses {
+			_, err := ScriptTypeFromAddress(address, profile)
+			require.Error(t, err, "%s %s", chain, address)
+		}
+	}
+}
+
+func TestHotWalletInput(t *testing.T) {
+	btc, doge := chainProfile(t, "btc"), chainProfile(t, "doge")
+	input, err := HotWalletInput("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", 0, 0, btc)
+	require.NoError(t, err)
+	require.Equal(t, P2SHP2WPKH, input.ScriptType)
+
+	input, err = HotWalletInput("9rSHsR8xxKEkKW8Tbv3SGBdiwnQGWZ4bdM", 0, 0, doge)
+	require.NoError(t, err)
+	require.Equal(t, P2SHP2PKH, input.ScriptType)
+
+	input, err = HotWalletInput("9rSHsR8xxKEkKW8Tbv3SGBdiwnQGWZ4bdM", 2, 3, doge)
+	require.NoError(t, err)
+	require.Equal(t, Input{ScriptType: P2SHMultisig, M: 2, N: 3}, input)
+
+	// 传统 P2SH 没有见证折扣，同样的输入比 P2SH-P2WPKH 大
+	segwitPlan, err := NewPlan("3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", 0, 0, []string{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"}, btc)
+	require.NoError(t, err)
+	legacyPlan, err := NewPlan("9rSHsR8xxKEkKW8Tbv3SGBdiwnQGWZ4bdM", 0, 0, []string{"9rSHsR8xxKEkKW8Tbv3SGBdiwnQGWZ4bdM"}, doge)
+	require.NoError(t, err)
+	require.Equal(t, int64(91), segwitPlan.InputVSize)
+	require.Equal(t, int64(174), legacyPlan.InputVSize)
+	require.Equal(t, int64(10+32), legacyPlan.BaseVSize)
+}
+
+func TestEstimatorFeeRate(t *testing.T) {
+	// 链上服务 GetFee 的返回：fee_rate 来自节点 estimatesmartfee（BTC/kvB），hd_wallet 来自浏览器（sat/vbyte）
+	rates := FeeRatesFromResponse(&utxo.FeeResponse{
+		FeeRate: 0.00012,
+		HdWallet: &utxo.HdWallet{
+			BestFee:    "0.00012",
+			BestFeeSat: "12000",
+			SlowFee:    "3",
+			NormalFee:  "",
+			FastFee:    "25.4",
+		},
+	})
+	require.Equal(t, FeeRates{Economy: 3, Normal: 12, Fast: 26}, rates)
+
+	require.Equal(t, int64(3), (&Estimator{Priority: PriorityEconomy}).FeeRate(rates))
+	require.Equal(t, int64(12), (&Estimator{}).FeeRate(rates))
+	require.Equal(t, int64(20), (&Estimator{Priority: PriorityFast, MaxFeeRate: 20}).FeeRate(rates))
+	require.Equal(t, int64(5), (&Estimator{Priority: PriorityEconomy, MinFeeRate: 5}).FeeRate(rates))
+	require.Equal(t, int64(1), (&Estimator{}).FeeRate(FeeRates{}))
+
+	// 只有节点费率时所有档位都用它，0.00013 BTC/kvB 的 float32 误差不能多进一位
+	rates = FeeRatesFromResponse(&utxo.FeeResponse{FeeRate: 0.00013})
+	require.Equal(t, FeeRates{Economy: 13, Normal: 13, Fast: 13}, rates)
+	rates = FeeRatesFromResponse(&utxo.FeeResponse{FeeRate: 0.0000101})
+	require.Equal(t, FeeRates{Economy: 2, Normal: 2, Fast: 2}, rates)
+	rates = FeeRatesFromResponse(&utxo.FeeResponse{FeeRate: 0.00012, HdWallet: &utxo.HdWallet{SlowFee: "3"}})
+	require.Equal(t, FeeRates{Economy: 3, Normal: 12, Fast: 12}, rates)
+
+	priority, err := ParsePriority("")
+	require.NoError(t, err)
+	require.Equal(t, PriorityNormal, priority)
+	_, err = ParsePriority("urgent")
+	require.Error(t, err)
+	// 配置校验用的档位列表要和这里保持一致
+	for _, name := range config.FeePriorities {
+		_, err = ParsePriority(name)
+		require.NoError(t, err)
+	}
+}
+
+func TestBumpFeeRate(t *testing.T) {
+	// 默认提高 25%
+	rate, err := BumpFeeRate(20, 25, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(25), rate)
+
+	// 低费率时至少提高 1 sat/vbyte
+	rate, err = BumpFeeRate(2, 25, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rate)
+
+	// 市场费率更高时直接使用市场费率
+	rate, err = BumpFeeRate(20, 25, 40, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(40), rate)
+
+	// 受业务方上限约束
+	rate, err = BumpFeeRate(20, 25, 40, 30)
+	require.NoError(t, err)
+	require.Equal(t, int64(30), rate)
+
+	_, err = BumpFeeRate(30, 25, 40, 30)
+	require.ErrorIs(t, err, ErrFeeRateCapReached)
+}
+
+func TestBumpFee(t *testing.T) {
+	require.Equal(t, int64(141*25), BumpFee(141*20, 141, 25))
+	// 新费率算出的手续费不足以覆盖原手续费 + 新交易大小时按规则 4 补足
+	require.Equal(t, int64(3000+141), BumpFee(3000, 141, 21))
+}
+
+func TestCpfpFee(t *testing.T) {
+	// 父交易 200 vbyte 只付了 1 sat/vbyte，子交易 110 vbyte，整体提到 20 sat/vbyte
+	fee := CpfpFee(200, 200, 110, 20)
+	require.Equal(t, int64(20*310-200), fee)
+	require.Equal(t, int64(20), PackageFeeRate(200, 200, fee, 110))
+
+	// 父交易费率已经足够时子交易只需满足最低转发费率
+	require.Equal(t, int64(110), CpfpFee(200*50, 200, 110, 20))
+}
@@ -0,0 +1,77 @@
+package feeestimator
+
+import (
+	"fmt"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+)
+
+// Plan 一笔热钱包出金交易的大小模型，所有输入都来自热钱包，找零回到热钱包，选币时按输入个数估算手续费
+type Plan struct {
+	hotInput     Input
+	outputs      []Output
+	changeOutput Output
+
+	BaseVSize   int64 // 交易公共部分和所有提现输出
+	InputVSize  int64
+	ChangeVSize int64
+}
+
+// NewPlan 按所选链的地址参数确定热钱包地址和提现地址的脚本类型，热钱包是多签时传入 M-of-N
+func NewPlan(hotWalletAddress string, multisigM int, multisigN int, toAddresses []string, chain config.ChainProfile) (*Plan, error) {
+	hotInput, err := HotWalletInput(hotWalletAddress, multisigM, multisigN, chain)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{
+		hotInput:     hotInput,
+		changeOutput: Output{ScriptType: hotInput.ScriptType},
+	}
+	if plan.InputVSize, err = InputVSize(hotInput); err != nil {
+		return nil, fmt.Errorf("hot wallet %s: %w", hotWalletAddress, err)
+	}
+	if plan.ChangeVSize, err = OutputVSize(plan.changeOutput); err != nil {
+		return nil, err
+	}
+	plan.BaseVSize = OverheadVSize(hotInput.ScriptType.IsSegwit())
+	for _, address := range toAddresses {
+		scriptType, err := ScriptTypeFromAddress(address, chain)
+		if err != nil {
+			return nil, err
+		}
+		output := Output{ScriptType: scriptType}
+		outputVSize, err := OutputVSize(output)
+		if err != nil {
+			return nil, err
+		}
+		plan.outputs = append(plan.outputs, output)
+		plan.BaseVSize += outputVSize
+	}
+	return plan, nil
+}
+
+// HotWalletInput 热钱包输入的脚本类型，P2SH 地址配置了多签参数时按 P2SH 多签计算
+func HotWalletInput(hotWalletAddress string, multisigM int, multisigN int, chain config.ChainProfile) (Input, error) {
+	hotScriptType, err := ScriptTypeFromAddress(hotWalletAddress, chain)
+	if err != nil {
+		return Input{}, err
+	}
+	hotInput := Input{ScriptType: hotScriptType, M: multisigM, N: multisigN}
+	if multisigM > 0 && (hotScriptType == P2SHP2WPKH || hotScriptType == P2SHP2PKH) {
+		hotInput.ScriptType = P2SHMultisig
+	}
+	return hotInput, nil
+}
+
+// VSize 按最终的输入个数和是否找零计算交易大小
+func (p *Plan) VSize(inputs int, withChange bool) (int64, error) {
+	inputList := make([]Input, inputs)
+	for i := range inputList {
+		inputList[i] = p.hotInput
+	}
+	outputList := append([]Output{}, p.outputs...)
+	if withChange {
+		outputList = append(outputList, p.changeOutput)
+	}
+	return VSize(inputList, outputList)
+}
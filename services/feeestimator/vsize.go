@@ -0,0 +1,200 @@
+package feeestimator
+
+import (
+	"fmt"
+)
+
+type ScriptType string
+
+const (
+	P2PKH         ScriptType = "p2pkh"
+	P2SHP2PKH     ScriptType = "p2sh-p2pkh"
+	P2SHP2WPKH    ScriptType = "p2sh-p2wpkh"
+	P2WPKH        ScriptType = "p2wpkh"
+	P2TR          ScriptType = "p2tr"
+	P2SHMultisig  ScriptType = "p2sh-multisig"
+	P2WSHMultisig ScriptType = "p2wsh-multisig"
+)
+
+// 交易各部分的重量（weight unit），非见证数据 1 字节 = 4 WU，见证数据 1 字节 = 1 WU，vsize = ceil(weight / 4)
+const (
+	witnessScaleFactor = 4
+
+	// 版本号 4 + locktime 4 + 输入个数 1 + 输出个数 1
+	txOverheadWeight = (4 + 4 + 1 + 1) * witnessScaleFactor
+	// 隔离见证交易的 marker 和 flag
+	segwitMarkerWeight = 2
+
+	// 输入公共部分: 前序输出 32 + 4，sequence 4
+	outpointAndSequenceSize = 32 + 4 + 4
+
+	signatureSize = 72 // DER 签名 + sighash 类型，按最大长度估算
+	pubKeySize    = 33 // 压缩公钥
+	schnorrSize   = 64 // taproot key path 签名，默认 sighash
+)
+
+// Input 交易输入，多签时 M-of-N
+type Input struct {
+	ScriptType ScriptType
+	M          int
+	N          int
+}
+
+type Output struct {
+	ScriptType ScriptType
+}
+
+func (t ScriptType) IsSegwit() bool {
+	return t == P2SHP2WPKH || t == P2WPKH || t == P2TR || t == P2WSHMultisig
+}
+
+// InputWeight 返回单个输入的重量
+func InputWeight(input Input) (int64, error) {
+	switch input.ScriptType {
+	case P2PKH:
+		// scriptSig: 签名 + 公钥两个 push
+		scriptSig := 1 + signatureSize + 1 + pubKeySize
+		return int64(outpointAndSequenceSize+varIntSize(scriptSig)+scriptSig) * witnessScaleFactor, nil
+	case P2SHP2PKH:
+		// 不支持隔离见证的链上的单签 P2SH，scriptSig: 签名 + 公钥 + 25 字节的 P2PKH 赎回脚本
+		scriptSig := 1 + signatureSize + 1 + pubKeySize + 1 + 25
+		return int64(outpointAndSequenceSize+varIntSize(scriptSig)+scriptSig) * witnessScaleFactor, nil
+	case P2SHP2WPKH:
+		// scriptSig 只 push 一个 22 字节的 P2WPKH 赎回脚本
+		scriptSig := 1 + 22
+		witness := 1 + 1 + signatureSize + 1 + pubKeySize
+		return int64(outpointAndSequenceSize+varIntSize(scriptSig)+scriptSig)*witnessScaleFactor + int64(witness), nil
+	case P2WPKH:
+		witness := 1 + 1 + signatureSize + 1 + pubKeySize
+		return int64(outpointAndSequenceSize+1)*witnessScaleFactor + int64(witness), nil
+	case P2TR:
+		witness := 1 + 1 + schnorrSize
+		return int64(outpointAndSequenceSize+1)*witnessScaleFactor + int64(witness), nil
+	case P2SHMultisig:
+		redeemScript, err := multisigScriptSize(input.M, input.N)
+		if err != nil {
+			return 0, err
+		}
+		// OP_0 + M 个签名 + 赎回脚本
+		scriptSig := 1 + input.M*(1+signatureSize) + pushDataSize(redeemScript) + redeemScript
+		return int64(outpointAndSequenceSize+varIntSize(scriptSig)+scriptSig) * witnessScaleFactor, nil
+	case P2WSHMultisig:
+		redeemScript, err := multisigScriptSize(input.M, input.N)
+		if err != nil {
+			return 0, err
+		}
+		// 见证: 元素个数 + 空元素 + M 个签名 + 见证脚本
+		witness := 1 + 1 + input.M*(1+signatureSize) + varIntSize(redeemScript) + redeemScript
+		return int64(outpointAndSequenceSize+1)*witnessScaleFactor + int64(witness), nil
+	default:
+		return 0, fmt.Errorf("unsupported input script type: %s", input.ScriptType)
+	}
+}
+
+// OutputWeight 返回单个输出的重量，金额 8 字节 + 脚本长度 + 锁定脚本
+func OutputWeight(output Output) (int64, error) {
+	var script int
+	switch output.ScriptType {
+	case P2PKH:
+		script = 25
+	case P2SHP2PKH, P2SHP2WPKH, P2SHMultisig:
+		script = 23
+	case P2WPKH:
+		script = 22
+	case P2TR, P2WSHMultisig:
+		script = 34
+	default:
+		return 0, fmt.Errorf("unsupported output script type: %s", output.ScriptType)
+	}
+	return int64(8+1+script) * witnessScaleFactor, nil
+}
+
+// VSize 根据输入输出的个数和脚本类型计算交易的虚拟大小
+func VSize(inputs []Input, outputs []Output) (int64, error) {
+	weight := int64(txOverheadWeight)
+	segwit := false
+	for _, input := range inputs {
+		inputWeight, err := InputWeight(input)
+		if err != nil {
+			return 0, err
+		}
+		weight += inputWeight
+		segwit = segwit || input.ScriptType.IsSegwit()
+	}
+	for _, output := range outputs {
+		outputWeight, err := OutputWeight(output)
+		if err != nil {
+			return 0, err
+		}
+		weight += outputWeight
+	}
+	if segwit {
+		// 有见证输入时每个输入都要带见证个数，非见证输入记 1 字节的空见证
+		weight += segwitMarkerWeight
+		for _, input := range inputs {
+			if !input.ScriptType.IsSegwit() {
+				weight++
+			}
+		}
+	}
+	return weightToVSize(weight), nil
+}
+
+// InputVSize 单个输入的 vsize，向上取整，选币时按输入累加
+func InputVSize(input Input) (int64, error) {
+	weight, err := InputWeight(input)
+	if err != nil {
+		return 0, err
+	}
+	return weightToVSize(weight), nil
+}
+
+func OutputVSize(output Output) (int64, error) {
+	weight, err := OutputWeight(output)
+	if err != nil {
+		return 0, err
+	}
+	return weightToVSize(weight), nil
+}
+
+// OverheadVSize 交易公共部分的 vsize，包含隔离见证的 marker 和 flag
+func OverheadVSize(segwit bool) int64 {
+	if segwit {
+		return weightToVSize(txOverheadWeight + segwitMarkerWeight)
+	}
+	return weightToVSize(txOverheadWeight)
+}
+
+func weightToVSize(weight int64) int64 {
+	return (weight + witnessScaleFactor - 1) / witnessScaleFactor
+}
+
+// multisigScriptSize OP_M <N 个公钥> OP_N OP_CHECKMULTISIG
+func multisigScriptSize(m, n int) (int, error) {
+	if m <= 0 || n <= 0 || m > n || n > 16 {
+		return 0, fmt.Errorf("invalid multisig %d-of-%d", m, n)
+	}
+	return 1 + n*(1+pubKeySize) + 1 + 1, nil
+}
+
+func varIntSize(n int) int {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	default:
+		return 5
+	}
+}
+
+func pushDataSize(n int) int {
+	switch {
+	case n < 0x4c:
+		return 1
+	case n <= 0xff:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -2,22 +2,31 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/apikey"
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
+	"github.com/dapplink-labs/multichain-sync-btc/common/tenant"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
 	"github.com/dapplink-labs/multichain-sync-btc/database/dynamic"
 	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+	"github.com/dapplink-labs/multichain-sync-btc/services/coinselect"
+	"github.com/dapplink-labs/multichain-sync-btc/services/feeestimator"
 )
 
 const (
+	// ConsumerToken 调用链上 rpc 服务使用的 token，业务方的请求通过 api key 鉴权
 	ConsumerToken = "DappLink123456"
 )
 
@@ -28,24 +37,108 @@ func (bws *BusinessMiddleWireServices) BusinessRegister(ctx context.Context, req
 			Msg:  "invalid params",
 		}, nil
 	}
+	if err := tenant.ValidateRequestId(request.RequestId); err != nil {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  err.Error(),
+		}, nil
+	}
+	request = bws.applyBusinessPolicy(request)
+	strategy, err := coinselect.NewStrategy(request.CoinSelection)
+	if err != nil {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  err.Error(),
+		}, nil
+	}
+	priority, err := feeestimator.ParsePriority(request.FeePriority)
+	if err != nil {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  err.Error(),
+		}, nil
+	}
+	if request.MinFeeRate < 0 || request.MaxFeeRate < 0 || (request.MaxFeeRate > 0 && request.MinFeeRate > request.MaxFeeRate) {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  "invalid fee rate caps",
+		}, nil
+	}
+	if request.MultisigM > request.MultisigN || request.MultisigN > 16 {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  "invalid multisig params",
+		}, nil
+	}
+	// 确认阈值为 0 时使用全局配置；确认数以 uint8 记录，阈值不能超过 255
+	if request.FinalizedConfirms > math.MaxUint8 || (request.FinalizedConfirms > 0 && request.SafeConfirms > request.FinalizedConfirms) {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  "invalid confirmation thresholds",
+		}, nil
+	}
+	if _, err := bws.db.Business.QueryBusinessByUuid(request.RequestId); err == nil {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  "business already exist",
+		}, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("query business fail", "err", err)
+		return nil, err
+	}
+	apiKey, apiKeyHash, err := apikey.Generate()
+	if err != nil {
+		log.Error("generate api key fail", "err", err)
+		return nil, err
+	}
 	business := &database.Business{
-		GUID:        uuid.New(),
-		BusinessUid: request.RequestId,
-		NotifyUrl:   request.NotifyUrl,
-		Timestamp:   uint64(time.Now().Unix()),
+		GUID:              uuid.New(),
+		BusinessUid:       request.RequestId,
+		NotifyUrl:         request.NotifyUrl,
+		CoinSelection:     strategy.Name(),
+		FeePriority:       string(priority),
+		MinFeeRate:        request.MinFeeRate,
+		MaxFeeRate:        request.MaxFeeRate,
+		MultisigM:         int(request.MultisigM),
+		MultisigN:         int(request.MultisigN),
+		SafeConfirms:      uint64(request.SafeConfirms),
+		FinalizedConfirms: uint64(request.FinalizedConfirms),
+		Status:            database.BusinessStatusActive,
+		ApiKeyHash:        apiKeyHash,
+		Timestamp:         uint64(time.Now().Unix()),
+	}
+	// 业务方记录和每条链上业务方的表一起创建，建表失败时不留下没有表的业务方
+	err = bws.db.Transaction(func(tx *database.DB) error {
+		if err := tx.Business.StoreBusiness(business); err != nil {
+			log.Error("store business fail", "err", err)
+			return err
+		}
+		for _, schema := range bws.tenantSchemas() {
+			if err := tx.InSchema(schema, func(chainTx *database.DB) error {
+				return dynamic.CreateTableFromTemplate(request.RequestId, chainTx)
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, database.ErrBusinessExist) {
+		return &dal_wallet_go.BusinessRegisterResponse{
+			Code: dal_wallet_go.ReturnCode_ERROR,
+			Msg:  "business already exist",
+		}, nil
 	}
-	err := bws.db.Business.StoreBusiness(business)
 	if err != nil {
-		log.Error("store business fail", "err", err)
+		log.Error("register business fail", "err", err)
 		return &dal_wallet_go.BusinessRegisterResponse{
 			Code: dal_wallet_go.ReturnCode_ERROR,
 			Msg:  "store db fail",
 		}, nil
 	}
-	dynamic.CreateTableFromTemplate(request.RequestId, bws.db)
 	return &dal_wallet_go.BusinessRegisterResponse{
-		Code: dal_wallet_go.ReturnCode_SUCCESS,
-		Msg:  "config business success",
+		Code:   dal_wallet_go.ReturnCode_SUCCESS,
+		Msg:    "config business success",
+		ApiKey: apiKey,
 	}, nil
 }
 
@@ -56,7 +149,15 @@ func (bws *BusinessMiddleWireServices) ExportAddressesByPublicKeys(ctx context.C
 		balances      []database.Balances
 	)
 	for _, value := range request.PublicKeys {
-		address := bws.syncClient.ExportAddressByPubKey(value.Format, value.PublicKey)
+		// 地址格式按所选链校验，没有传格式时使用链的默认格式
+		format, err := bws.Chain.AddressFormat(value.Format)
+		if err != nil {
+			return &dal_wallet_go.ExportAddressesResponse{
+				Code: dal_wallet_go.ReturnCode_ERROR,
+				Msg:  err.Error(),
+			}, nil
+		}
+		address := bws.syncClient.ExportAddressByPubKey(format, value.PublicKey)
 		item := &dal_wallet_go.Address{
 			Type:    value.Type,
 			Address: address,
@@ -96,6 +197,7 @@ func (bws *BusinessMiddleWireServices) ExportAddressesByPublicKeys(ctx context.C
 			Msg:  "store balance to db fail",
 		}, nil
 	}
+	cache.GetAddressIndex(bws.Chain.Key).Add(request.RequestId, dbAddresses)
 	return &dal_wallet_go.ExportAddressesResponse{
 		Code:      dal_wallet_go.ReturnCode_SUCCESS,
 		Msg:       "generate address success",
@@ -108,16 +210,65 @@ func (bws *BusinessMiddleWireServices) BuildUnSignTransaction(ctx context.Contex
 		Code: dal_wallet_go.ReturnCode_ERROR,
 		Msg:  "submit withdraw fail",
 	}
-	if request.ConsumerToken != ConsumerToken {
-		resp.Msg = "consumer token is error"
+
+	business, err := bws.db.Business.QueryBusinessByUuid(request.RequestId)
+	if err != nil {
+		log.Error("query business fail", "err", err)
+		resp.Msg = "business not exist"
+		return resp, nil
+	}
+	if !business.Active() {
+		resp.Msg = "business is " + business.Status
+		return resp, nil
+	}
+	strategyName := request.CoinSelection
+	if strategyName == "" {
+		strategyName = business.CoinSelection
+	}
+	strategy, err := coinselect.NewStrategy(strategyName)
+	if err != nil {
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	priorityName := request.FeePriority
+	if priorityName == "" {
+		priorityName = business.FeePriority
+	}
+	priority, err := feeestimator.ParsePriority(priorityName)
+	if err != nil {
+		resp.Msg = err.Error()
+		return resp, nil
+	}
+
+	var target int64
+	var utxoVouts []*utxo.Vout
+	var toAddresses []string
+	for _, reqVout := range request.Txn {
+		amount, err := strconv.ParseInt(reqVout.Value, 10, 64)
+		if err != nil || amount <= 0 {
+			resp.Msg = "invalid withdraw amount"
+			return resp, nil
+		}
+		target += amount
+		voutItem := &utxo.Vout{
+			Address: reqVout.To,
+			Amount:  amount,
+			Index:   uint32(len(utxoVouts)),
+		}
+		utxoVouts = append(utxoVouts, voutItem)
+		toAddresses = append(toAddresses, reqVout.To)
+	}
+	if len(utxoVouts) == 0 {
+		resp.Msg = "withdraw transaction is empty"
 		return resp, nil
 	}
 
 	feeReq := &utxo.FeeRequest{
 		ConsumerToken: ConsumerToken,
-		Chain:         bws.BusinessMiddleConfig.ChainName,
-		Network:       bws.BusinessMiddleConfig.NetWork,
-		Coin:          bws.BusinessMiddleConfig.CoinName,
+		Chain:         bws.Chain.ChainName,
+		Network:       bws.Chain.Network,
+		Coin:          bws.Chain.CoinName,
 		RawTx:         "",
 	}
 
@@ -126,90 +277,162 @@ func (bws *BusinessMiddleWireServices) BuildUnSignTransaction(ctx context.Contex
 		log.Error("get btc fee fail", "err", err)
 		return nil, err
 	}
-	btcSt := utxoFee.FeeRate * 10e8 * 380
-	btcStStr := fmt.Sprintf("%f", btcSt) // 每个字节消耗手续费聪
-
-	/*
-	 根据是 taproot，隔离见证或者legacy 的预估单个 input 和 output 字节数，再根据 input 和 output 的数量做出总字节数
-	 再去乘以单个字节需要消耗聪的手续，得到的就是这笔交易的手续费
-
-	 如果铭文和符石，直接先进行一次预签名进行，铭文和符石，一个 witness, 一个 op-return, 不管是在那个结构里面都是要消耗的手续
-	*/
-	// todo: 上面预测模型实现
-
-	btcStBigIntFee, _ := new(big.Int).SetString(btcStStr, 10)
+	estimator := &feeestimator.Estimator{
+		Priority:   priority,
+		MinFeeRate: max(business.MinFeeRate, bws.Chain.MinFeeRate),
+		MaxFeeRate: business.MaxFeeRate,
+	}
+	// 每个 vbyte 消耗手续费聪
+	feeRate := estimator.FeeRate(feeestimator.FeeRatesFromResponse(utxoFee))
 
 	howWalletInfo, err := bws.db.Addresses.QueryHotWalletInfo(request.RequestId)
 	if err != nil {
 		log.Error("query hot wallet info fail", "err", err)
 		return nil, err
 	}
-
-	// todo: 需要找到和提现交易匹配的热钱包地址的 vin,
-	// - 暴力的形式，将所有 utxo 输入进去，然后找零
-	// - 将 uxto 进行排序，选择和提现相近的交易放到 vin, 此种方案会造成 utxo 臃肿，需要通过合并 utxo 解决臃肿问题
-	vinsList, err := bws.db.Vins.QueryVinsByAddress(request.RequestId, howWalletInfo.Address)
+	if howWalletInfo == nil {
+		resp.Msg = "hot wallet not exist"
+		return resp, nil
+	}
+	plan, err := feeestimator.NewPlan(howWalletInfo.Address, business.MultisigM, business.MultisigN, toAddresses, bws.Chain)
 	if err != nil {
-		return nil, err
+		log.Error("estimate transaction size fail", "err", err)
+		resp.Msg = err.Error()
+		return resp, nil
 	}
 
-	var utxoVins []*utxo.Vin
-	for _, dbVin := range vinsList {
-		vinItem := &utxo.Vin{
-			Hash:    dbVin.TxId,
-			Index:   uint32(dbVin.Vout),
-			Amount:  dbVin.Amount.Int64(),
-			Address: howWalletInfo.Address,
+	transactionUuid := uuid.New()
+	var (
+		withdraw *database.Withdraws
+		utr      *utxo.UnSignTransactionRequest
+		selected *coinselect.Result
+	)
+	// 选币、锁定 utxo 和保存提现记录放在一个短事务里，提交后再调用上游构建交易，不在持有行锁时做网络请求
+	err = bws.db.Transaction(func(tx *database.DB) error {
+		availableUtxos, err := tx.Utxos.LockAvailableUtxos(request.RequestId, howWalletInfo.Address)
+		if err != nil {
+			log.Error("query hot wallet utxos fail", "err", err)
+			return err
+		}
+		selectReq := &coinselect.Request{
+			Target:        target,
+			FeeRate:       feeRate,
+			BaseVSize:     plan.BaseVSize,
+			InputVSize:    plan.InputVSize,
+			ChangeVSize:   plan.ChangeVSize,
+			DustThreshold: bws.Chain.DustLimit,
+		}
+		dbUtxos := make(map[string]database.Utxos, len(availableUtxos))
+		for _, dbUtxo := range availableUtxos {
+			selectReq.Utxos = append(selectReq.Utxos, coinselect.Utxo{
+				TxId:    dbUtxo.TxId,
+				Index:   dbUtxo.VoutIndex,
+				Address: dbUtxo.Address,
+				Amount:  dbUtxo.Amount.Int64(),
+			})
+			dbUtxos[fmt.Sprintf("%s:%d", dbUtxo.TxId, dbUtxo.VoutIndex)] = dbUtxo
+		}
+		selected, err = strategy.Select(selectReq)
+		if err != nil {
+			log.Error("select utxo fail", "strategy", strategy.Name(), "err", err)
+			return err
 		}
-		utxoVins = append(utxoVins, vinItem)
-	}
-
-	var utxoVouts []*utxo.Vout
 
-	for _, reqVout := range request.Txn {
-		aomumt, _ := strconv.Atoi(reqVout.Value)
-		voutItem := &utxo.Vout{
-			Address: reqVout.To,
-			Amount:  int64(aomumt),
-			Index:   0,
+		var utxoVins []*utxo.Vin
+		var reserved []database.Utxos
+		for _, selectedUtxo := range selected.Selected {
+			utxoVins = append(utxoVins, &utxo.Vin{
+				Hash:    selectedUtxo.TxId,
+				Index:   selectedUtxo.Index,
+				Amount:  selectedUtxo.Amount,
+				Address: selectedUtxo.Address,
+			})
+			reserved = append(reserved, dbUtxos[fmt.Sprintf("%s:%d", selectedUtxo.TxId, selectedUtxo.Index)])
+		}
+		if err := tx.Utxos.ReserveUtxos(request.RequestId, transactionUuid.String(), reserved); err != nil {
+			log.Error("reserve utxo fail", "err", err)
+			return err
+		}
+		vsize, err := plan.VSize(len(selected.Selected), selected.Change > 0)
+		if err != nil {
+			return err
+		}
+		vouts := utxoVouts
+		if selected.Change > 0 {
+			vouts = append(vouts, &utxo.Vout{
+				Address: howWalletInfo.Address,
+				Amount:  selected.Change,
+				Index:   uint32(len(vouts)),
+			})
+		}
+		// WalletUtxoService 的请求里没有 nSequence 参数，提现是否声明 BIP125 由上游决定；
+		// 手续费加速在广播替换交易前从签名后的交易里检查，没有声明且被节点拒绝时不再重试
+		utr = &utxo.UnSignTransactionRequest{
+			ConsumerToken: ConsumerToken,
+			Chain:         bws.Chain.ChainName,
+			Network:       bws.Chain.Network,
+			Fee:           strconv.FormatInt(selected.Fee, 10),
+			Vin:           utxoVins,
+			Vout:          vouts,
 		}
-		utxoVouts = append(utxoVouts, voutItem)
-	}
 
-	utr := &utxo.UnSignTransactionRequest{
-		ConsumerToken: ConsumerToken,
-		Chain:         bws.BusinessMiddleConfig.ChainName,
-		Network:       bws.BusinessMiddleConfig.NetWork,
-		Fee:           btcStStr, // 每个字节消耗手续费聪
-		Vin:           utxoVins,
-		Vout:          utxoVouts,
+		withdraw = &database.Withdraws{
+			Guid:        transactionUuid,
+			BlockHash:   "0x0",
+			BlockNumber: big.NewInt(0),
+			Hash:        "0x0",
+			Fee:         big.NewInt(selected.Fee),
+			VSize:       vsize,
+			FeeRate:     feeRate,
+			LockTime:    big.NewInt(0),
+			Version:     "0x0",
+			TxSignHex:   "0x0",
+			SentHeight:  big.NewInt(0),
+			Status:      database.TxStatusWaitSign,
+			Timestamp:   uint64(time.Now().Unix()),
+		}
+		if err := tx.Withdraws.StoreWithdraws(request.RequestId, withdraw); err != nil {
+			log.Error("store withdraws fail", "err", err)
+			return err
+		}
+		// 记录提现输出，广播时锁定余额，手续费加速时按原输出重建交易
+		var childTxList []database.ChildTxs
+		for index, reqVout := range request.Txn {
+			childTxList = append(childTxList, database.ChildTxs{
+				GUID:        uuid.New(),
+				Hash:        "0x0",
+				TxId:        transactionUuid.String(),
+				TxIndex:     big.NewInt(int64(index)),
+				TxType:      "withdraw",
+				FromAddress: howWalletInfo.Address,
+				ToAddress:   reqVout.To,
+				Amount:      reqVout.Value,
+				Timestamp:   withdraw.Timestamp,
+			})
+		}
+		if err := tx.ChildTxs.StoreChildTxs(request.RequestId, childTxList); err != nil {
+			log.Error("store child txs fail", "err", err)
+			return err
+		}
+		return tx.NotifyApiCacheInvalidate(request.RequestId, database.ApiCacheWithdraws, database.ApiCacheTransactions, database.ApiCacheBalances)
+	})
+	if errors.Is(err, coinselect.ErrInsufficientFunds) || errors.Is(err, coinselect.ErrNoExactMatch) {
+		resp.Msg = err.Error()
+		return resp, nil
+	} else if err != nil {
+		return nil, err
 	}
 
 	txMessageHash, err := bws.syncClient.BtcRpcClient.CreateUnSignTransaction(context.Background(), utr)
 	if err != nil {
 		log.Error("create un sign transaction fail", "err", err)
+		// 上游构建失败，提现作废并释放刚占用的 utxo
+		if failErr := bws.db.FailWithdraws(request.RequestId, []database.Withdraws{*withdraw}); failErr != nil {
+			log.Error("release withdraw utxos fail", "transactionUuid", transactionUuid, "err", failErr)
+		}
 		return nil, err
 	}
-	log.Info("txMessageHash", "txMessageHash", txMessageHash)
-
-	transactionUuid := uuid.New()
-	withdraw := &database.Withdraws{
-		Guid:        transactionUuid,
-		BlockHash:   "0x0",
-		BlockNumber: big.NewInt(0),
-		Hash:        "0x0",
-		Fee:         btcStBigIntFee,
-		LockTime:    big.NewInt(0),
-		Version:     "0x0",
-		TxSignHex:   "0x0",
-		Status:      database.TxStatusWaitSign,
-		Timestamp:   uint64(time.Now().Unix()),
-	}
-	err = bws.db.Withdraws.StoreWithdraws(request.RequestId, withdraw)
-	if err != nil {
-		log.Error("store withdraws fail", "err", err)
-		return nil, err
-	}
+	log.Info("txMessageHash", "txMessageHash", txMessageHash, "strategy", strategy.Name(), "inputs", len(utr.Vin), "vsize", withdraw.VSize, "feeRate", feeRate, "fee", selected.Fee, "change", selected.Change)
 	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
 	resp.Msg = "create tx message hash success"
 	var retTxHashList []*dal_wallet_go.ReturnTransactionHashes
@@ -240,10 +463,6 @@ func (bws *BusinessMiddleWireServices) BuildSignedTransaction(ctx context.Contex
 		Code: dal_wallet_go.ReturnCode_ERROR,
 		Msg:  "submit withdraw fail",
 	}
-	if request.ConsumerToken != ConsumerToken {
-		resp.Msg = "consumer token is error"
-		return resp, nil
-	}
 
 	var resultSignature [][]byte
 	var txData []byte
@@ -266,8 +485,8 @@ func (bws *BusinessMiddleWireServices) BuildSignedTransaction(ctx context.Contex
 	publicKeys = append(publicKeys, []byte(hotWalletInfo.PublicKey))
 	signedReq := &utxo.SignedTransactionRequest{
 		ConsumerToken: "ConsumerToken",
-		Chain:         bws.ChainName,
-		Network:       bws.NetWork,
+		Chain:         bws.Chain.ChainName,
+		Network:       bws.Chain.Network,
 		TxData:        txData,
 		Signatures:    resultSignature,
 		PublicKeys:    publicKeys,
@@ -275,6 +494,7 @@ func (bws *BusinessMiddleWireServices) BuildSignedTransaction(ctx context.Contex
 	compTx, err := bws.syncClient.BtcRpcClient.BuildSignedTransaction(context.Background(), signedReq)
 	if err != nil {
 		log.Error("create un sign transaction fail", "err", err)
+		bws.failWaitSignTransaction(request.RequestId, transactionId)
 		return nil, err
 	}
 	log.Info("signed transaction data", "SignedTxData", compTx.SignedTxData)
@@ -284,11 +504,37 @@ func (bws *BusinessMiddleWireServices) BuildSignedTransaction(ctx context.Contex
 		SignedTx:        string(compTx.SignedTxData),
 	}
 
-	err = bws.db.Withdraws.UpdateWithdrawByGuuid(request.RequestId, transactionId, string(compTx.SignedTxData))
+	// 手续费加速生成的替换交易、CPFP 子交易和普通提现走同一个签名流程，按 transaction uuid 区分
+	replacement, err := bws.db.WithdrawReplacements.QueryReplacementByGuid(request.RequestId, transactionId)
+	if err != nil {
+		log.Error("query withdraw replacement fail", "err", err)
+		return nil, err
+	}
+	internal, err := bws.db.Internals.QueryInternalByGuid(request.RequestId, transactionId)
+	if err != nil {
+		log.Error("query internal fail", "err", err)
+		return nil, err
+	}
+	if replacement != nil {
+		err = bws.db.WithdrawReplacements.UpdateReplacementSigned(request.RequestId, transactionId, string(compTx.SignedTxData))
+	} else if internal != nil {
+		err = bws.db.Internals.UpdateInternalTx(request.RequestId, transactionId, string(compTx.SignedTxData), database.TxStatusUnSent)
+	} else {
+		err = bws.db.Withdraws.UpdateWithdrawByGuuid(request.RequestId, transactionId, string(compTx.SignedTxData))
+	}
+	// 提现已经超时作废或者不存在，拒绝这次签名，不能把作废的提现拉回来广播
+	if errors.Is(err, database.ErrStatusChanged) {
+		log.Warn("reject signature for withdraw not waiting for signature", "transactionId", transactionId, "err", err)
+		resp.Msg = "withdraw is not waiting for signature"
+		return resp, nil
+	}
 	if err != nil {
 		log.Error("update withdraw fail", "err", err)
 		return nil, err
 	}
+	if err := bws.db.NotifyApiCacheInvalidate(request.RequestId, database.ApiCacheWithdraws, database.ApiCacheInternals); err != nil {
+		log.Warn("notify api cache invalidate fail", "err", err)
+	}
 
 	retSignedTxn = append(retSignedTxn, retSign)
 	resp.Msg = "create signed tx success"
@@ -297,13 +543,52 @@ func (bws *BusinessMiddleWireServices) BuildSignedTransaction(ctx context.Contex
 	return resp, nil
 }
 
+// failWaitSignTransaction 签名交易构建失败时作废还在等待签名的提现或 CPFP 子交易并释放它占用的 utxo，业务方需要重新发起
+func (bws *BusinessMiddleWireServices) failWaitSignTransaction(requestId string, transactionId string) {
+	guid, err := uuid.Parse(transactionId)
+	if err != nil {
+		return
+	}
+	internal, err := bws.db.Internals.QueryInternalByGuid(requestId, transactionId)
+	if err != nil {
+		log.Error("query internal fail", "transactionId", transactionId, "err", err)
+		return
+	}
+	if internal != nil {
+		if internal.TxType != database.InternalTxTypeCpfp || internal.Status != database.TxStatusWaitSign {
+			return
+		}
+		if err := bws.db.FailInternals(requestId, []database.Internals{*internal}); err != nil {
+			log.Error("release cpfp reservation fail", "transactionId", transactionId, "err", err)
+		}
+		return
+	}
+	withdraw, err := bws.db.Withdraws.QueryWithdrawByGuid(requestId, guid)
+	if err != nil {
+		log.Error("query withdraw fail", "transactionId", transactionId, "err", err)
+		return
+	}
+	if withdraw == nil || withdraw.Status != database.TxStatusWaitSign {
+		return
+	}
+	if err := bws.db.FailWithdraws(requestId, []database.Withdraws{*withdraw}); err != nil {
+		log.Error("release withdraw utxos fail", "transactionId", transactionId, "err", err)
+	}
+}
+
 func (bws *BusinessMiddleWireServices) SubmitWithdraw(ctx context.Context, request *dal_wallet_go.SubmitWithdrawRequest) (*dal_wallet_go.SubmitWithdrawResponse, error) {
 	resp := &dal_wallet_go.SubmitWithdrawResponse{
 		Code: dal_wallet_go.ReturnCode_ERROR,
 		Msg:  "submit withdraw fail",
 	}
-	if request.ConsumerToken != ConsumerToken {
-		resp.Msg = "consumer token is error"
+	business, err := bws.db.Business.QueryBusinessByUuid(request.RequestId)
+	if err != nil {
+		log.Error("query business fail", "err", err)
+		resp.Msg = "business not exist"
+		return resp, nil
+	}
+	if !business.Active() {
+		resp.Msg = "business is " + business.Status
 		return resp, nil
 	}
 	var childTxList []database.ChildTxs
@@ -332,6 +617,7 @@ func (bws *BusinessMiddleWireServices) SubmitWithdraw(ctx context.Context, reque
 		LockTime:    big.NewInt(0),
 		Version:     "0x0",
 		TxSignHex:   "0x0",
+		SentHeight:  big.NewInt(0),
 		Status:      database.TxStatusWaitSign,
 		Timestamp:   withdrawTimeStamp,
 	}
@@ -346,7 +632,7 @@ func (bws *BusinessMiddleWireServices) SubmitWithdraw(ctx context.Context, reque
 			log.Error("store child txs fail", "err", err)
 			return err
 		}
-		return nil
+		return tx.NotifyApiCacheInvalidate(request.RequestId, database.ApiCacheWithdraws, database.ApiCacheTransactions)
 	}); err != nil {
 		log.Error("unable to persist withdraw tx batch", "err", err)
 		return nil, err
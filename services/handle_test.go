@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+)
+
+type signAddressesDB struct{ database.AddressesDB }
+
+func (db *signAddressesDB) QueryHotWalletInfo(string) (*database.Addresses, error) {
+	return &database.Addresses{PublicKey: "hot-public-key"}, nil
+}
+
+type signReplacementsDB struct {
+	database.WithdrawReplacementsDB
+}
+
+func (db *signReplacementsDB) QueryReplacementByGuid(string, string) (*database.WithdrawReplacements, error) {
+	return nil, nil
+}
+
+type signInternalsDB struct{ database.InternalsDB }
+
+func (db *signInternalsDB) QueryInternalByGuid(string, string) (*database.Internals, error) {
+	return nil, nil
+}
+
+// expiredWithdrawsDB 提现已经超时作废，签名写不进去
+type expiredWithdrawsDB struct{ database.WithdrawsDB }
+
+func (db *expiredWithdrawsDB) UpdateWithdrawByGuuid(_ string, transactionId string, _ string) error {
+	return fmt.Errorf("withdraw %s is not waiting for signature: %w", transactionId, database.ErrStatusChanged)
+}
+
+type signUtxoClient struct{ utxo.WalletUtxoServiceClient }
+
+func (c *signUtxoClient) BuildSignedTransaction(context.Context, *utxo.SignedTransactionRequest, ...grpc.CallOption) (*utxo.SignedTransactionResponse, error) {
+	return &utxo.SignedTransactionResponse{SignedTxData: []byte("signed")}, nil
+}
+
+func TestBuildSignedTransactionRejectsExpiredWithdraw(t *testing.T) {
+	bws := &BusinessMiddleWireServices{
+		BusinessMiddleConfig: &BusinessMiddleConfig{},
+		syncClient:           &syncclient.WalletBtcAccountClient{BtcRpcClient: &signUtxoClient{}},
+		db: &database.DB{
+			Addresses:            &signAddressesDB{},
+			WithdrawReplacements: &signReplacementsDB{},
+			Internals:            &signInternalsDB{},
+			Withdraws:            &expiredWithdrawsDB{},
+		},
+	}
+	resp, err := bws.BuildSignedTransaction(context.Background(), &dal_wallet_go.SignedWithdrawTransactionRequest{
+		RequestId: "biz",
+		SignTxn:   []*dal_wallet_go.SignedTransactions{{TransactionUuid: "withdraw-guid", TxData: "tx", Signature: "sig"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, dal_wallet_go.ReturnCode_ERROR, resp.Code)
+	require.Equal(t, "withdraw is not waiting for signature", resp.Msg)
+	require.Empty(t, resp.ReturnSignTxn)
+}
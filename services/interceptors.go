@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
+)
+
+// RequestIdHeader 请求关联 id，调用方没有传时由服务端生成，并通过响应头返回
+const RequestIdHeader = "x-request-id"
+
+// requestInfo 一次请求的关联信息，选出链之后补上链名，鉴权之后补上业务方，访问日志和指标在请求结束时输出
+type requestInfo struct {
+	RequestId  string
+	BusinessId string
+	Chain      string
+}
+
+type requestInfoKey struct{}
+
+// RequestIdFromContext 返回当前请求的关联 id
+func RequestIdFromContext(ctx context.Context) string {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		return info.RequestId
+	}
+	return ""
+}
+
+// requestIdUnaryInterceptor 给请求绑定关联 id
+func requestIdUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	requestId := metadataValue(ctx, RequestIdHeader)
+	if requestId == "" {
+		requestId = uuid.New().String()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIdHeader, requestId)); err != nil {
+		log.Warn("set request id header fail", "err", err)
+	}
+	return handler(context.WithValue(ctx, requestInfoKey{}, &requestInfo{RequestId: requestId}), req)
+}
+
+// loggingUnaryInterceptor 输出访问日志：方法、关联 id、业务方、返回码和耗时
+func loggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	ctxInfo, _ := ctx.Value(requestInfoKey{}).(*requestInfo)
+	if ctxInfo == nil {
+		ctxInfo = &requestInfo{}
+	}
+	code := status.Code(err)
+	if code == codes.OK || code == codes.Unauthenticated || code == codes.PermissionDenied || code == codes.ResourceExhausted {
+		log.Info("grpc access", "method", info.FullMethod, "requestId", ctxInfo.RequestId, "businessId", ctxInfo.BusinessId, "code", code, "latency", time.Since(start))
+	} else {
+		log.Warn("grpc access", "method", info.FullMethod, "requestId", ctxInfo.RequestId, "businessId", ctxInfo.BusinessId, "code", code, "latency", time.Since(start), "err", err)
+	}
+	return resp, err
+}
+
+// metricsUnaryInterceptor 按链、方法和返回码记录请求数和耗时，在选出链之前就被拒绝的请求链名记为 unknown
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	chain := "unknown"
+	if ctxInfo, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok && ctxInfo.Chain != "" {
+		chain = ctxInfo.Chain
+	}
+	metrics.RecordRpcRequest(chain, metrics.MethodName(info.FullMethod), status.Code(err).String(), time.Since(start))
+	return resp, err
+}
+
+// recoveryUnaryInterceptor 处理请求时 panic 不会让服务退出，返回 Internal
+func recoveryUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("grpc handler panic", "method", info.FullMethod, "requestId", RequestIdFromContext(ctx), "panic", r, "stack", string(debug.Stack()))
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// peerRateLimitUnaryInterceptor 鉴权之前按客户端地址做令牌桶限流，无效 api key 的请求也会被限流，不会打满鉴权查询；
+// 同一地址的不同端口算同一个客户端
+func (bws *BusinessMiddleWireServices) peerRateLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	if !bws.peerLimiter.Allow(peerHost(ctx)) {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return handler(ctx, req)
+}
+
+// peerHost 返回客户端地址去掉端口之后的部分，取不到时返回空串，所有取不到地址的请求共用一个桶
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// rateLimitUnaryInterceptor 按鉴权之后的业务方做令牌桶限流，管理员请求不限流
+func (bws *BusinessMiddleWireServices) rateLimitUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	business, ok := AuthBusinessFromContext(ctx)
+	if ok && !bws.limiter.Allow(business.BusinessUid) {
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return handler(ctx, req)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/ratelimit"
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
+)
+
+func TestRequestIdUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test"}
+	var requestId string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestId = RequestIdFromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIdHeader, "client-id"))
+	_, err := requestIdUnaryInterceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "client-id", requestId)
+
+	// 调用方没有传时由服务端生成，每个请求不同
+	_, err = requestIdUnaryInterceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	generated := requestId
+	require.NotEmpty(t, generated)
+	_, err = requestIdUnaryInterceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	require.NotEqual(t, generated, requestId)
+
+	require.Empty(t, RequestIdFromContext(context.Background()))
+}
+
+func TestLoggingUnaryInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	root := log.Root()
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(&buf, log.LevelInfo, false)))
+	defer log.SetDefault(root)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+	ctx := context.WithValue(context.Background(), requestInfoKey{}, &requestInfo{RequestId: "req-1"})
+	resp, err := loggingUnaryInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		// 鉴权之后补上的业务方会出现在访问日志里
+		ctx.Value(requestInfoKey{}).(*requestInfo).BusinessId = "biz"
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+	require.Contains(t, buf.String(), "INFO")
+	require.Contains(t, buf.String(), "method=/test/Method")
+	require.Contains(t, buf.String(), "requestId=req-1")
+	require.Contains(t, buf.String(), "businessId=biz")
+
+	buf.Reset()
+	_, err = loggingUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("db down")
+	})
+	require.EqualError(t, err, "db down")
+	require.Contains(t, buf.String(), "WARN")
+	require.Contains(t, buf.String(), "db down")
+
+	// 鉴权和限流失败是正常的业务结果，不按错误输出
+	buf.Reset()
+	_, err = loggingUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "invalid api key")
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Contains(t, buf.String(), "INFO")
+}
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test"}
+	resp, err := recoveryUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("nil map")
+	})
+	require.Nil(t, resp)
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	resp, err = recoveryUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+}
+
+func TestMetricsUnaryInterceptor(t *testing.T) {
+	ltc := &BusinessMiddleWireServices{BusinessMiddleConfig: &BusinessMiddleConfig{Chain: config.ChainProfile{Key: "ltc"}}}
+	bws := &BusinessMiddleWireServices{
+		BusinessMiddleConfig: &BusinessMiddleConfig{Chain: config.ChainProfile{Key: "btc"}},
+		chains:               map[string]*BusinessMiddleWireServices{},
+	}
+	bws.AddChain(ltc)
+	info := &grpc.UnaryServerInfo{FullMethod: "/dapplink.wallet.BusinessMiddleWireServices/getMetricsTest"}
+	call := func(chain string) error {
+		ctx := context.WithValue(context.Background(), requestInfoKey{}, &requestInfo{})
+		if chain != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(ChainHeader, chain))
+		}
+		_, err := metricsUnaryInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return bws.chainUnaryInterceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			})
+		})
+		return err
+	}
+	require.NoError(t, call(""))
+	require.NoError(t, call("LTC"))
+	require.Equal(t, codes.InvalidArgument, status.Code(call("doge")))
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	require.Contains(t, body, `multichain_sync_rpc_requests_total{chain="btc",method="getMetricsTest",code="OK"} 1`)
+	require.Contains(t, body, `multichain_sync_rpc_requests_total{chain="ltc",method="getMetricsTest",code="OK"} 1`)
+	// 未知的链在选出链之前就被拒绝，链名记为 unknown
+	require.Contains(t, body, `multichain_sync_rpc_requests_total{chain="unknown",method="getMetricsTest",code="InvalidArgument"} 1`)
+	require.Contains(t, body, `multichain_sync_rpc_request_duration_seconds_count{chain="btc",method="getMetricsTest"} 1`)
+}
+
+func TestPeerRateLimitUnaryInterceptor(t *testing.T) {
+	bws := &BusinessMiddleWireServices{peerLimiter: ratelimit.NewLimiter(1, 2)}
+	info := &grpc.UnaryServerInfo{FullMethod: "/test"}
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+	peerCtx := func(addr string) context.Context {
+		tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+		require.NoError(t, err)
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+	}
+
+	// 同一地址的不同端口共用一个桶，超过之后在鉴权之前就返回
+	for _, addr := range []string{"10.0.0.1:5000", "10.0.0.1:5001"} {
+		_, err := bws.peerRateLimitUnaryInterceptor(peerCtx(addr), nil, info, handler)
+		require.NoError(t, err)
+	}
+	_, err := bws.peerRateLimitUnaryInterceptor(peerCtx("10.0.0.1:5002"), nil, info, handler)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, 2, calls)
+
+	_, err = bws.peerRateLimitUnaryInterceptor(peerCtx("10.0.0.2:5000"), nil, info, handler)
+	require.NoError(t, err)
+
+	// 探针不限流
+	_, err = bws.peerRateLimitUnaryInterceptor(peerCtx("10.0.0.1:5003"), nil, &grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName}, handler)
+	require.NoError(t, err)
+	require.Equal(t, 4, calls)
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// applyBusinessPolicy 返回用配置文件里同一业务方的策略补齐后的注册请求，不修改传入的请求；
+// 请求里的费率上下限、确认阈值为 0 视为没有填写，沿用策略的值，选币策略和费率档位为空时同样沿用
+func (bws *BusinessMiddleWireServices) applyBusinessPolicy(request *dal_wallet_go.BusinessRegisterRequest) *dal_wallet_go.BusinessRegisterRequest {
+	for _, policy := range bws.BusinessPolicies {
+		if policy.RequestId != request.RequestId {
+			continue
+		}
+		merged := proto.Clone(request).(*dal_wallet_go.BusinessRegisterRequest)
+		if merged.CoinSelection == "" {
+			merged.CoinSelection = policy.CoinSelection
+		}
+		if merged.FeePriority == "" {
+			merged.FeePriority = policy.FeePriority
+		}
+		if merged.MinFeeRate == 0 {
+			merged.MinFeeRate = policy.MinFeeRate
+		}
+		if merged.MaxFeeRate == 0 {
+			merged.MaxFeeRate = policy.MaxFeeRate
+		}
+		if merged.SafeConfirms == 0 {
+			merged.SafeConfirms = policy.SafeConfirms
+		}
+		if merged.FinalizedConfirms == 0 {
+			merged.FinalizedConfirms = policy.FinalizedConfirms
+		}
+		return merged
+	}
+	return request
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+func TestApplyBusinessPolicy(t *testing.T) {
+	bws := &BusinessMiddleWireServices{BusinessMiddleConfig: &BusinessMiddleConfig{
+		BusinessPolicies: []config.BusinessPolicy{
+			{RequestId: "exchange", CoinSelection: "knapsack", FeePriority: "fast", MinFeeRate: 2, SafeConfirms: 3, FinalizedConfirms: 6},
+		},
+	}}
+
+	request := &dal_wallet_go.BusinessRegisterRequest{RequestId: "exchange", FeePriority: "economy", SafeConfirms: 1}
+	merged := bws.applyBusinessPolicy(request)
+	// 请求里填写的字段优先，0 和空值沿用策略
+	require.Equal(t, "knapsack", merged.CoinSelection)
+	require.Equal(t, "economy", merged.FeePriority)
+	require.Equal(t, int64(2), merged.MinFeeRate)
+	require.Equal(t, uint32(1), merged.SafeConfirms)
+	require.Equal(t, uint32(6), merged.FinalizedConfirms)
+	// 传入的请求保持不变
+	require.Empty(t, request.CoinSelection)
+	require.Zero(t, request.FinalizedConfirms)
+
+	other := &dal_wallet_go.BusinessRegisterRequest{RequestId: "shop"}
+	require.Same(t, other, bws.applyBusinessPolicy(other))
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	dal_wallet_go "github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+)
+
+// QueryDeposits 分页查询业务方的充值记录
+func (bws *BusinessMiddleWireServices) QueryDeposits(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return bws.queryTransactionsPage(request, func(query database.TxQuery) ([]*dal_wallet_go.TransactionRecord, string, error) {
+		depositList, nextCursor, err := bws.db.Reader().Deposits.QueryDepositsPage(request.RequestId, query)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]*dal_wallet_go.TransactionRecord, 0, len(depositList))
+		for _, deposit := range depositList {
+			records = append(records, &dal_wallet_go.TransactionRecord{
+				Guid:        deposit.GUID.String(),
+				BlockHash:   deposit.BlockHash,
+				BlockNumber: bigIntString(deposit.BlockNumber),
+				Hash:        deposit.Hash,
+				Fee:         bigIntString(deposit.Fee),
+				TxType:      "deposit",
+				Status:      string(deposit.Status),
+				Confirms:    uint32(deposit.Confirms),
+				Timestamp:   deposit.Timestamp,
+			})
+		}
+		return records, nextCursor, nil
+	})
+}
+
+// QueryWithdraws 分页查询业务方的提现记录
+func (bws *BusinessMiddleWireServices) QueryWithdraws(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return bws.queryTransactionsPage(request, func(query database.TxQuery) ([]*dal_wallet_go.TransactionRecord, string, error) {
+		withdrawList, nextCursor, err := bws.db.Reader().Withdraws.QueryWithdrawsPage(request.RequestId, query)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]*dal_wallet_go.TransactionRecord, 0, len(withdrawList))
+		for _, withdraw := range withdrawList {
+			records = append(records, &dal_wallet_go.TransactionRecord{
+				Guid:         withdraw.Guid.String(),
+				BlockHash:    withdraw.BlockHash,
+				BlockNumber:  bigIntString(withdraw.BlockNumber),
+				Hash:         withdraw.Hash,
+				Fee:          bigIntString(withdraw.Fee),
+				TxType:       "withdraw",
+				Status:       string(withdraw.Status),
+				Vsize:        withdraw.VSize,
+				FeeRate:      withdraw.FeeRate,
+				ReplaceCount: int32(withdraw.ReplaceCount),
+				Timestamp:    withdraw.Timestamp,
+			})
+		}
+		return records, nextCursor, nil
+	})
+}
+
+// QueryInternals 分页查询业务方的内部交易，tx_type 可以按归集、冷热互转和 cpfp 过滤
+func (bws *BusinessMiddleWireServices) QueryInternals(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return bws.queryTransactionsPage(request, func(query database.TxQuery) ([]*dal_wallet_go.TransactionRecord, string, error) {
+		internalList, nextCursor, err := bws.db.Reader().Internals.QueryInternalsPage(request.RequestId, query)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]*dal_wallet_go.TransactionRecord, 0, len(internalList))
+		for _, internal := range internalList {
+			records = append(records, &dal_wallet_go.TransactionRecord{
+				Guid:        internal.Guid.String(),
+				BlockHash:   internal.BlockHash,
+				BlockNumber: bigIntString(internal.BlockNumber),
+				Hash:        internal.Hash,
+				Fee:         bigIntString(internal.Fee),
+				TxType:      internal.TxType,
+				Status:      string(internal.Status),
+				ParentHash:  internal.ParentHash,
+				Timestamp:   internal.Timestamp,
+			})
+		}
+		return records, nextCursor, nil
+	})
+}
+
+// QueryTransactions 分页查询扫块记录的交易流水
+func (bws *BusinessMiddleWireServices) QueryTransactions(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	return bws.queryTransactionsPage(request, func(query database.TxQuery) ([]*dal_wallet_go.TransactionRecord, string, error) {
+		transactionList, nextCursor, err := bws.db.Reader().Transactions.QueryTransactionsPage(request.RequestId, query)
+		if err != nil {
+			return nil, "", err
+		}
+		records := make([]*dal_wallet_go.TransactionRecord, 0, len(transactionList))
+		for _, transaction := range transactionList {
+			records = append(records, transactionRecord(&transaction))
+		}
+		return records, nextCursor, nil
+	})
+}
+
+// QueryChildTxs 分页查询子交易，hash 可以是交易哈希，也可以是提现或内部交易的 guid
+func (bws *BusinessMiddleWireServices) QueryChildTxs(ctx context.Context, request *dal_wallet_go.QueryTransactionsRequest) (*dal_wallet_go.QueryChildTxsResponse, error) {
+	resp := &dal_wallet_go.QueryChildTxsResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "query child txs fail",
+	}
+	query, ok := txQueryFromRequest(request)
+	if !ok {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+	childTxList, nextCursor, err := bws.db.Reader().ChildTxs.QueryChildTxsPage(request.RequestId, query)
+	if errors.Is(err, database.ErrInvalidCursor) {
+		resp.Msg = "invalid cursor"
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	resp.ChildTxs = childTxRecords(childTxList)
+	resp.NextCursor = nextCursor
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "query child txs success"
+	return resp, nil
+}
+
+// GetTransaction 按哈希查询一笔交易的详情，包括输入、输出和子交易
+func (bws *BusinessMiddleWireServices) GetTransaction(ctx context.Context, request *dal_wallet_go.GetTransactionRequest) (*dal_wallet_go.GetTransactionResponse, error) {
+	resp := &dal_wallet_go.GetTransactionResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "get transaction fail",
+	}
+	if request.Hash == "" {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+	reader := bws.db.Reader()
+	transaction, err := reader.Transactions.QueryTransactionByHash(request.RequestId, request.Hash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Msg = "transaction not exist"
+		return resp, nil
+	} else if err != nil {
+		log.Error("query transaction by hash fail", "hash", request.Hash, "err", err)
+		return nil, err
+	}
+	vinList, err := reader.Vins.QueryVinsBySpendTxHash(request.RequestId, request.Hash)
+	if err != nil {
+		log.Error("query vins fail", "hash", request.Hash, "err", err)
+		return nil, err
+	}
+	voutList, err := reader.Vouts.QueryVoutsByTxId(request.RequestId, request.Hash)
+	if err != nil {
+		log.Error("query vouts fail", "hash", request.Hash, "err", err)
+		return nil, err
+	}
+	childTxList, err := reader.ChildTxs.QueryChildTxsByHash(request.RequestId, request.Hash)
+	if err != nil {
+		return nil, err
+	}
+	resp.Transaction = transactionRecord(transaction)
+	for _, vin := range vinList {
+		resp.Vins = append(resp.Vins, &dal_wallet_go.VinRecord{
+			Address: vin.Address,
+			TxId:    vin.TxId,
+			Vout:    vin.Vout,
+			Amount:  bigIntString(vin.Amount),
+		})
+	}
+	for _, vout := range voutList {
+		resp.Vouts = append(resp.Vouts, &dal_wallet_go.VoutRecord{
+			Address: vout.Address,
+			N:       vout.N,
+			Amount:  bigIntString(vout.Amount),
+			Script:  vout.Script,
+		})
+	}
+	resp.ChildTxs = childTxRecords(childTxList)
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "get transaction success"
+	return resp, nil
+}
+
+func (bws *BusinessMiddleWireServices) queryTransactionsPage(request *dal_wallet_go.QueryTransactionsRequest, queryPage func(database.TxQuery) ([]*dal_wallet_go.TransactionRecord, string, error)) (*dal_wallet_go.QueryTransactionsResponse, error) {
+	resp := &dal_wallet_go.QueryTransactionsResponse{
+		Code: dal_wallet_go.ReturnCode_ERROR,
+		Msg:  "query transactions fail",
+	}
+	query, ok := txQueryFromRequest(request)
+	if !ok {
+		resp.Msg = "invalid params"
+		return resp, nil
+	}
+	records, nextCursor, err := queryPage(query)
+	if errors.Is(err, database.ErrInvalidCursor) {
+		resp.Msg = "invalid cursor"
+		return resp, nil
+	} else if err != nil {
+		return nil, err
+	}
+	resp.Records = records
+	resp.NextCursor = nextCursor
+	resp.Code = dal_wallet_go.ReturnCode_SUCCESS
+	resp.Msg = "query transactions success"
+	return resp, nil
+}
+
+// txQueryFromRequest 把请求转换成查询条件，区间的下限大于上限时视为非法参数
+func txQueryFromRequest(request *dal_wallet_go.QueryTransactionsRequest) (database.TxQuery, bool) {
+	if request.RequestId == "" {
+		return database.TxQuery{}, false
+	}
+	if request.ToHeight > 0 && request.FromHeight > request.ToHeight {
+		return database.TxQuery{}, false
+	}
+	if request.ToTime > 0 && request.FromTime > request.ToTime {
+		return database.TxQuery{}, false
+	}
+	query := database.TxQuery{
+		Guid:       request.Guid,
+		Address:    request.Address,
+		Hash:       request.Hash,
+		TxType:     request.TxType,
+		FromHeight: request.FromHeight,
+		ToHeight:   request.ToHeight,
+		FromTime:   request.FromTime,
+		ToTime:     request.ToTime,
+		Cursor:     request.Cursor,
+		Limit:      int(request.Limit),
+	}
+	for _, status := range request.Status {
+		query.Status = append(query.Status, database.TxStatus(status))
+	}
+	return query, true
+}
+
+func transactionRecord(transaction *database.Transactions) *dal_wallet_go.TransactionRecord {
+	return &dal_wallet_go.TransactionRecord{
+		Guid:        transaction.GUID.String(),
+		BlockHash:   transaction.BlockHash,
+		BlockNumber: bigIntString(transaction.BlockNumber),
+		Hash:        transaction.Hash,
+		Fee:         bigIntString(transaction.Fee),
+		TxType:      transaction.TxType,
+		Status:      string(transaction.Status),
+		Timestamp:   transaction.Timestamp,
+	}
+}
+
+func childTxRecords(childTxList []database.ChildTxs) []*dal_wallet_go.ChildTxRecord {
+	records := make([]*dal_wallet_go.ChildTxRecord, 0, len(childTxList))
+	for _, childTx := range childTxList {
+		records = append(records, &dal_wallet_go.ChildTxRecord{
+			Guid:        childTx.GUID.String(),
+			Hash:        childTx.Hash,
+			TxId:        childTx.TxId,
+			TxIndex:     bigIntString(childTx.TxIndex),
+			TxType:      childTx.TxType,
+			FromAddress: childTx.FromAddress,
+			ToAddress:   childTx.ToAddress,
+			Amount:      childTx.Amount,
+			Timestamp:   childTx.Timestamp,
+		})
+	}
+	return records
+}
+
+func bigIntString(value *big.Int) string {
+	if value == nil {
+		return "0"
+	}
+	return value.String()
+}
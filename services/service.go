@@ -2,76 +2,213 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 	"net"
 	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
+	"github.com/dapplink-labs/multichain-sync-btc/common/ratelimit"
+	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
 	"github.com/dapplink-labs/multichain-sync-btc/protobuf/dal-wallet-go"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 )
 
-const MaxRecvMessageSize = 1024 * 1024 * 300
+const (
+	MaxRecvMessageSize     = 1024 * 1024 * 300
+	defaultShutdownTimeout = 10 * time.Second
+)
 
 type BusinessMiddleConfig struct {
 	GrpcHostname string
 	GrpcPort     int
-	ChainName    string
-	NetWork      string
-	CoinName     string
+	// Chain 所选链的配置，链名、网络、币种、地址格式、粉尘阈值和最低费率都从这里取
+	Chain           config.ChainProfile
+	AdminToken      string
+	RateLimit       float64
+	RateBurst       int
+	PeerRateLimit   float64
+	PeerRateBurst   int
+	ShutdownTimeout time.Duration
+	// ScannerLag 扫块落后链上最新高度的区块数，计算确认数时加上
+	ScannerLag uint64
+	// FinalizedConfirms 余额查询中 utxo 视为已确认的确认数，业务方注册时配置的优先
+	FinalizedConfirms uint64
+	ApiCacheEnable    bool
+	CacheConfig       config.CacheConfig
+	// HealthServer 非空时注册到 rpc 端口上，和业务接口共用一个端口
+	HealthServer healthpb.HealthServer
+	// TenantSchemas 所有链的 schema，注册和注销业务方时每条链的表一起创建和归档
+	TenantSchemas []string
+	// BusinessPolicies 配置文件里预设的业务方策略，注册时补齐请求里没有填写的字段
+	BusinessPolicies []config.BusinessPolicy
 }
 
 type BusinessMiddleWireServices struct {
 	*BusinessMiddleConfig
 	syncClient *syncclient.WalletBtcAccountClient
 	db         *database.DB
+	limiter    *ratelimit.Limiter
+	server     *grpc.Server
+	serveDone  chan struct{}
+	shutdown   context.CancelCauseFunc
 	stopped    atomic.Bool
+	chains     map[string]*BusinessMiddleWireServices
+
+	// peerLimiter 鉴权之前按客户端地址限流，避免没有合法 api key 的请求打满鉴权查询
+	peerLimiter *ratelimit.Limiter
+
+	apiCache     *cache.ApiCache
+	cacheEntries map[string]cache.Entry[proto.Message]
+	stopCache    context.CancelFunc
+	cacheDone    chan struct{}
 }
 
+// Stop 优雅停止 grpc 服务，等待处理中的请求结束，超过 ShutdownTimeout 或 ctx 取消时强制关闭
 func (bws *BusinessMiddleWireServices) Stop(ctx context.Context) error {
+	if bws.server == nil {
+		err := bws.closeChains()
+		bws.stopped.Store(true)
+		return err
+	}
+	timeout := bws.ShutdownTimeout
+	if timeout == 0 {
+		timeout = defaultShutdownTimeout
+	}
+	stopCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	graceful := make(chan struct{})
+	go func() {
+		bws.server.GracefulStop()
+		close(graceful)
+	}()
+	var result error
+	select {
+	case <-graceful:
+		log.Info("grpc server stopped gracefully")
+	case <-stopCtx.Done():
+		log.Warn("grpc graceful stop timeout, force stop", "timeout", timeout)
+		bws.server.Stop()
+		result = fmt.Errorf("grpc graceful stop: %w", stopCtx.Err())
+	}
+	<-bws.serveDone
+	if bws.stopCache != nil {
+		bws.stopCache()
+		<-bws.cacheDone
+	}
+	bws.apiCache.Close()
+	result = errors.Join(result, bws.closeChains())
 	bws.stopped.Store(true)
-	return nil
+	return result
+}
+
+// closeChains 其他链的服务不单独监听，跟着主链一起停止；请求处理完后关闭所有链的数据库连接
+func (bws *BusinessMiddleWireServices) closeChains() error {
+	var result error
+	for key, chain := range bws.chains {
+		if chain != bws {
+			chain.stopped.Store(true)
+		}
+		if chain.db == nil {
+			continue
+		}
+		if err := chain.db.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("close chain %s database: %w", key, err))
+		}
+	}
+	return result
 }
 
 func (bws *BusinessMiddleWireServices) Stopped() bool {
 	return bws.stopped.Load()
 }
 
-func NewBusinessMiddleWireServices(db *database.DB, config *BusinessMiddleConfig, syncClient *syncclient.WalletBtcAccountClient) (*BusinessMiddleWireServices, error) {
-	return &BusinessMiddleWireServices{
+func NewBusinessMiddleWireServices(db *database.DB, config *BusinessMiddleConfig, syncClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*BusinessMiddleWireServices, error) {
+	var apiCache *cache.ApiCache
+	if config.ApiCacheEnable {
+		var err error
+		apiCache, err = cache.NewApiCache(config.CacheConfig)
+		if err != nil {
+			log.Error("create api cache fail", "err", err)
+			return nil, err
+		}
+	}
+	bws := &BusinessMiddleWireServices{
 		BusinessMiddleConfig: config,
 		syncClient:           syncClient,
 		db:                   db,
-	}, nil
+		limiter:              ratelimit.NewLimiter(config.RateLimit, config.RateBurst),
+		peerLimiter:          ratelimit.NewLimiter(config.PeerRateLimit, config.PeerRateBurst),
+		shutdown:             shutdown,
+		chains:               make(map[string]*BusinessMiddleWireServices),
+		apiCache:             apiCache,
+		cacheEntries:         newCacheEntries(apiCache),
+	}
+	bws.chains[config.Chain.Key] = bws
+	return bws, nil
 }
 
+// Start 监听失败直接返回错误；服务运行中出错时通过 shutdown 通知生命周期退出
 func (bws *BusinessMiddleWireServices) Start(ctx context.Context) error {
-	go func(bws *BusinessMiddleWireServices) {
-		addr := fmt.Sprintf("%s:%d", bws.GrpcHostname, bws.GrpcPort)
-		log.Info("start rpc server", "addr", addr)
-		listener, err := net.Listen("tcp", addr)
-		if err != nil {
-			log.Error("Could not start tcp listener. ")
-		}
-		gs := grpc.NewServer(
-			grpc.MaxRecvMsgSize(MaxRecvMessageSize),
-			grpc.ChainUnaryInterceptor(
-				nil,
-			),
-		)
-		reflection.Register(gs)
-
-		dal_wallet_go.RegisterBusinessMiddleWireServicesServer(gs, bws)
-
-		log.Info("Grpc info", "port", bws.GrpcPort, "address", listener.Addr())
-		if err := gs.Serve(listener); err != nil {
-			log.Error("Could not GRPC server")
+	if bws.AdminToken == "" {
+		log.Warn("rpc admin token is not configured, business management apis are disabled")
+	}
+	addr := fmt.Sprintf("%s:%d", bws.GrpcHostname, bws.GrpcPort)
+	log.Info("start rpc server", "addr", addr)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Error("Could not start tcp listener", "addr", addr, "err", err)
+		return err
+	}
+	gs := grpc.NewServer(
+		grpc.MaxRecvMsgSize(MaxRecvMessageSize),
+		grpc.ChainUnaryInterceptor(
+			requestIdUnaryInterceptor,
+			loggingUnaryInterceptor,
+			metricsUnaryInterceptor,
+			recoveryUnaryInterceptor,
+			bws.peerRateLimitUnaryInterceptor,
+			bws.chainUnaryInterceptor,
+			bws.authUnaryInterceptor,
+			bws.rateLimitUnaryInterceptor,
+			bws.cacheUnaryInterceptor,
+		),
+	)
+	reflection.Register(gs)
+
+	dal_wallet_go.RegisterBusinessMiddleWireServicesServer(gs, &chainRouter{primary: bws})
+	if bws.HealthServer != nil {
+		healthpb.RegisterHealthServer(gs, bws.HealthServer)
+	}
+	bws.server = gs
+	bws.serveDone = make(chan struct{})
+
+	if bws.apiCache != nil {
+		cacheCtx, stopCache := context.WithCancel(context.Background())
+		bws.stopCache = stopCache
+		bws.cacheDone = make(chan struct{})
+		go bws.listenCacheInvalidation(cacheCtx)
+	}
+
+	log.Info("Grpc info", "port", bws.GrpcPort, "address", listener.Addr())
+	go func() {
+		defer close(bws.serveDone)
+		if err := gs.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Error("Could not GRPC server", "err", err)
+			if bws.shutdown != nil {
+				bws.shutdown(fmt.Errorf("grpc server: %w", err))
+			}
 		}
-	}(bws)
+	}()
 	return nil
 }
@@ -11,20 +11,23 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/google/uuid"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
 	"github.com/dapplink-labs/multichain-sync-btc/common/retry"
 	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 )
 
 type Deposit struct {
 	BaseSynchronizer
-	confirms       uint8
-	latestHeader   syncclient.BlockHeader
-	resourceCtx    context.Context
-	resourceCancel context.CancelFunc
-	tasks          tasks.Group
+	safeConfirms      uint64
+	finalizedConfirms uint64
+	latestHeader      syncclient.BlockHeader
+	resourceCtx       context.Context
+	resourceCancel    context.CancelFunc
+	tasks             tasks.Group
 }
 
 func NewDeposit(cfg *config.Config, db *database.DB, rpcClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*Deposit, error) {
@@ -54,24 +57,41 @@ func NewDeposit(cfg *config.Config, db *database.DB, rpcClient *syncclient.Walle
 		fromHeader = chainLatestBlockHeader
 	}
 
+	// 启动时先记下起始高度，第一批处理完之前落后的区块数也是准确的
+	metrics.RecordSyncHeight(cfg.Chain.Key, fromHeader.Number.Uint64())
+
 	businessTxChannel := make(chan map[string]*TransactionsChannel)
 
 	baseSyncer := BaseSynchronizer{
-		loopInterval:     cfg.ChainNode.SynchronizerInterval,
-		headerBufferSize: cfg.ChainNode.BlocksStep,
-		businessChannels: businessTxChannel,
-		rpcClient:        rpcClient,
-		blockBatch:       syncclient.NewBatchBlock(rpcClient, fromHeader, big.NewInt(int64(cfg.ChainNode.Confirmations))),
-		database:         db,
+		chain:                 cfg.Chain.Key,
+		loopInterval:          cfg.ChainNode.SynchronizerInterval,
+		headerBufferSize:      cfg.ChainNode.BlocksStep,
+		blockFetchConcurrency: int(cfg.ChainNode.BlockFetchConcurrency),
+		businessChannels:      businessTxChannel,
+		rpcClient:             rpcClient,
+		blockBatch:            syncclient.NewBatchBlock(rpcClient, fromHeader, big.NewInt(int64(cfg.ChainNode.Confirmations)), cfg.ChainNode.HeaderFetchConcurrency),
+		database:              db,
+		addressIndex:          cache.GetAddressIndex(cfg.Chain.Key),
+	}
+
+	businessList, err := db.Business.QueryBusinessList()
+	if err != nil {
+		log.Error("query business list fail", "err", err)
+		return nil, err
+	}
+	if err := baseSyncer.refreshAddressIndex(businessList); err != nil {
+		log.Error("load address index fail", "err", err)
+		return nil, err
 	}
 
 	resCtx, resCancel := context.WithCancel(context.Background())
 
 	return &Deposit{
-		BaseSynchronizer: baseSyncer,
-		confirms:         uint8(cfg.ChainNode.Confirmations),
-		resourceCtx:      resCtx,
-		resourceCancel:   resCancel,
+		BaseSynchronizer:  baseSyncer,
+		safeConfirms:      uint64(cfg.ChainNode.SafeConfirmations),
+		finalizedConfirms: uint64(cfg.ChainNode.FinalizedConfirmations),
+		resourceCtx:       resCtx,
+		resourceCancel:    resCancel,
 		tasks: tasks.Group{HandleCrit: func(err error) {
 			shutdown(fmt.Errorf("critical error in deposit: %w", err))
 		}},
@@ -108,6 +128,21 @@ func (deposit *Deposit) Start() error {
 	return nil
 }
 
+// confirmThresholds 返回业务方的 safe 和 finalized 确认阈值，业务方没有配置时使用全局配置
+func (deposit *Deposit) confirmThresholds(business database.Business) (uint64, uint64) {
+	safeConfirms, finalizedConfirms := deposit.safeConfirms, deposit.finalizedConfirms
+	if business.FinalizedConfirms > 0 {
+		finalizedConfirms = business.FinalizedConfirms
+	}
+	if business.SafeConfirms > 0 {
+		safeConfirms = business.SafeConfirms
+	}
+	if safeConfirms > finalizedConfirms {
+		safeConfirms = finalizedConfirms
+	}
+	return safeConfirms, finalizedConfirms
+}
+
 func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error {
 	businessList, err := deposit.database.Business.QueryBusinessList()
 	if err != nil {
@@ -132,19 +167,12 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 			vins                        []database.Vins
 			vouts                       []database.Vouts
 			balances                    []database.TokenBalance
+			utxos                       []database.Utxos
+			spentOutpoints              []database.SpentOutpoint
 		)
 
-		log.Info("handle business flow", "businessId", business.BusinessUid, "chainLatestBlock", batch[business.BusinessUid].BlockHeight, "txn", len(batch[business.BusinessUid].Transactions))
-		var pvList []*PrepareVoutList
+		log.Info("handle business flow", "businessId", business.BusinessUid, "batchBlock", batch[business.BusinessUid].BlockHeight, "chainTip", batch[business.BusinessUid].ChainTip, "txn", len(batch[business.BusinessUid].Transactions))
 		for _, tx := range batch[business.BusinessUid].Transactions {
-
-			txItem, err := deposit.rpcClient.GetTransactionByHash(tx.Hash)
-			if err != nil {
-				log.Info("get transaction by hash fail", "err", err)
-				return err
-			}
-
-			log.Info("get transaction success", "txHash", txItem.Hash)
 			transactionFlow, transactionFlowChildTxs, err := deposit.HandleTransaction(tx)
 			if err != nil {
 				log.Info("handle  transaction fail", "err", err)
@@ -166,9 +194,11 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 			}
 			balances = append(balances, voutBalances...)
 
-			pvList = append(pvList, voutListPre)
-			vlist := voutListPre.VoutList
-			vouts = append(vouts, vlist...)
+			vouts = append(vouts, voutListPre.VoutList...)
+
+			txUtxos, txSpentOutpoints := deposit.HandleUtxo(tx, business.BusinessUid)
+			utxos = append(utxos, txUtxos...)
+			spentOutpoints = append(spentOutpoints, txSpentOutpoints...)
 
 			switch tx.TxType {
 			case "deposit":
@@ -195,15 +225,26 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 			if err := deposit.database.Transaction(func(tx *database.DB) error {
 				if len(depositList) > 0 {
 					log.Info("Store deposit transaction success", "totalTx", len(depositList))
-					if err := tx.Deposits.StoreDeposits(business.BusinessUid, depositList); err != nil {
+					// 内存池里已经入库的充值补上区块信息，其余的新写入
+					newDepositList, err := tx.Deposits.ReconcileMempoolDeposits(business.BusinessUid, depositList)
+					if err != nil {
 						return err
 					}
+					if len(newDepositList) > 0 {
+						if err := tx.Deposits.StoreDeposits(business.BusinessUid, newDepositList); err != nil {
+							return err
+						}
+					}
 
-					if err := tx.ChildTxs.StoreChildTxs(business.BusinessUid, depositListChildTxFlowList); err != nil {
-						return err
+					// 内存池阶段已经写过 child_txs 的充值不再重复写入
+					if childTxs := childTxsOfDeposits(depositListChildTxFlowList, newDepositList); len(childTxs) > 0 {
+						if err := tx.ChildTxs.StoreChildTxs(business.BusinessUid, childTxs); err != nil {
+							return err
+						}
 					}
 				}
-				if err := tx.Deposits.UpdateDepositsComfirms(business.BusinessUid, batch[business.BusinessUid].BlockHeight, uint64(deposit.confirms)); err != nil {
+				safeConfirms, finalizedConfirms := deposit.confirmThresholds(business)
+				if err := tx.Deposits.UpdateDepositsComfirms(business.BusinessUid, batch[business.BusinessUid].ChainTip, safeConfirms, finalizedConfirms); err != nil {
 					log.Info("Handle confims fail", "totalTx", "err", err)
 					return err
 				}
@@ -214,7 +255,7 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 					}
 				}
 				if len(withdrawList) > 0 {
-					if err := tx.Withdraws.UpdateWithdrawStatus(business.BusinessUid, database.TxStatusWithdrawed, withdrawList); err != nil {
+					if err := tx.Withdraws.UpdateWithdrawStatusByTxHash(business.BusinessUid, database.TxStatusWithdrawed, withdrawList); err != nil {
 						return err
 					}
 					if err := tx.ChildTxs.StoreChildTxs(business.BusinessUid, withdrawListChildTxFlowList); err != nil {
@@ -222,7 +263,7 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 					}
 				}
 				if len(internals) > 0 {
-					if err := tx.Internals.UpdateInternalStatus(business.BusinessUid, database.TxStatusSuccess, internals); err != nil {
+					if err := tx.Internals.UpdateInternalStatusByTxHash(business.BusinessUid, database.TxStatusWithdrawed, internals); err != nil {
 						return err
 					}
 					if err := tx.ChildTxs.StoreChildTxs(business.BusinessUid, internalsChildTxFlowList); err != nil {
@@ -248,18 +289,22 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 					}
 				}
 
-				if len(pvList) > 0 {
-					for _, pvItem := range pvList {
-						for _, voutItmepv := range pvItem.VoutList {
-							if err := tx.Vins.UpdateVinsTx(business.BusinessUid, pvItem.TxId, voutItmepv.Address, true, pvItem.TxId, pvItem.BlockNumber); err != nil {
-								return err
-							}
-						}
+				// 先写入本批次新产生的 utxo，再标记花费，同一批次里产生又被花掉的 utxo 也能正确标记
+				if len(utxos) > 0 {
+					if err := tx.Utxos.StoreUtxos(business.BusinessUid, utxos); err != nil {
+						return err
 					}
 				}
-				return nil
+				if len(spentOutpoints) > 0 {
+					if err := tx.Utxos.SpendUtxos(business.BusinessUid, spentOutpoints); err != nil {
+						return err
+					}
+				}
+				// 每个批次都会推进充值确认数，业务方的查询缓存全部失效
+				return tx.NotifyApiCacheInvalidate(business.BusinessUid)
 			}); err != nil {
 				log.Error("unable to persist batch", "err", err)
+				metrics.RecordDbRetry(deposit.chain, "deposit")
 				return nil, err
 			}
 			return nil, nil
@@ -270,6 +315,21 @@ func (deposit *Deposit) handleBatch(batch map[string]*TransactionsChannel) error
 	return nil
 }
 
+// childTxsOfDeposits 只保留属于 depositList 中交易的 child_txs
+func childTxsOfDeposits(childTxs []database.ChildTxs, depositList []database.Deposits) []database.ChildTxs {
+	hashes := make(map[string]bool, len(depositList))
+	for _, deposit := range depositList {
+		hashes[deposit.Hash] = true
+	}
+	var result []database.ChildTxs
+	for _, childTx := range childTxs {
+		if hashes[childTx.Hash] {
+			result = append(result, childTx)
+		}
+	}
+	return result
+}
+
 func (deposit *Deposit) HandleDeposit(tx *Transaction) (database.Deposits, []database.ChildTxs, error) {
 	var depositChildTx []database.ChildTxs
 	for _, voutItem := range tx.VoutList {
@@ -288,7 +348,7 @@ func (deposit *Deposit) HandleDeposit(tx *Transaction) (database.Deposits, []dat
 	txFee, _ := new(big.Int).SetString(tx.TxFee, 10)
 	depositTx := database.Deposits{
 		GUID:        uuid.New(),
-		BlockHash:   "",
+		BlockHash:   tx.BlockHash,
 		BlockNumber: tx.BlockNumber,
 		Hash:        tx.Hash,
 		Fee:         txFee,
@@ -316,7 +376,7 @@ func (deposit *Deposit) HandleWithdraw(tx *Transaction) (database.Withdraws, []d
 	}
 	withdrawTx := database.Withdraws{
 		Guid:        uuid.New(),
-		BlockHash:   "",
+		BlockHash:   tx.BlockHash,
 		BlockNumber: tx.BlockNumber,
 		Hash:        tx.Hash,
 		Fee:         txFee,
@@ -363,7 +423,7 @@ func (deposit *Deposit) HandleTransaction(tx *Transaction) (database.Transaction
 	}
 	transactionTx := database.Transactions{
 		GUID:        uuid.New(),
-		BlockHash:   "",
+		BlockHash:   tx.BlockHash,
 		BlockNumber: tx.BlockNumber,
 		Hash:        tx.Hash,
 		Fee:         txFee,
@@ -464,10 +524,10 @@ func (deposit *Deposit) HandleInternalTx(tx *Transaction) (database.Internals, [
 	}
 	internalTx := database.Internals{
 		Guid:        uuid.New(),
-		BlockHash:   "",
+		BlockHash:   tx.BlockHash,
 		BlockNumber: tx.BlockNumber,
 		Hash:        tx.Hash,
-		Status:      database.TxStatusSuccess,
+		Status:      database.TxStatusWithdrawed,
 		Fee:         txFee,
 		Timestamp:   uint64(time.Now().Unix()),
 	}
@@ -512,6 +572,7 @@ func (deposit *Deposit) HandleVout(tx *Transaction, businessID string) (*Prepare
 	for _, vin := range tx.VinList {
 		vout := database.Vouts{
 			GUID:      uuid.New(),
+			TxId:      tx.Hash,
 			Address:   vin.Address,
 			N:         vin.Vout,
 			Amount:    vin.Amount,
@@ -521,15 +582,11 @@ func (deposit *Deposit) HandleVout(tx *Transaction, businessID string) (*Prepare
 		if tx.TxType == "withdraw" || tx.TxType == "collection" || tx.TxType == "hot2cold" || tx.TxType == "cold2hot" {
 			vinAddressess := strings.Split(vin.Address, "|")
 			for _, addr := range vinAddressess {
-				vinDetail, err := deposit.database.Vins.QueryVinByTxId(businessID, addr, tx.Hash)
-				if err != nil {
-					log.Error("query vins fail", "err", err)
-				}
 				balanceItem := database.TokenBalance{
 					FromAddress:  addr,
 					ToAddress:    "",
 					TokenAddress: "",
-					Balance:      vinDetail.Amount,
+					Balance:      vin.Amount,
 					TxType:       tx.TxType,
 				}
 				balanceList = append(balanceList, balanceItem)
@@ -543,6 +600,52 @@ func (deposit *Deposit) HandleVout(tx *Transaction, businessID string) (*Prepare
 	}, balanceList, nil
 }
 
+// HandleUtxo 业务方地址收到的输出记为新的 utxo，交易输入引用的输出标记为已花费
+func (deposit *Deposit) HandleUtxo(tx *Transaction, businessID string) ([]database.Utxos, []database.SpentOutpoint) {
+	var utxoList []database.Utxos
+	var spentOutpoints []database.SpentOutpoint
+	for _, vout := range tx.VoutList {
+		exist, addressType := deposit.addressIndex.AddressExist(businessID, vout.Address)
+		if !exist {
+			continue
+		}
+		utxoList = append(utxoList, database.Utxos{
+			GUID:          uuid.New(),
+			TxId:          tx.Hash,
+			VoutIndex:     vout.TxIndex,
+			Address:       vout.Address,
+			AddressType:   addressType,
+			Amount:        vout.Amount,
+			Script:        "",
+			BlockHash:     tx.BlockHash,
+			CreatedHeight: tx.BlockNumber,
+			SpendTxHash:   "",
+			SpentHeight:   big.NewInt(0),
+			IsSpent:       false,
+			Timestamp:     uint64(time.Now().Unix()),
+		})
+	}
+	for _, vin := range tx.VinList {
+		managed := false
+		for _, address := range strings.Split(vin.Address, "|") {
+			if exist, _ := deposit.addressIndex.AddressExist(businessID, address); exist {
+				managed = true
+				break
+			}
+		}
+		if !managed {
+			continue
+		}
+		spentOutpoints = append(spentOutpoints, database.SpentOutpoint{
+			TxId:        vin.TxId,
+			VoutIndex:   vin.Vout,
+			SpendTxHash: tx.Hash,
+			SpentHeight: tx.BlockNumber,
+		})
+	}
+	return utxoList, spentOutpoints
+}
+
 type PrepareVoutList struct {
 	TxId        string
 	BlockNumber *big.Int
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+)
+
+func TestConfirmThresholds(t *testing.T) {
+	deposit := &Deposit{safeConfirms: 3, finalizedConfirms: 10}
+	tests := []struct {
+		name          string
+		business      database.Business
+		wantSafe      uint64
+		wantFinalized uint64
+	}{
+		{"chain defaults", database.Business{}, 3, 10},
+		{"business safe", database.Business{SafeConfirms: 6}, 6, 10},
+		{"business finalized", database.Business{FinalizedConfirms: 20}, 3, 20},
+		{"business both", database.Business{SafeConfirms: 1, FinalizedConfirms: 2}, 1, 2},
+		// safe 阈值不能超过 finalized 阈值
+		{"business safe above default finalized", database.Business{SafeConfirms: 12}, 10, 10},
+		{"business finalized below default safe", database.Business{FinalizedConfirms: 2}, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			safeConfirms, finalizedConfirms := deposit.confirmThresholds(tt.business)
+			require.Equal(t, tt.wantSafe, safeConfirms)
+			require.Equal(t, tt.wantFinalized, finalizedConfirms)
+		})
+	}
+}
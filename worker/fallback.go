@@ -4,17 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/retry"
 	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 )
 
 type FallBack struct {
+	chain          string
 	rpcClient      *syncclient.WalletBtcAccountClient
 	db             *database.DB
 	resourceCtx    context.Context
@@ -26,6 +30,7 @@ type FallBack struct {
 func NewFallBack(cfg *config.Config, db *database.DB, rpcClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*FallBack, error) {
 	resCtx, resCancel := context.WithCancel(context.Background())
 	return &FallBack{
+		chain:          cfg.Chain.Key,
 		rpcClient:      rpcClient,
 		db:             db,
 		resourceCtx:    resCtx,
@@ -57,6 +62,33 @@ func (w *FallBack) Start() error {
 			select {
 			case <-w.ticker.C:
 				log.Info("fallback process start")
+				businessList, err := w.db.Business.QueryBusinessList()
+				if err != nil {
+					log.Error("query business list fail", "err", err)
+					continue
+				}
+				for _, business := range businessList {
+					retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+					if _, err := retry.Do[interface{}](w.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
+						if err := w.db.Transaction(func(tx *database.DB) error {
+							if err := fallbackOrphanedTxs(tx, w.chain, business.BusinessUid); err != nil {
+								return err
+							}
+							if err := fallbackDone(tx, business.BusinessUid); err != nil {
+								return err
+							}
+							return tx.NotifyApiCacheInvalidate(business.BusinessUid)
+						}); err != nil {
+							log.Error("unable to persist fallback batch", "businessId", business.BusinessUid, "err", err)
+							metrics.RecordDbRetry(w.chain, "fallback")
+							return nil, err
+						}
+						return nil, nil
+					}); err != nil {
+						// 重试用完只跳过这个业务方，下一轮再处理，不能让回滚 worker 的错误停掉整个进程
+						log.Error("fallback business fail, retry next round", "businessId", business.BusinessUid, "err", err)
+					}
+				}
 			case <-w.resourceCtx.Done():
 				log.Info("stop fallback in worker")
 				return nil
@@ -65,3 +97,125 @@ func (w *FallBack) Start() error {
 	})
 	return nil
 }
+
+// fallbackOrphanedTxs 把所在区块已经不在主链上的充值、提现和内部交易标记为 fallback 状态，
+// 并回滚这些交易对地址余额的增减，后续由 notifier 通知业务方
+func fallbackOrphanedTxs(tx *database.DB, chain string, businessId string) error {
+	deposits, err := tx.Deposits.QueryFallbackDeposits(businessId)
+	if err != nil {
+		log.Error("query fallback deposits fail", "businessId", businessId, "err", err)
+		return err
+	}
+	withdraws, err := tx.Withdraws.QueryFallbackWithdraws(businessId)
+	if err != nil {
+		log.Error("query fallback withdraws fail", "businessId", businessId, "err", err)
+		return err
+	}
+	internals, err := tx.Internals.QueryFallbackInternals(businessId)
+	if err != nil {
+		log.Error("query fallback internals fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if len(deposits) == 0 && len(withdraws) == 0 && len(internals) == 0 {
+		return nil
+	}
+
+	// 充值和内部交易会给 vout 地址加余额，提现和内部交易会从花费地址扣余额，两边都要逆向恢复；
+	// 花费的 utxo 由扫链回滚时恢复为未花费
+	var creditTxHashes, debitTxHashes []string
+	for _, deposit := range deposits {
+		creditTxHashes = append(creditTxHashes, deposit.Hash)
+	}
+	for _, withdraw := range withdraws {
+		debitTxHashes = append(debitTxHashes, withdraw.Hash)
+	}
+	for _, internal := range internals {
+		creditTxHashes = append(creditTxHashes, internal.Hash)
+		debitTxHashes = append(debitTxHashes, internal.Hash)
+	}
+	creditVins, err := tx.Vins.QueryVinsByTxIds(businessId, creditTxHashes)
+	if err != nil {
+		log.Error("query fallback vins fail", "businessId", businessId, "err", err)
+		return err
+	}
+	debitVouts, err := tx.Vouts.QueryVoutsByTxIds(businessId, debitTxHashes)
+	if err != nil {
+		log.Error("query fallback vouts fail", "businessId", businessId, "err", err)
+		return err
+	}
+	// 先加回扣款再扣回入账：充值和花费它的归集同时被重组掉时，充值地址的余额已经扣过归集，先扣回充值会不够扣
+	credits, debits := fallbackBalances(creditVins, debitVouts)
+	if len(debits) > 0 {
+		if err := tx.Balances.RestoreBalances(businessId, debits); err != nil {
+			return err
+		}
+	}
+	if len(credits) > 0 {
+		underflows, err := tx.Balances.RollbackBalances(businessId, credits)
+		if err != nil {
+			return err
+		}
+		// 余额不够扣的地址保持原值，记录下来人工核对，不挡住这条链的回滚
+		if len(underflows) > 0 {
+			log.Error("fallback balances need manual reconciliation", "chain", chain, "businessId", businessId, "addresses", len(underflows))
+			metrics.RecordBalanceUnderflow(chain, businessId, len(underflows))
+		}
+	}
+
+	// 状态条件更新失败说明通知流程同时改了状态，整个事务回滚，余额不会被重复恢复，下一轮重新处理
+	if err := tx.Deposits.UpdateDepositsStatus(businessId, database.TxStatusFallback, deposits); err != nil {
+		return err
+	}
+	if err := tx.Withdraws.UpdateWithdrawStatus(businessId, database.TxStatusFallback, withdraws); err != nil {
+		return err
+	}
+	if err := tx.Internals.UpdateInternalStatus(businessId, database.TxStatusFallback, internals); err != nil {
+		return err
+	}
+	log.Warn("fallback orphaned transactions", "businessId", businessId, "deposits", len(deposits), "withdraws", len(withdraws), "internals", len(internals))
+	return nil
+}
+
+// fallbackBalances 计算回滚时要扣回的入账和要加回的扣款。vins 记录交易的输出（入账地址），
+// vouts 记录交易的输入（花费地址），多签输入的地址以 | 分隔，和扫链时 HandleVout 的扣款方式一致
+func fallbackBalances(creditVins []database.Vins, debitVouts []database.Vouts) ([]database.TokenBalance, []database.TokenBalance) {
+	var credits, debits []database.TokenBalance
+	for _, vin := range creditVins {
+		credits = append(credits, database.TokenBalance{
+			ToAddress: vin.Address,
+			Balance:   vin.Amount,
+		})
+	}
+	for _, vout := range debitVouts {
+		for _, addr := range strings.Split(vout.Address, "|") {
+			debits = append(debits, database.TokenBalance{
+				FromAddress: addr,
+				Balance:     vout.Amount,
+			})
+		}
+	}
+	return credits, debits
+}
+
+// fallbackDone 业务方已经确认收到回滚通知的交易，回滚流程结束
+func fallbackDone(tx *database.DB, businessId string) error {
+	deposits, err := tx.Deposits.QueryDepositsByStatus(businessId, database.TxStatusFallbackNotify)
+	if err != nil {
+		return err
+	}
+	if err := tx.Deposits.UpdateDepositsStatus(businessId, database.TxStatusFallbackDone, deposits); err != nil {
+		return err
+	}
+	withdraws, err := tx.Withdraws.QueryWithdrawsByStatus(businessId, database.TxStatusFallbackNotify)
+	if err != nil {
+		return err
+	}
+	if err := tx.Withdraws.UpdateWithdrawStatus(businessId, database.TxStatusFallbackDone, withdraws); err != nil {
+		return err
+	}
+	internals, err := tx.Internals.QueryInternalsByStatus(businessId, database.TxStatusFallbackNotify)
+	if err != nil {
+		return err
+	}
+	return tx.Internals.UpdateInternalStatus(businessId, database.TxStatusFallbackDone, internals)
+}
@@ -0,0 +1,154 @@
+package worker
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+)
+
+func TestFallbackBalances(t *testing.T) {
+	creditVins := []database.Vins{
+		{TxId: "deposit", Address: "user", Amount: big.NewInt(5000)},
+	}
+	debitVouts := []database.Vouts{
+		{TxId: "withdraw", Address: "hot", Amount: big.NewInt(8000)},
+		{TxId: "withdraw", Address: "hot-a|hot-b", Amount: big.NewInt(3000)},
+	}
+
+	credits, debits := fallbackBalances(creditVins, debitVouts)
+	require.Equal(t, []database.TokenBalance{
+		{ToAddress: "user", Balance: big.NewInt(5000)},
+	}, credits)
+	// 提现的扣款按花费地址加回，和扫链时的扣款一一对应
+	require.Equal(t, []database.TokenBalance{
+		{FromAddress: "hot", Balance: big.NewInt(8000)},
+		{FromAddress: "hot-a", Balance: big.NewInt(3000)},
+		{FromAddress: "hot-b", Balance: big.NewInt(3000)},
+	}, debits)
+
+	credits, debits = fallbackBalances(nil, nil)
+	require.Empty(t, credits)
+	require.Empty(t, debits)
+}
+
+type fallbackDepositsDB struct {
+	database.DepositsDB
+	deposits []database.Deposits
+}
+
+func (db *fallbackDepositsDB) QueryFallbackDeposits(string) ([]database.Deposits, error) {
+	return db.deposits, nil
+}
+
+func (db *fallbackDepositsDB) UpdateDepositsStatus(string, database.TxStatus, []database.Deposits) error {
+	return nil
+}
+
+type fallbackWithdrawsDB struct{ database.WithdrawsDB }
+
+func (db *fallbackWithdrawsDB) QueryFallbackWithdraws(string) ([]database.Withdraws, error) {
+	return nil, nil
+}
+
+func (db *fallbackWithdrawsDB) UpdateWithdrawStatus(string, database.TxStatus, []database.Withdraws) error {
+	return nil
+}
+
+type fallbackInternalsDB struct {
+	database.InternalsDB
+	internals []database.Internals
+}
+
+func (db *fallbackInternalsDB) QueryFallbackInternals(string) ([]database.Internals, error) {
+	return db.internals, nil
+}
+
+func (db *fallbackInternalsDB) UpdateInternalStatus(string, database.TxStatus, []database.Internals) error {
+	return nil
+}
+
+type fallbackVinsDB struct {
+	database.VinsDB
+	vins []database.Vins
+}
+
+func (db *fallbackVinsDB) QueryVinsByTxIds(_ string, txIds []string) ([]database.Vins, error) {
+	var out []database.Vins
+	for _, vin := range db.vins {
+		if slices.Contains(txIds, vin.TxId) {
+			out = append(out, vin)
+		}
+	}
+	return out, nil
+}
+
+type fallbackVoutsDB struct {
+	database.VoutsDB
+	vouts []database.Vouts
+}
+
+func (db *fallbackVoutsDB) QueryVoutsByTxIds(_ string, txIds []string) ([]database.Vouts, error) {
+	var out []database.Vouts
+	for _, vout := range db.vouts {
+		if slices.Contains(txIds, vout.TxId) {
+			out = append(out, vout)
+		}
+	}
+	return out, nil
+}
+
+// fallbackBalancesDB 内存里的地址余额，余额不够扣时和数据库实现一样保持原值并返回这一项
+type fallbackBalancesDB struct {
+	database.BalancesDB
+	balances map[string]int64
+}
+
+func (db *fallbackBalancesDB) RollbackBalances(_ string, balanceList []database.TokenBalance) ([]database.TokenBalance, error) {
+	var underflows []database.TokenBalance
+	for _, value := range balanceList {
+		if db.balances[value.ToAddress] < value.Balance.Int64() {
+			underflows = append(underflows, value)
+			continue
+		}
+		db.balances[value.ToAddress] -= value.Balance.Int64()
+	}
+	return underflows, nil
+}
+
+func (db *fallbackBalancesDB) RestoreBalances(_ string, balanceList []database.TokenBalance) error {
+	for _, value := range balanceList {
+		db.balances[value.FromAddress] += value.Balance.Int64()
+	}
+	return nil
+}
+
+func TestFallbackOrphanedTxsRestoresDebitsFirst(t *testing.T) {
+	// 充值 5000 到 user，随后归集把它花掉转给 hot（手续费 100），两笔交易被同一次重组掉；
+	// user 的余额已经扣过归集，必须先加回归集的扣款才能扣回充值
+	balances := &fallbackBalancesDB{balances: map[string]int64{"user": 0, "hot": 4900}}
+	tx := &database.DB{
+		Deposits:  &fallbackDepositsDB{deposits: []database.Deposits{{Hash: "deposit"}}},
+		Withdraws: &fallbackWithdrawsDB{},
+		Internals: &fallbackInternalsDB{internals: []database.Internals{{Hash: "collection"}}},
+		Vins: &fallbackVinsDB{vins: []database.Vins{
+			{TxId: "deposit", Address: "user", Amount: big.NewInt(5000)},
+			{TxId: "collection", Address: "hot", Amount: big.NewInt(4900)},
+		}},
+		Vouts: &fallbackVoutsDB{vouts: []database.Vouts{
+			{TxId: "collection", Address: "user", Amount: big.NewInt(5000)},
+		}},
+		Balances: balances,
+	}
+
+	require.NoError(t, fallbackOrphanedTxs(tx, "btc", "biz"))
+	require.Equal(t, map[string]int64{"user": 0, "hot": 0}, balances.balances)
+
+	// 余额本身已经对不上时只记录下来人工核对，不返回错误挡住回滚
+	balances.balances = map[string]int64{"user": 0, "hot": 100}
+	require.NoError(t, fallbackOrphanedTxs(tx, "btc", "biz"))
+	require.Equal(t, map[string]int64{"user": 0, "hot": 100}, balances.balances)
+}
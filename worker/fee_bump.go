@@ -0,0 +1,386 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/rawtx"
+	"github.com/dapplink-labs/multichain-sync-btc/common/retry"
+	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+	"github.com/dapplink-labs/multichain-sync-btc/services/feeestimator"
+)
+
+var ErrInsufficientChange = errors.New("change output can not cover the bumped fee")
+
+// FeeBump 对广播之后超过 N 个区块仍未确认的提现做 RBF 加速：用相同的输入构建费率更高的替换交易，
+// 交给业务方签名之后重新广播，提现记录始终指向最新广播的版本。原交易没有声明 BIP125 时只有开启 full-RBF 的节点接受替换，
+// 被拒绝一次之后这笔提现不再加速
+type FeeBump struct {
+	chain          string
+	rpcClient      *syncclient.WalletBtcAccountClient
+	db             *database.DB
+	bumpBlocks     *big.Int
+	bumpPercent    int64
+	dustLimit      int64
+	minFeeRate     int64
+	chainProfile   config.ChainProfile
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+	ticker         *time.Ticker
+}
+
+func NewFeeBump(cfg *config.Config, db *database.DB, rpcClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*FeeBump, error) {
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &FeeBump{
+		chain:          cfg.Chain.Key,
+		rpcClient:      rpcClient,
+		db:             db,
+		bumpBlocks:     new(big.Int).SetUint64(uint64(cfg.ChainNode.FeeBumpBlocks)),
+		bumpPercent:    int64(cfg.ChainNode.FeeBumpPercent),
+		dustLimit:      cfg.Chain.DustLimit,
+		minFeeRate:     cfg.Chain.MinFeeRate,
+		chainProfile:   cfg.Chain,
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			shutdown(fmt.Errorf("critical error in fee bump: %w", err))
+		}},
+		ticker: time.NewTicker(cfg.ChainNode.WorkerInterval),
+	}, nil
+}
+
+func (fb *FeeBump) Close() error {
+	var result error
+	fb.resourceCancel()
+	fb.ticker.Stop()
+	log.Info("stop fee bump......")
+	if err := fb.tasks.Wait(); err != nil {
+		result = errors.Join(result, fmt.Errorf("failed to await fee bump %w", err))
+		return result
+	}
+	log.Info("stop fee bump success")
+	return nil
+}
+
+func (fb *FeeBump) Start() error {
+	log.Info("start fee bump......")
+	fb.tasks.Go(func() error {
+		for {
+			select {
+			case <-fb.ticker.C:
+				if err := fb.processFeeBump(); err != nil {
+					log.Error("process fee bump fail", "err", err)
+					return err
+				}
+			case <-fb.resourceCtx.Done():
+				log.Info("stop fee bump in worker")
+				return nil
+			}
+		}
+	})
+	return nil
+}
+
+func (fb *FeeBump) processFeeBump() error {
+	latestBlock, err := fb.db.Blocks.LatestBlocks()
+	if err != nil {
+		log.Error("query latest block fail", "err", err)
+		return nil
+	}
+	if latestBlock == nil {
+		return nil
+	}
+	businessList, err := fb.db.Business.QueryActiveBusinessList()
+	if err != nil {
+		log.Error("query business list fail", "err", err)
+		return nil
+	}
+	for _, business := range businessList {
+		if err := fb.replaceStuckWithdraws(business, latestBlock.Number); err != nil {
+			log.Error("replace stuck withdraws fail", "businessId", business.BusinessUid, "err", err)
+		}
+		// 替换交易广播之后写库失败不能停掉进程，下一轮按交易哈希确认已经广播过再写库
+		if err := fb.sendReplacements(business.BusinessUid, latestBlock.Number); err != nil {
+			log.Error("persist withdraw replacements fail, retry next tick", "businessId", business.BusinessUid, "err", err)
+		}
+	}
+	return nil
+}
+
+// replaceStuckWithdraws 为卡住的提现生成替换交易，等待通知业务方签名；已经有未广播替换交易的提现跳过
+func (fb *FeeBump) replaceStuckWithdraws(business database.Business, latestNumber *big.Int) error {
+	maxSentHeight := new(big.Int).Sub(latestNumber, fb.bumpBlocks)
+	if maxSentHeight.Sign() <= 0 {
+		return nil
+	}
+	stuckWithdraws, err := fb.db.Withdraws.QueryStuckWithdraws(business.BusinessUid, maxSentHeight)
+	if err != nil {
+		return err
+	}
+	if len(stuckWithdraws) == 0 {
+		return nil
+	}
+	hotWallet, err := fb.db.Addresses.QueryHotWalletInfo(business.BusinessUid)
+	if err != nil {
+		return err
+	}
+	if hotWallet == nil {
+		return errors.New("hot wallet not exist")
+	}
+	feeResp, err := fb.rpcClient.GetFee()
+	if err != nil {
+		return err
+	}
+	priority, err := feeestimator.ParsePriority(business.FeePriority)
+	if err != nil {
+		return err
+	}
+	estimator := &feeestimator.Estimator{
+		Priority:   priority,
+		MinFeeRate: max(business.MinFeeRate, fb.minFeeRate),
+		MaxFeeRate: business.MaxFeeRate,
+	}
+	marketFeeRate := estimator.FeeRate(feeestimator.FeeRatesFromResponse(feeResp))
+
+	for _, withdraw := range stuckWithdraws {
+		pending, err := fb.db.WithdrawReplacements.QueryPendingReplacement(business.BusinessUid, withdraw.Guid)
+		if err != nil {
+			return err
+		}
+		if pending != nil {
+			continue
+		}
+		// 原交易没有声明 BIP125 时替换交易被节点拒绝过一次就不再生成，避免每轮都让业务方签一笔发不出去的交易
+		if !signalsReplacement(withdraw.TxSignHex) {
+			rejected, err := fb.db.WithdrawReplacements.QueryFailedReplacement(business.BusinessUid, withdraw.Hash)
+			if err != nil {
+				return err
+			}
+			if rejected != nil {
+				continue
+			}
+		}
+		replacement, err := fb.buildReplacement(business, hotWallet.Address, withdraw, marketFeeRate)
+		if err != nil {
+			log.Warn("skip fee bump", "businessId", business.BusinessUid, "withdraw", withdraw.Guid, "hash", withdraw.Hash, "err", err)
+			continue
+		}
+		if err := fb.db.WithdrawReplacements.StoreReplacement(business.BusinessUid, replacement); err != nil {
+			return err
+		}
+		log.Info("build withdraw replacement success", "businessId", business.BusinessUid, "withdraw", withdraw.Guid, "replacedHash", withdraw.Hash, "oldFeeRate", withdraw.FeeRate, "feeRate", replacement.FeeRate, "fee", replacement.Fee)
+	}
+	return nil
+}
+
+// buildReplacement 花费原交易占用的同一批 utxo，提现输出不变，多出的手续费从找零中扣除
+func (fb *FeeBump) buildReplacement(business database.Business, hotWalletAddress string, withdraw database.Withdraws, marketFeeRate int64) (*database.WithdrawReplacements, error) {
+	inputs, err := fb.db.Utxos.QueryReservedUtxos(business.BusinessUid, withdraw.Guid.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 {
+		return nil, errors.New("withdraw has no reserved inputs")
+	}
+	outputs, err := fb.db.ChildTxs.QueryChildTxnByTxId(business.BusinessUid, withdraw.Guid.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("withdraw has no outputs")
+	}
+
+	var (
+		inputAmount  int64
+		outputAmount int64
+		utxoVins     []*utxo.Vin
+		utxoVouts    []*utxo.Vout
+		toAddresses  []string
+	)
+	for _, input := range inputs {
+		inputAmount += input.Amount.Int64()
+		utxoVins = append(utxoVins, &utxo.Vin{
+			Hash:    input.TxId,
+			Index:   input.VoutIndex,
+			Amount:  input.Amount.Int64(),
+			Address: input.Address,
+		})
+	}
+	for _, output := range outputs {
+		amount, err := strconv.ParseInt(output.Amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid withdraw amount %s: %w", output.Amount, err)
+		}
+		outputAmount += amount
+		utxoVouts = append(utxoVouts, &utxo.Vout{
+			Address: output.ToAddress,
+			Amount:  amount,
+			Index:   uint32(len(utxoVouts)),
+		})
+		toAddresses = append(toAddresses, output.ToAddress)
+	}
+
+	feeRate, err := feeestimator.BumpFeeRate(withdraw.FeeRate, fb.bumpPercent, marketFeeRate, business.MaxFeeRate)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := feeestimator.NewPlan(hotWalletAddress, business.MultisigM, business.MultisigN, toAddresses, fb.chainProfile)
+	if err != nil {
+		return nil, err
+	}
+	vsize, err := plan.VSize(len(inputs), true)
+	if err != nil {
+		return nil, err
+	}
+	fee := feeestimator.BumpFee(withdraw.Fee.Int64(), vsize, feeRate)
+	change := inputAmount - outputAmount - fee
+	if change < fb.dustLimit {
+		// 找零低于粉尘阈值时去掉找零输出，剩余金额全部作为手续费
+		if vsize, err = plan.VSize(len(inputs), false); err != nil {
+			return nil, err
+		}
+		fee = inputAmount - outputAmount
+		change = 0
+		if fee < feeestimator.BumpFee(withdraw.Fee.Int64(), vsize, feeRate) {
+			return nil, ErrInsufficientChange
+		}
+	}
+	if change > 0 {
+		utxoVouts = append(utxoVouts, &utxo.Vout{
+			Address: hotWalletAddress,
+			Amount:  change,
+			Index:   uint32(len(utxoVouts)),
+		})
+	}
+
+	unSignTx, err := fb.rpcClient.CreateUnSignTransaction(fee, utxoVins, utxoVouts)
+	if err != nil {
+		return nil, err
+	}
+	var signHashes []string
+	for _, signHash := range unSignTx.SignHashes {
+		signHashes = append(signHashes, string(signHash))
+	}
+	return &database.WithdrawReplacements{
+		GUID:         uuid.New(),
+		WithdrawGuid: withdraw.Guid,
+		ReplacedHash: withdraw.Hash,
+		Fee:          big.NewInt(fee),
+		FeeRate:      feeRate,
+		VSize:        vsize,
+		UnSignTx:     strings.Join(signHashes, "|"),
+		TxData:       string(unSignTx.TxData),
+		Status:       database.TxStatusWaitSign,
+		Timestamp:    uint64(time.Now().Unix()),
+	}, nil
+}
+
+// sendReplacements 广播业务方已经签名的替换交易；原交易或更早的替换交易已经上链的，替换交易直接作废
+func (fb *FeeBump) sendReplacements(businessId string, latestNumber *big.Int) error {
+	signedReplacements, err := fb.db.WithdrawReplacements.QueryReplacementsByStatus(businessId, database.TxStatusUnSent)
+	if err != nil {
+		log.Error("query signed replacements fail", "businessId", businessId, "err", err)
+		return nil
+	}
+	var (
+		sentList      []database.WithdrawReplacements
+		abandonedList []database.WithdrawReplacements
+	)
+	for _, replacement := range signedReplacements {
+		withdraw, err := fb.db.Withdraws.QueryWithdrawByGuid(businessId, replacement.WithdrawGuid)
+		if err != nil {
+			log.Error("query withdraw fail", "businessId", businessId, "err", err)
+			return nil
+		}
+		if withdraw == nil || withdraw.Status != database.TxStatusSent || withdraw.Hash != replacement.ReplacedHash {
+			abandonedList = append(abandonedList, replacement)
+			continue
+		}
+		txHash, err := fb.rpcClient.SendTx(replacement.TxSignHex)
+		if err != nil {
+			// 上一轮广播成功但写库失败时重发会被节点拒绝，链上或内存池里已经有这笔交易就按已发送处理
+			sentHash, broadcast := fb.broadcasted(replacement)
+			if !broadcast {
+				// 原交易没有声明 BIP125，没有开启 full-RBF 的节点永远不会接受替换，这笔替换交易直接作废，不再每轮重发
+				if !signalsReplacement(withdraw.TxSignHex) {
+					log.Warn("replacement rejected, original withdraw does not signal rbf", "businessId", businessId, "withdraw", replacement.WithdrawGuid, "replacedHash", replacement.ReplacedHash, "err", err)
+					abandonedList = append(abandonedList, replacement)
+					continue
+				}
+				log.Error("send replacement transaction fail", "businessId", businessId, "withdraw", replacement.WithdrawGuid, "err", err)
+				continue
+			}
+			txHash = sentHash
+		}
+		replacement.Hash = txHash
+		sentList = append(sentList, replacement)
+	}
+	if len(sentList) == 0 && len(abandonedList) == 0 {
+		return nil
+	}
+
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	if _, err := retry.Do[interface{}](fb.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
+		if err := fb.db.Transaction(func(tx *database.DB) error {
+			for i := range sentList {
+				if err := tx.WithdrawReplacements.UpdateSentReplacement(businessId, sentList[i].GUID, sentList[i].Hash); err != nil {
+					return err
+				}
+				if err := tx.Withdraws.ReplaceWithdraw(businessId, &sentList[i], latestNumber); err != nil {
+					return err
+				}
+			}
+			if err := tx.WithdrawReplacements.UpdateReplacementStatus(businessId, database.TxStatusFail, abandonedList); err != nil {
+				return err
+			}
+			return tx.NotifyApiCacheInvalidate(businessId, database.ApiCacheWithdraws)
+		}); err != nil {
+			log.Error("unable to persist replacements", "err", err)
+			metrics.RecordDbRetry(fb.chain, "fee_bump")
+			return nil, err
+		}
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+	log.Info("send withdraw replacements success", "businessId", businessId, "sent", len(sentList), "abandoned", len(abandonedList))
+	return nil
+}
+
+// signalsReplacement 原交易由上游 CreateUnSignTransaction 构建，请求里没有 nSequence 参数，是否声明 BIP125 只能从签名后的交易里看；
+// 解析失败时按没有声明处理
+func signalsReplacement(signedTxHex string) bool {
+	signals, err := rawtx.SignalsReplacement(signedTxHex)
+	if err != nil {
+		log.Warn("parse signed withdraw fail, assume no rbf signal", "err", err)
+		return false
+	}
+	return signals
+}
+
+// broadcasted 按签名交易计算的哈希查询替换交易是否已经在链上或内存池中
+func (fb *FeeBump) broadcasted(replacement database.WithdrawReplacements) (string, bool) {
+	txHash, err := rawtx.TxHash(replacement.TxSignHex)
+	if err != nil {
+		return "", false
+	}
+	tx, err := fb.rpcClient.GetTransactionByHash(txHash)
+	if err != nil || tx.Status == utxo.TxStatus_NotFound || tx.Status == utxo.TxStatus_Failed {
+		return "", false
+	}
+	return txHash, true
+}
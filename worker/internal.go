@@ -13,21 +13,25 @@ import (
 	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
 )
 
 type Internal struct {
+	chain          string
 	rpcClient      *syncclient.WalletBtcAccountClient
 	db             *database.DB
 	resourceCtx    context.Context
 	resourceCancel context.CancelFunc
 	tasks          tasks.Group
 	ticker         *time.Ticker
+	reservationTtl time.Duration
 }
 
 func NewInternal(cfg *config.Config, db *database.DB, rpcClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*Internal, error) {
 	resCtx, resCancel := context.WithCancel(context.Background())
 	return &Internal{
+		chain:          cfg.Chain.Key,
 		rpcClient:      rpcClient,
 		db:             db,
 		resourceCtx:    resCtx,
@@ -35,7 +39,8 @@ func NewInternal(cfg *config.Config, db *database.DB, rpcClient *syncclient.Wall
 		tasks: tasks.Group{HandleCrit: func(err error) {
 			shutdown(fmt.Errorf("critical error in internals: %w", err))
 		}},
-		ticker: time.NewTicker(cfg.ChainNode.WorkerInterval),
+		ticker:         time.NewTicker(cfg.ChainNode.WorkerInterval),
+		reservationTtl: cfg.ChainNode.ReservationTtl,
 	}, nil
 }
 
@@ -59,19 +64,25 @@ func (w *Internal) Start() error {
 			select {
 			case <-w.ticker.C:
 				log.Info("collection and hot to cold")
-				businessList, err := w.db.Business.QueryBusinessList()
+				businessList, err := w.db.Business.QueryActiveBusinessList()
 				if err != nil {
 					log.Error("query business list fail", "err", err)
 					continue
 				}
 
 				for _, businessId := range businessList {
+					if err := w.failExpiredCpfp(businessId.BusinessUid); err != nil {
+						log.Error("fail expired cpfp fail", "businessId", businessId.BusinessUid, "err", err)
+					}
 					unSendInternalTxList, err := w.db.Internals.UnSendInternalsList(businessId.BusinessUid)
 					if err != nil {
 						log.Error("query un send internal tx list fail", "err", err)
 						continue
 					}
-					var balanceList []database.Balances
+					var (
+						balanceList []database.Balances
+						sentList    []database.Internals
+					)
 					for _, unSendInternalTx := range unSendInternalTxList {
 						childTxList, err := w.db.ChildTxs.QueryChildTxnByTxId(businessId.BusinessUid, unSendInternalTx.Guid.String())
 						if err != nil {
@@ -99,7 +110,8 @@ func (w *Internal) Start() error {
 							continue
 						} else {
 							unSendInternalTx.Hash = txHash
-							unSendInternalTx.Status = database.TxStatusSuccess
+							unSendInternalTx.Status = database.TxStatusSent
+							sentList = append(sentList, unSendInternalTx)
 						}
 					}
 
@@ -113,16 +125,20 @@ func (w *Internal) Start() error {
 									return err
 								}
 							}
-							if len(unSendInternalTxList) > 0 {
-								err = w.db.Internals.UpdateInternalStatus(businessId.BusinessUid, database.TxStatusSuccess, unSendInternalTxList)
+							if len(sentList) > 0 {
+								err = tx.Internals.UpdateSentInternals(businessId.BusinessUid, sentList)
 								if err != nil {
 									log.Error("update internals status fail", "err", err)
 									return err
 								}
+								if err := tx.NotifyApiCacheInvalidate(businessId.BusinessUid, database.ApiCacheInternals); err != nil {
+									return err
+								}
 							}
 							return nil
 						}); err != nil {
 							log.Error("unable to persist batch", "err", err)
+							metrics.RecordDbRetry(w.chain, "internal")
 							return nil, err
 						}
 						return nil, nil
@@ -138,3 +154,20 @@ func (w *Internal) Start() error {
 	})
 	return nil
 }
+
+// failExpiredCpfp 超过占用时限仍未广播的 CPFP 子交易标记为 done_fail，释放合并的 utxo 和父交易输出
+func (w *Internal) failExpiredCpfp(businessId string) error {
+	before := uint64(time.Now().Add(-w.reservationTtl).Unix())
+	expiredList, err := w.db.Internals.QueryExpiredCpfpInternals(businessId, before)
+	if err != nil {
+		return err
+	}
+	if len(expiredList) == 0 {
+		return nil
+	}
+	if err := w.db.FailInternals(businessId, expiredList); err != nil {
+		return err
+	}
+	log.Warn("expired cpfp transactions failed and reservations released", "businessId", businessId, "count", len(expiredList))
+	return nil
+}
@@ -0,0 +1,263 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
+	"github.com/dapplink-labs/multichain-sync-btc/common/retry"
+	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
+	"github.com/dapplink-labs/multichain-sync-btc/config"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+)
+
+const (
+	mempoolFetchConcurrency = 8
+	mempoolPageSize         = 50
+)
+
+// Mempool 轮询业务方用户地址在内存池中的交易，按和扫块相同的规则识别充值，零确认就以 unsafe 状态入库并通知业务方；
+// 交易上链之后由扫块流程补上区块信息，交易被替换或者被驱逐出内存池时标记为 dropped
+type Mempool struct {
+	chain          string
+	rpcClient      *syncclient.WalletBtcAccountClient
+	db             *database.DB
+	addressIndex   *cache.AddressIndex
+	resourceCtx    context.Context
+	resourceCancel context.CancelFunc
+	tasks          tasks.Group
+	ticker         *time.Ticker
+}
+
+func NewMempool(cfg *config.Config, db *database.DB, rpcClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*Mempool, error) {
+	resCtx, resCancel := context.WithCancel(context.Background())
+	return &Mempool{
+		chain:          cfg.Chain.Key,
+		rpcClient:      rpcClient,
+		db:             db,
+		addressIndex:   cache.GetAddressIndex(cfg.Chain.Key),
+		resourceCtx:    resCtx,
+		resourceCancel: resCancel,
+		tasks: tasks.Group{HandleCrit: func(err error) {
+			shutdown(fmt.Errorf("critical error in mempool: %w", err))
+		}},
+		ticker: time.NewTicker(cfg.ChainNode.MempoolInterval),
+	}, nil
+}
+
+func (m *Mempool) Close() error {
+	var result error
+	m.resourceCancel()
+	m.ticker.Stop()
+	log.Info("stop mempool......")
+	if err := m.tasks.Wait(); err != nil {
+		result = errors.Join(result, fmt.Errorf("failed to await mempool %w", err))
+		return result
+	}
+	log.Info("stop mempool success")
+	return nil
+}
+
+func (m *Mempool) Start() error {
+	log.Info("start mempool......")
+	m.tasks.Go(func() error {
+		for {
+			select {
+			case <-m.ticker.C:
+				businessList, err := m.db.Business.QueryBusinessList()
+				if err != nil {
+					log.Error("query business list fail", "err", err)
+					continue
+				}
+				if err := refreshAddressIndex(m.db, m.addressIndex, businessList); err != nil {
+					log.Error("refresh address index fail", "err", err)
+					continue
+				}
+				for _, business := range businessList {
+					if err := m.scanPendingDeposits(business.BusinessUid); err != nil {
+						log.Error("scan mempool deposits fail", "businessId", business.BusinessUid, "err", err)
+					}
+					if err := m.checkMempoolDeposits(business.BusinessUid); err != nil {
+						log.Error("check mempool deposits fail", "businessId", business.BusinessUid, "err", err)
+					}
+				}
+			case <-m.resourceCtx.Done():
+				log.Info("stop mempool in worker")
+				return nil
+			}
+		}
+	})
+	return nil
+}
+
+// scanPendingDeposits 拉取用户地址的未打包交易，识别为充值且还没有入库的写入 unsafe 充值
+func (m *Mempool) scanPendingDeposits(businessId string) error {
+	userAddresses := m.addressIndex.Addresses(businessId, 0)
+	if len(userAddresses) == 0 {
+		return nil
+	}
+	pendingTxLists, err := tasks.ParallelMap(len(userAddresses), mempoolFetchConcurrency, func(i int) ([]*utxo.TxMessage, error) {
+		return m.rpcClient.GetPendingTxByAddress(userAddresses[i], mempoolPageSize)
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		depositList []database.Deposits
+		childTxList []database.ChildTxs
+	)
+	for _, tx := range classifyPendingDeposits(m.addressIndex, businessId, pendingTxLists) {
+		existDeposit, err := m.db.Deposits.QueryDepositByHash(businessId, tx.Hash)
+		if err != nil {
+			return err
+		}
+		if existDeposit != nil {
+			continue
+		}
+		deposit, childTxs := mempoolDeposit(tx)
+		depositList = append(depositList, deposit)
+		childTxList = append(childTxList, childTxs...)
+	}
+	if len(depositList) == 0 {
+		return nil
+	}
+
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	if _, err := retry.Do[interface{}](m.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
+		if err := m.db.Transaction(func(tx *database.DB) error {
+			if err := tx.Deposits.StoreDeposits(businessId, depositList); err != nil {
+				return err
+			}
+			if len(childTxList) > 0 {
+				return tx.ChildTxs.StoreChildTxs(businessId, childTxList)
+			}
+			return nil
+		}); err != nil {
+			metrics.RecordDbRetry(m.chain, "mempool")
+			return nil, err
+		}
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+	if err := m.db.NotifyApiCacheInvalidate(businessId, database.ApiCacheDeposits); err != nil {
+		log.Warn("notify api cache invalidate fail", "businessId", businessId, "err", err)
+	}
+	log.Info("store mempool deposits success", "businessId", businessId, "totalTx", len(depositList))
+	return nil
+}
+
+// classifyPendingDeposits 按和扫块相同的规则从未打包交易里挑出充值，同一笔交易出现在多个地址的查询结果里只保留一次
+func classifyPendingDeposits(addressIndex *cache.AddressIndex, businessId string, pendingTxLists [][]*utxo.TxMessage) []*utxo.TxMessage {
+	hotWalletAddress := addressIndex.HotWallet(businessId)
+	coldWalletAddress := addressIndex.ColdWallet(businessId)
+	seen := make(map[string]bool)
+	var depositTxList []*utxo.TxMessage
+	for _, pendingTxList := range pendingTxLists {
+		for _, tx := range pendingTxList {
+			if seen[tx.Hash] {
+				continue
+			}
+			seen[tx.Hash] = true
+
+			var vinAddressList, toAddressList []string
+			for _, from := range tx.Froms {
+				vinAddressList = append(vinAddressList, strings.Split(from.Address, "|")...)
+			}
+			for _, to := range tx.Tos {
+				toAddressList = append(toAddressList, to.Address)
+			}
+			if classifyTransaction(addressIndex, businessId, hotWalletAddress, coldWalletAddress, vinAddressList, toAddressList) == "deposit" {
+				depositTxList = append(depositTxList, tx)
+			}
+		}
+	}
+	return depositTxList
+}
+
+// mempoolDeposit 未打包的充值以 unsafe 状态入库，child_txs 和扫块时一样每个输出记一条，上链之后扫块不再重复写入
+func mempoolDeposit(tx *utxo.TxMessage) (database.Deposits, []database.ChildTxs) {
+	txFee, ok := new(big.Int).SetString(tx.Fee, 10)
+	if !ok {
+		txFee = big.NewInt(0)
+	}
+	deposit := database.Deposits{
+		GUID:        uuid.New(),
+		BlockHash:   "",
+		BlockNumber: big.NewInt(0),
+		Hash:        tx.Hash,
+		Fee:         txFee,
+		LockTime:    big.NewInt(0),
+		Version:     "0x0",
+		Confirms:    0,
+		Status:      database.TxStatusUnSafe,
+		Timestamp:   uint64(time.Now().Unix()),
+	}
+	var childTxs []database.ChildTxs
+	for i, to := range tx.Tos {
+		amount := "0"
+		if i < len(tx.Values) {
+			amount = tx.Values[i].Value
+		}
+		childTxs = append(childTxs, database.ChildTxs{
+			GUID:        uuid.New(),
+			Hash:        tx.Hash,
+			TxIndex:     big.NewInt(int64(i)),
+			TxType:      "deposit",
+			FromAddress: "",
+			ToAddress:   to.Address,
+			Amount:      amount,
+			Timestamp:   deposit.Timestamp,
+		})
+	}
+	return deposit, childTxs
+}
+
+// checkMempoolDeposits 内存池里的充值如果在链上已经查不到（被 RBF 替换或者被驱逐），标记为 dropped 并通知业务方
+func (m *Mempool) checkMempoolDeposits(businessId string) error {
+	mempoolDeposits, err := m.db.Deposits.QueryMempoolDeposits(businessId)
+	if err != nil {
+		return err
+	}
+	var droppedList []database.Deposits
+	for _, deposit := range mempoolDeposits {
+		tx, err := m.rpcClient.GetTransactionByHash(deposit.Hash)
+		if errors.Is(err, syncclient.ErrTransactionNotFound) {
+			droppedList = append(droppedList, deposit)
+			continue
+		} else if err != nil {
+			log.Warn("query mempool deposit fail", "hash", deposit.Hash, "err", err)
+			continue
+		}
+		if tx.Status == utxo.TxStatus_NotFound || tx.Status == utxo.TxStatus_Failed {
+			droppedList = append(droppedList, deposit)
+		}
+	}
+	if len(droppedList) == 0 {
+		return nil
+	}
+	// 查询期间已经被区块扫描确认的充值不会被改成 dropped
+	dropped, err := m.db.Deposits.DropMempoolDeposits(businessId, droppedList)
+	if err != nil {
+		return err
+	}
+	if dropped == 0 {
+		return nil
+	}
+	if err := m.db.NotifyApiCacheInvalidate(businessId, database.ApiCacheDeposits); err != nil {
+		log.Warn("notify api cache invalidate fail", "businessId", businessId, "err", err)
+	}
+	log.Warn("mempool deposits dropped", "businessId", businessId, "totalTx", dropped)
+	return nil
+}
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
+)
+
+func TestClassifyPendingDeposits(t *testing.T) {
+	addressIndex := cache.NewAddressIndex()
+	addressIndex.Add("b1", []database.Addresses{
+		{Address: "user", AddressType: 0},
+		{Address: "hot", AddressType: 1},
+	})
+
+	deposit := &utxo.TxMessage{
+		Hash:  "deposit",
+		Froms: []*utxo.Address{{Address: "outside"}},
+		Tos:   []*utxo.Address{{Address: "user"}},
+	}
+	collection := &utxo.TxMessage{
+		Hash:  "collection",
+		Froms: []*utxo.Address{{Address: "user"}},
+		Tos:   []*utxo.Address{{Address: "hot"}},
+	}
+	foreign := &utxo.TxMessage{
+		Hash:  "foreign",
+		Froms: []*utxo.Address{{Address: "outside"}},
+		Tos:   []*utxo.Address{{Address: "other"}},
+	}
+
+	// 同一笔充值出现在多个地址的查询结果里只保留一次
+	depositTxList := classifyPendingDeposits(addressIndex, "b1", [][]*utxo.TxMessage{
+		{deposit, collection},
+		{foreign, deposit},
+	})
+	require.Equal(t, []*utxo.TxMessage{deposit}, depositTxList)
+
+	require.Empty(t, classifyPendingDeposits(addressIndex, "b2", [][]*utxo.TxMessage{{deposit}}))
+}
+
+func TestMempoolDeposit(t *testing.T) {
+	deposit, childTxs := mempoolDeposit(&utxo.TxMessage{
+		Hash:   "tx",
+		Fee:    "300",
+		Tos:    []*utxo.Address{{Address: "user-a"}, {Address: "user-b"}},
+		Values: []*utxo.Value{{Value: "1000"}, {Value: "2000"}},
+	})
+	require.Equal(t, "tx", deposit.Hash)
+	require.Equal(t, big.NewInt(300), deposit.Fee)
+	require.Equal(t, database.TxStatusUnSafe, deposit.Status)
+	require.Equal(t, 0, deposit.BlockNumber.Sign())
+
+	require.Len(t, childTxs, 2)
+	for i, childTx := range childTxs {
+		require.Equal(t, "tx", childTx.Hash)
+		require.Equal(t, "deposit", childTx.TxType)
+		require.Equal(t, int64(i), childTx.TxIndex.Int64())
+		require.Equal(t, deposit.Timestamp, childTx.Timestamp)
+	}
+	require.Equal(t, "user-a", childTxs[0].ToAddress)
+	require.Equal(t, "1000", childTxs[0].Amount)
+	require.Equal(t, "user-b", childTxs[1].ToAddress)
+	require.Equal(t, "2000", childTxs[1].Amount)
+
+	// 手续费解析失败按 0 处理，缺少金额的输出记为 0
+	deposit, childTxs = mempoolDeposit(&utxo.TxMessage{
+		Hash: "tx",
+		Fee:  "",
+		Tos:  []*utxo.Address{{Address: "user"}},
+	})
+	require.Equal(t, 0, deposit.Fee.Sign())
+	require.Len(t, childTxs, 1)
+	require.Equal(t, "0", childTxs[0].Amount)
+}
+
+func TestChildTxsOfDeposits(t *testing.T) {
+	childTxs := []database.ChildTxs{
+		{Hash: "mempool", TxIndex: big.NewInt(0)},
+		{Hash: "new", TxIndex: big.NewInt(0)},
+		{Hash: "new", TxIndex: big.NewInt(1)},
+	}
+	// 内存池阶段已入库的充值不再重复写 child_txs
+	require.Equal(t, childTxs[1:], childTxsOfDeposits(childTxs, []database.Deposits{{Hash: "new"}}))
+	require.Empty(t, childTxsOfDeposits(childTxs, nil))
+}
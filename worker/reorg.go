@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/dapplink-labs/multichain-sync-btc/common/bigint"
+	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
+)
+
+// maxReorgDepth 回溯查找公共祖先区块的最大深度，超过这个深度认为链状态异常，需要人工介入
+const maxReorgDepth = 1000
+
+var ErrReorgTooDeep = errors.New("chain reorg deeper than max reorg depth")
+
+// handleReorg 从数据库中最新的区块开始往回走，直到数据库中的区块哈希和链上一致（公共祖先），
+// 把中间被重组掉的区块移到 reorg_blocks 表，并在一个数据库事务里回滚各个业务方受影响的数据，最后从分叉点继续扫块
+func (syncer *BaseSynchronizer) handleReorg() error {
+	latestHeader, err := syncer.database.Blocks.LatestBlocks()
+	if err != nil {
+		log.Error("query latest block fail", "err", err)
+		return err
+	}
+	if latestHeader == nil {
+		log.Warn("no block stored yet, nothing to rollback")
+		return nil
+	}
+
+	chainTip, err := syncer.rpcClient.GetBlockHeader(nil)
+	if err != nil {
+		log.Error("get latest block header from chain fail", "err", err)
+		return err
+	}
+
+	var orphanedBlocks []database.ReorgBlocks
+	ancestor := latestHeader
+	for depth := 0; ; depth++ {
+		if depth >= maxReorgDepth {
+			return ErrReorgTooDeep
+		}
+		// 重组后的链可能比库里的链短，高于链上最新高度的区块在链上查不到，直接当作被重组掉的区块往回走
+		if ancestor.Number.Cmp(chainTip.Number) > 0 {
+			log.Warn("found orphaned block above chain tip", "number", ancestor.Number, "hash", ancestor.Hash, "chainTip", chainTip.Number)
+		} else {
+			chainHeader, err := syncer.rpcClient.GetBlockHeader(ancestor.Number)
+			if err != nil {
+				log.Error("get block header from chain fail", "number", ancestor.Number, "err", err)
+				return err
+			}
+			if chainHeader.Hash == ancestor.Hash {
+				break
+			}
+			log.Warn("found orphaned block", "number", ancestor.Number, "hash", ancestor.Hash, "canonicalHash", chainHeader.Hash)
+		}
+		orphanedBlocks = append(orphanedBlocks, database.ReorgBlocks{
+			Hash:      ancestor.Hash,
+			PrevHash:  ancestor.PrevHash,
+			Number:    ancestor.Number,
+			Timestamp: ancestor.Timestamp,
+		})
+
+		parentNumber := new(big.Int).Sub(ancestor.Number, bigint.One)
+		parent, err := syncer.database.Blocks.QueryBlocksByNumber(parentNumber)
+		if err != nil {
+			log.Error("query parent block fail", "number", parentNumber, "err", err)
+			return err
+		}
+		if parent == nil {
+			// 已经回溯到数据库中最早的区块，直接以链上父区块作为分叉点
+			parent, err = syncer.rpcClient.GetBlockHeader(parentNumber)
+			if err != nil {
+				log.Error("get parent block header from chain fail", "number", parentNumber, "err", err)
+				return err
+			}
+			ancestor = parent
+			break
+		}
+		ancestor = parent
+	}
+
+	if len(orphanedBlocks) == 0 {
+		log.Info("latest stored block is canonical, no rollback needed", "number", latestHeader.Number, "hash", latestHeader.Hash)
+		syncer.blockBatch.Rewind(latestHeader)
+		return nil
+	}
+
+	log.Warn("rollback orphaned blocks", "ancestorNumber", ancestor.Number, "ancestorHash", ancestor.Hash, "orphaned", len(orphanedBlocks))
+	if err := syncer.rollbackBlocks(ancestor, orphanedBlocks); err != nil {
+		return err
+	}
+	syncer.blockBatch.Rewind(ancestor)
+	return nil
+}
+
+func (syncer *BaseSynchronizer) rollbackBlocks(ancestor *syncclient.BlockHeader, orphanedBlocks []database.ReorgBlocks) error {
+	businessList, err := syncer.database.Business.QueryBusinessList()
+	if err != nil {
+		log.Error("query business list fail", "err", err)
+		return err
+	}
+	return syncer.database.Transaction(func(tx *database.DB) error {
+		if err := tx.ReorgBlocks.StoreReorgBlocks(orphanedBlocks); err != nil {
+			log.Error("store reorg blocks fail", "err", err)
+			return err
+		}
+		if err := tx.Blocks.DeleteBlocksAfterNumber(ancestor.Number); err != nil {
+			log.Error("delete orphaned blocks fail", "err", err)
+			return err
+		}
+		for _, business := range businessList {
+			if err := rollbackBusiness(tx, syncer.chain, business.BusinessUid, ancestor.Number); err != nil {
+				return fmt.Errorf("rollback business %s fail: %w", business.BusinessUid, err)
+			}
+		}
+		return nil
+	})
+}
+
+// rollbackBusiness 回滚单个业务方在分叉点之后扫到的数据：充值、提现和内部交易在同一个事务里转入回滚流程并恢复地址余额，
+// 再恢复被花费的 utxo、删除被重组掉的交易，事务提交后余额不会和库里的交易对不上，不依赖回滚 worker 之后再处理
+func rollbackBusiness(tx *database.DB, chain string, businessId string, ancestorNumber *big.Int) error {
+	orphanedTxList, err := tx.Transactions.QueryTransactionsAfterBlock(businessId, ancestorNumber)
+	if err != nil {
+		log.Error("query orphaned transactions fail", "businessId", businessId, "err", err)
+		return err
+	}
+
+	var orphanedTxHashes []string
+	for _, orphanedTx := range orphanedTxList {
+		orphanedTxHashes = append(orphanedTxHashes, orphanedTx.Hash)
+	}
+
+	// 先把受影响的充值、提现和内部交易转入回滚流程并恢复余额，之后才能删除它们依赖的 vins
+	if err := fallbackOrphanedTxs(tx, chain, businessId); err != nil {
+		return err
+	}
+
+	if err := tx.Utxos.UnSpendUtxosBySpendTxHashes(businessId, orphanedTxHashes); err != nil {
+		log.Error("restore spent utxos fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if err := tx.Utxos.DeleteUtxosByTxIds(businessId, orphanedTxHashes); err != nil {
+		log.Error("delete orphaned utxos fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if err := tx.Vins.UnSpendVinsBySpendTxHashes(businessId, orphanedTxHashes); err != nil {
+		log.Error("restore spent vins fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if err := tx.Vins.DeleteVinsByTxIds(businessId, orphanedTxHashes); err != nil {
+		log.Error("delete orphaned vins fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if err := tx.Vouts.DeleteVoutsByTxIds(businessId, orphanedTxHashes); err != nil {
+		log.Error("delete orphaned vouts fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if err := tx.ChildTxs.DeleteChildTxsByHashes(businessId, orphanedTxHashes); err != nil {
+		log.Error("delete orphaned child txs fail", "businessId", businessId, "err", err)
+		return err
+	}
+	if err := tx.Transactions.DeleteTransactionsAfterBlock(businessId, ancestorNumber); err != nil {
+		log.Error("delete orphaned transactions fail", "businessId", businessId, "err", err)
+		return err
+	}
+	log.Info("rollback business success", "businessId", businessId, "ancestorNumber", ancestorNumber, "orphanedTx", len(orphanedTxHashes))
+	return tx.NotifyApiCacheInvalidate(businessId)
+}
@@ -9,26 +9,31 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/cache"
 	"github.com/dapplink-labs/multichain-sync-btc/common/clock"
+	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
 )
 
 type Vin struct {
 	Address string
-	TxId    string
-	Vout    uint8
+	TxId    string // 被花费输出所在的交易哈希
+	Vout    uint32 // 被花费输出的下标
 	Amount  *big.Int
 }
 
 type Vout struct {
 	Address string
-	TxIndex uint8
+	TxIndex uint32
 	Amount  *big.Int
 }
 
 type Transaction struct {
 	BusinessId  string
+	BlockHash   string
 	BlockNumber *big.Int
 	Hash        string
 	TxFee       string
@@ -45,8 +50,10 @@ type Config struct {
 }
 
 type BaseSynchronizer struct {
-	loopInterval     time.Duration
-	headerBufferSize uint64
+	chain                 string
+	loopInterval          time.Duration
+	headerBufferSize      uint64
+	blockFetchConcurrency int
 
 	businessChannels chan map[string]*TransactionsChannel
 
@@ -54,12 +61,15 @@ type BaseSynchronizer struct {
 	blockBatch *syncclient.BatchBlock
 	database   *database.DB
 
+	addressIndex *cache.AddressIndex
+
 	headers []syncclient.BlockHeader
 	worker  *clock.LoopFn
 }
 
 type TransactionsChannel struct {
 	BlockHeight  uint64
+	ChainTip     uint64
 	ChannelId    string
 	Transactions []*Transaction
 }
@@ -88,7 +98,17 @@ func (syncer *BaseSynchronizer) tick(_ context.Context) {
 		log.Info("retrying previous batch")
 	} else {
 		newHeaders, err := syncer.blockBatch.NextHeaders(syncer.headerBufferSize)
-		if err != nil {
+		// 每次轮询都更新链上最新高度，同步停滞时落后的区块数照样增长
+		if latestHeader := syncer.blockBatch.LatestHeader(); latestHeader != nil {
+			metrics.RecordChainTip(syncer.chain, latestHeader.Number.Uint64())
+		}
+		if errors.Is(err, syncclient.ErrBatchBlockAndProviderMismatchedState) {
+			log.Warn("chain reorg detected while querying headers", "err", err)
+			if err := syncer.handleReorg(); err != nil {
+				log.Error("handle chain reorg fail", "err", err)
+			}
+			return
+		} else if err != nil {
 			log.Error("error querying for headers", "err", err)
 		} else if len(newHeaders) == 0 {
 			log.Warn("no new headers. syncer at head?")
@@ -97,24 +117,138 @@ func (syncer *BaseSynchronizer) tick(_ context.Context) {
 		}
 	}
 	err := syncer.processBatch(syncer.headers)
+	if errors.Is(err, syncclient.ErrBatchBlockAndProviderMismatchedState) {
+		log.Warn("chain reorg detected while processing batch", "err", err)
+		syncer.headers = nil
+		if err := syncer.handleReorg(); err != nil {
+			log.Error("handle chain reorg fail", "err", err)
+		}
+		return
+	}
 	if err == nil {
 		syncer.headers = nil
 	}
 }
 
+func (syncer *BaseSynchronizer) refreshAddressIndex(businessList []database.Business) error {
+	return refreshAddressIndex(syncer.database, syncer.addressIndex, businessList)
+}
+
+// refreshAddressIndex 增量加载各业务方新导出的地址，地址由 rpc 服务写库，可能不在同一个进程里，所以按时间戳从库里补齐
+func refreshAddressIndex(db *database.DB, addressIndex *cache.AddressIndex, businessList []database.Business) error {
+	businessIds := make([]string, 0, len(businessList))
+	for _, business := range businessList {
+		businessIds = append(businessIds, business.BusinessUid)
+	}
+	// rpc 服务可能在另一个进程里注销业务方，不在列表里的业务方从索引中删除
+	addressIndex.Retain(businessIds)
+	for _, business := range businessList {
+		loadedUntil, _ := addressIndex.LoadedUntil(business.BusinessUid)
+		addressList, err := db.Addresses.QueryAddressesAfterTimestamp(business.BusinessUid, loadedUntil)
+		if err != nil {
+			log.Error("query addresses fail", "businessId", business.BusinessUid, "err", err)
+			return err
+		}
+		addressIndex.Add(business.BusinessUid, addressList)
+	}
+	return nil
+}
+
+// classifyTransaction 按输入和输出地址给交易分类，区块交易和内存池交易使用同一套规则，不属于业务方的交易返回 unknown
 // 充值：  from 地址是外部地址；to 地址是系统数据的用户地址
 // 提现：  from 地址热钱包地址；to 地址外部地址
 // 归集：  from 地址是用户钱包地址，to 是热钱包地址
 // 热转冷：from 地址是热钱包地址，to 是冷钱包地址
 // 冷转热  from 地址是冷钱包地址，to 是热钱包地址
+func classifyTransaction(addressIndex *cache.AddressIndex, businessId string, hotWalletAddress string, coldWalletAddress string, vinAddressList []string, toAddressList []string) string {
+	isDeposit, isWithdraw, isCollection, isToCold, isToHot := false, false, false, false, false
+	for _, toAddress := range toAddressList {
+		existToAddress, toAddressType := addressIndex.AddressExist(businessId, toAddress)
+		for _, address := range vinAddressList {
+			existVinAddress, _ := addressIndex.AddressExist(businessId, address)
+			if !existVinAddress && existToAddress && toAddressType == 0 {
+				isDeposit = true
+			}
+			if existToAddress && toAddressType == 1 && existVinAddress {
+				isCollection = true
+			}
+			if hotWalletAddress != "" && address == hotWalletAddress && !existToAddress {
+				isWithdraw = true
+			}
+			if existToAddress && toAddressType == 2 && hotWalletAddress != "" && address == hotWalletAddress {
+				isToCold = true
+			}
+			if coldWalletAddress != "" && address == coldWalletAddress && existToAddress && toAddressType == 1 {
+				isToHot = true
+			}
+		}
+	}
+	txType := "unknown"
+	// 对于一笔交易来说，出金的地址相对于入金的地址来说是 vout; 入金的地址相对于出金地址来说他是 vin
+	if isDeposit { // 充值，to 地址是用户地址代表充值, 通过 txid 和地址来匹配一个 vin
+		txType = "deposit"
+	}
+
+	if isWithdraw { // 提现
+		txType = "withdraw"
+	}
+
+	if isCollection { // 归集； 1: 代表热钱包地址
+		txType = "collection"
+	}
+
+	if isToCold { // 热转冷；2 是冷钱包地址
+		txType = "hot2cold"
+	}
+
+	if isToHot { // 冷转热；
+		txType = "cold2hot"
+	}
+	return txType
+}
+
 func (syncer *BaseSynchronizer) processBatch(headers []syncclient.BlockHeader) error {
 	if len(headers) == 0 {
 		log.Info("headers is empty, no block waiting to handle")
 		return nil
 	}
 
+	latestHeader, err := syncer.database.Blocks.LatestBlocks()
+	if err != nil {
+		log.Error("query latest block fail", "err", err)
+		return err
+	}
+	if latestHeader != nil && headers[0].PrevHash != latestHeader.Hash {
+		log.Warn("batch does not link to latest stored block", "number", headers[0].Number, "prevHash", headers[0].PrevHash, "latestNumber", latestHeader.Number, "latestHash", latestHeader.Hash)
+		return syncclient.ErrBatchBlockAndProviderMismatchedState
+	}
+
+	// 并发拉取整批区块的交易，结果按高度顺序排列，之后仍按顺序分类并整批写库
+	blockTxList, err := tasks.ParallelMap(len(headers), syncer.blockFetchConcurrency, func(i int) ([]*utxo.TransactionList, error) {
+		txList, err := syncer.rpcClient.GetBlockByNumber(headers[i].Number)
+		if err != nil {
+			log.Error("get block by number fail", "height", headers[i].Number, "err", err)
+			return nil, err
+		}
+		return txList, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	businessList, err := syncer.database.Business.QueryBusinessList()
+	if err != nil {
+		log.Error("query business list fail", "err", err)
+		return err
+	}
+	if err := syncer.refreshAddressIndex(businessList); err != nil {
+		return err
+	}
+
 	businessTxChannel := make(map[string]*TransactionsChannel)
 	blockHeaders := make([]database.Blocks, len(headers))
+	classifiedCount := make(map[string]int)
+	batchTxCount := 0
 
 	for i := range headers {
 		log.Info("Sync block data", "height", headers[i].Number)
@@ -125,20 +259,17 @@ func (syncer *BaseSynchronizer) processBatch(headers []syncclient.BlockHeader) e
 			Timestamp: headers[i].Timestamp,
 		}
 
-		txList, err := syncer.rpcClient.GetBlockByNumber(blockHeaders[i].Number)
-		if err != nil {
-			return err
-		}
-		businessList, err := syncer.database.Business.QueryBusinessList()
-		if err != nil {
-			log.Error("query business list fail", "err", err)
-			return err
-		}
+		txList := blockTxList[i]
+		batchTxCount += len(txList)
 		for _, businessId := range businessList {
+			// 热钱包和冷钱包地址每个批次每个业务方只解析一次
+			hotWalletAddress := syncer.addressIndex.HotWallet(businessId.BusinessUid)
+			coldWalletAddress := syncer.addressIndex.ColdWallet(businessId.BusinessUid)
 			var businessTransactions []*Transaction
 			for _, tx := range txList {
 				txItem := &Transaction{
 					BusinessId:  businessId.BusinessUid,
+					BlockHash:   headers[i].Hash,
 					BlockNumber: headers[i].Number,
 					Hash:        tx.Hash,
 					TxFee:       tx.Fee,
@@ -151,80 +282,27 @@ func (syncer *BaseSynchronizer) processBatch(headers []syncclient.BlockHeader) e
 					toAddressList = append(toAddressList, vout.Address)
 					voutItem := Vout{
 						Address: vout.Address,
-						TxIndex: uint8(vout.Index),
+						TxIndex: vout.Index,
 						Amount:  big.NewInt(int64(vout.Amount)),
 					}
 					voutArray = append(voutArray, voutItem)
 				}
 				txItem.VoutList = voutArray
-				var existToAddress bool
-				var toAddressType uint8
-
-				isDeposit, isWithdraw, isCollection, isToCold, isToHot := false, false, false, false, false
-				for index := range toAddressList {
-					existToAddress, toAddressType = syncer.database.Addresses.AddressExist(businessId.BusinessUid, toAddressList[index])
-					hotWalletAddress, errHot := syncer.database.Addresses.QueryHotWalletInfo(businessId.BusinessUid)
-					if errHot != nil {
-						log.Error("Query hot wallet info", "err", err)
-						return err
-					}
-					coldWalletAddress, errCold := syncer.database.Addresses.QueryColdWalletInfo(businessId.BusinessUid)
-					if errCold != nil {
-						log.Error("query cold wallet info fail", "err", err)
+				var vinAddressList []string
+				for _, txVin := range tx.Vin {
+					vinItem := Vin{
+						Address: txVin.Address,
+						TxId:    txVin.Hash,
+						Vout:    txVin.Index,
+						Amount:  big.NewInt(int64(txVin.Amount)),
 					}
-					for _, txVin := range tx.Vin {
-						vinItem := Vin{
-							Address: txVin.Address,
-							TxId:    tx.Hash,
-							Vout:    uint8(txVin.Index),
-							Amount:  big.NewInt(int64(txVin.Amount)),
-						}
-						vinArray = append(vinArray, vinItem)
-						addressList := strings.Split(txVin.Address, "|")
-						for _, address := range addressList {
-							vinAddress, errQuery := syncer.database.Addresses.QueryAddressesByToAddress(businessId.BusinessUid, address)
-							if errQuery != nil {
-								log.Error("Query address fail", "err", err)
-								return err
-							}
-							if vinAddress == nil && existToAddress && toAddressType == 0 {
-								isDeposit = true
-							}
-							if existToAddress && toAddressType == 1 && vinAddress != nil {
-								isCollection = true
-							}
-							if address == hotWalletAddress.Address && !existToAddress {
-								isWithdraw = true
-							}
-							if existToAddress && toAddressType == 2 && address == hotWalletAddress.Address {
-								isToCold = true
-							}
-							if address == coldWalletAddress.Address && existToAddress && toAddressType == 1 {
-								isToHot = true
-							}
-						}
-					}
-				}
-				// 对于一笔交易来说，出金的地址相对于入金的地址来说是 vout; 入金的地址相对于出金地址来说他是 vin
-				if isDeposit { // 充值，to 地址是用户地址代表充值, 通过 txid 和地址来匹配一个 vin
-					txItem.TxType = "deposit"
-				}
-
-				if isWithdraw { // 提现
-					txItem.TxType = "withdraw"
-				}
-
-				if isCollection { // 归集； 1: 代表热钱包地址
-					txItem.TxType = "collection"
+					vinArray = append(vinArray, vinItem)
+					vinAddressList = append(vinAddressList, strings.Split(txVin.Address, "|")...)
 				}
+				txItem.VinList = vinArray
 
-				if isToCold { // 热转冷；2 是冷钱包地址
-					txItem.TxType = "hot2cold"
-				}
-
-				if isToHot { // 冷转热；
-					txItem.TxType = "cold2hot"
-				}
+				txItem.TxType = classifyTransaction(syncer.addressIndex, businessId.BusinessUid, hotWalletAddress, coldWalletAddress, vinAddressList, toAddressList)
+				classifiedCount[txItem.TxType]++
 				businessTransactions = append(businessTransactions, txItem)
 			}
 			if len(businessTransactions) > 0 {
@@ -240,6 +318,19 @@ func (syncer *BaseSynchronizer) processBatch(headers []syncclient.BlockHeader) e
 			}
 		}
 	}
+	// 每个业务方都带上链上最新高度，没有交易的批次也要推进充值确认
+	chainTip := headers[len(headers)-1].Number.Uint64()
+	if latestHeader := syncer.blockBatch.LatestHeader(); latestHeader != nil {
+		chainTip = latestHeader.Number.Uint64()
+	}
+	for _, businessId := range businessList {
+		if businessTxChannel[businessId.BusinessUid] == nil {
+			businessTxChannel[businessId.BusinessUid] = &TransactionsChannel{
+				BlockHeight: headers[len(headers)-1].Number.Uint64(),
+			}
+		}
+		businessTxChannel[businessId.BusinessUid].ChainTip = chainTip
+	}
 	if len(businessTxChannel) >= 0 {
 		syncer.businessChannels <- businessTxChannel
 	}
@@ -249,5 +340,10 @@ func (syncer *BaseSynchronizer) processBatch(headers []syncclient.BlockHeader) e
 			return err
 		}
 	}
+	metrics.RecordSyncHeight(syncer.chain, headers[len(headers)-1].Number.Uint64())
+	metrics.RecordSyncBatch(syncer.chain, len(headers), batchTxCount)
+	for txType, count := range classifiedCount {
+		metrics.RecordClassified(syncer.chain, txType, count)
+	}
 	return nil
 }
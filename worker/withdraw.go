@@ -9,25 +9,31 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/dapplink-labs/multichain-sync-btc/common/rawtx"
 	"github.com/dapplink-labs/multichain-sync-btc/common/retry"
 	"github.com/dapplink-labs/multichain-sync-btc/common/tasks"
 	"github.com/dapplink-labs/multichain-sync-btc/config"
 	"github.com/dapplink-labs/multichain-sync-btc/database"
+	"github.com/dapplink-labs/multichain-sync-btc/metrics"
 	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient"
+	"github.com/dapplink-labs/multichain-sync-btc/rpcclient/syncclient/utxo"
 )
 
 type Withdraw struct {
+	chain          string
 	rpcClient      *syncclient.WalletBtcAccountClient
 	db             *database.DB
 	resourceCtx    context.Context
 	resourceCancel context.CancelFunc
 	tasks          tasks.Group
 	ticker         *time.Ticker
+	reservationTtl time.Duration
 }
 
 func NewWithdraw(cfg *config.Config, db *database.DB, rpcClient *syncclient.WalletBtcAccountClient, shutdown context.CancelCauseFunc) (*Withdraw, error) {
 	resCtx, resCancel := context.WithCancel(context.Background())
 	return &Withdraw{
+		chain:          cfg.Chain.Key,
 		rpcClient:      rpcClient,
 		db:             db,
 		resourceCtx:    resCtx,
@@ -35,7 +41,8 @@ func NewWithdraw(cfg *config.Config, db *database.DB, rpcClient *syncclient.Wall
 		tasks: tasks.Group{HandleCrit: func(err error) {
 			shutdown(fmt.Errorf("critical error in withdraw: %w", err))
 		}},
-		ticker: time.NewTicker(cfg.ChainNode.WorkerInterval),
+		ticker:         time.NewTicker(cfg.ChainNode.WorkerInterval),
+		reservationTtl: cfg.ChainNode.ReservationTtl,
 	}, nil
 }
 
@@ -58,73 +65,53 @@ func (w *Withdraw) Start() error {
 		for {
 			select {
 			case <-w.ticker.C:
-				businessList, err := w.db.Business.QueryBusinessList()
+				businessList, err := w.db.Business.QueryActiveBusinessList()
 				if err != nil {
 					log.Error("query business list fail", "err", err)
 					continue
 				}
+				queueDepth := 0
 				for _, businessId := range businessList {
+					sentHeight := w.latestSentHeight()
+					if err := w.failExpiredWithdraws(businessId.BusinessUid, sentHeight); err != nil {
+						log.Error("fail expired withdraws fail", "businessId", businessId.BusinessUid, "err", err)
+					}
 					unSendTransactionList, err := w.db.Withdraws.UnSendWithdrawsList(businessId.BusinessUid)
 					if err != nil {
 						log.Error("Query un send withdraws list fail", "err", err)
 						continue
 					}
+					queueDepth += len(unSendTransactionList)
 					if len(unSendTransactionList) == 0 {
 						log.Error("Withdraw Start", "businessId", businessId, "unSendTransactionList", "is null")
 						continue
 					}
-					var balanceList []database.Balances
+					var (
+						balanceList []database.Balances
+						sentList    []database.Withdraws
+					)
 					for _, unSendTransaction := range unSendTransactionList {
-						childTxList, err := w.db.ChildTxs.QueryChildTxnByTxId(businessId.BusinessUid, unSendTransaction.Guid.String())
-						if err != nil {
-							log.Error("query child tx fail", "err", err)
-							return err
-						}
-						for _, childTx := range childTxList {
-							lockBalance, _ := new(big.Int).SetString(childTx.Amount, 10)
-							balanceItem := database.Balances{
-								Address:     childTx.FromAddress,
-								LockBalance: lockBalance,
-							}
-							balanceList = append(balanceList, balanceItem)
-						}
 						txHash, err := w.rpcClient.SendTx(unSendTransaction.TxSignHex)
 						if err != nil {
 							log.Error("send transaction fail", "err", err)
 							continue
-						} else {
-							unSendTransaction.Hash = txHash
-							unSendTransaction.Status = database.TxStatusSent
 						}
-					}
-					retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
-					if _, err := retry.Do[interface{}](w.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
-						if err := w.db.Transaction(func(tx *database.DB) error {
-							if len(balanceList) > 0 {
-								log.Info("Update address balance", "totalTx", len(balanceList))
-								if err := tx.Balances.UpdateBalances(businessId.BusinessUid, balanceList); err != nil {
-									log.Error("Update address balance fail", "err", err)
-									return err
-								}
-
-							}
-							if len(unSendTransactionList) > 0 {
-								err = w.db.Withdraws.UpdateWithdrawStatus(businessId.BusinessUid, database.TxStatusSent, unSendTransactionList)
-								if err != nil {
-									log.Error("update withdraw status fail", "err", err)
-									return err
-								}
-							}
-							return nil
-						}); err != nil {
-							log.Error("unable to persist batch", "err", err)
-							return nil, err
+						// 只有广播成功的提现才扣锁定余额，发送失败的下一轮重发时再扣
+						lockBalances, err := w.lockBalances(businessId.BusinessUid, unSendTransaction)
+						if err != nil {
+							return err
 						}
-						return nil, nil
-					}); err != nil {
+						balanceList = append(balanceList, lockBalances...)
+						unSendTransaction.Hash = txHash
+						unSendTransaction.SentHeight = sentHeight
+						unSendTransaction.Status = database.TxStatusSent
+						sentList = append(sentList, unSendTransaction)
+					}
+					if err := w.persistSentWithdraws(businessId.BusinessUid, balanceList, sentList); err != nil {
 						return err
 					}
 				}
+				metrics.RecordWithdrawQueue(w.chain, queueDepth)
 			case <-w.resourceCtx.Done():
 				log.Info("stop withdraw in worker")
 				return nil
@@ -133,3 +120,138 @@ func (w *Withdraw) Start() error {
 	})
 	return nil
 }
+
+// latestSentHeight 广播时数据库中的最新高度，手续费加速按这个高度判断交易是否卡住
+func (w *Withdraw) latestSentHeight() *big.Int {
+	latestBlock, err := w.db.Blocks.LatestBlocks()
+	if err != nil {
+		log.Error("query latest block fail", "err", err)
+		return big.NewInt(0)
+	}
+	if latestBlock == nil {
+		return big.NewInt(0)
+	}
+	return latestBlock.Number
+}
+
+// lockBalances 提现广播后从花费地址扣掉的锁定余额
+func (w *Withdraw) lockBalances(businessId string, withdraw database.Withdraws) ([]database.Balances, error) {
+	childTxList, err := w.db.ChildTxs.QueryChildTxnByTxId(businessId, withdraw.Guid.String())
+	if err != nil {
+		log.Error("query child tx fail", "err", err)
+		return nil, err
+	}
+	var balanceList []database.Balances
+	for _, childTx := range childTxList {
+		lockBalance, _ := new(big.Int).SetString(childTx.Amount, 10)
+		balanceList = append(balanceList, database.Balances{
+			Address:     childTx.FromAddress,
+			LockBalance: lockBalance,
+		})
+	}
+	return balanceList, nil
+}
+
+func (w *Withdraw) persistSentWithdraws(businessId string, balanceList []database.Balances, sentList []database.Withdraws) error {
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	_, err := retry.Do[interface{}](w.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
+		if err := w.db.Transaction(func(tx *database.DB) error {
+			if len(balanceList) > 0 {
+				log.Info("Update address balance", "totalTx", len(balanceList))
+				if err := tx.Balances.UpdateBalances(businessId, balanceList); err != nil {
+					log.Error("Update address balance fail", "err", err)
+					return err
+				}
+
+			}
+			if len(sentList) > 0 {
+				if err := tx.Withdraws.UpdateSentWithdraws(businessId, sentList); err != nil {
+					log.Error("update withdraw status fail", "err", err)
+					return err
+				}
+				if err := tx.NotifyApiCacheInvalidate(businessId, database.ApiCacheWithdraws); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			log.Error("unable to persist batch", "err", err)
+			metrics.RecordDbRetry(w.chain, "withdraw")
+			return nil, err
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// failExpiredWithdraws 超过占用时限仍未广播的提现（业务方放弃签名，或者签名后一直发送失败）标记为 done_fail 并释放 utxo，
+// 避免 utxo 被永久占用。已签名的提现可能已经广播成功、只是状态没有写库，按签名交易的哈希查链上和内存池，
+// 查得到的按已发送处理，查询失败的留到下一轮，不能释放可能已经被花费的 utxo
+func (w *Withdraw) failExpiredWithdraws(businessId string, sentHeight *big.Int) error {
+	before := uint64(time.Now().Add(-w.reservationTtl).Unix())
+	expiredList, err := w.db.Withdraws.QueryExpiredWithdraws(businessId, before)
+	if err != nil {
+		return err
+	}
+	var (
+		failList    []database.Withdraws
+		sentList    []database.Withdraws
+		balanceList []database.Balances
+	)
+	for _, withdraw := range expiredList {
+		if withdraw.Status != database.TxStatusUnSent {
+			failList = append(failList, withdraw)
+			continue
+		}
+		broadcast, txHash, err := w.broadcasted(withdraw)
+		if err != nil {
+			log.Warn("check expired withdraw broadcast fail", "businessId", businessId, "guid", withdraw.Guid, "err", err)
+			continue
+		}
+		if !broadcast {
+			failList = append(failList, withdraw)
+			continue
+		}
+		lockBalances, err := w.lockBalances(businessId, withdraw)
+		if err != nil {
+			return err
+		}
+		balanceList = append(balanceList, lockBalances...)
+		withdraw.Hash = txHash
+		withdraw.SentHeight = sentHeight
+		withdraw.Status = database.TxStatusSent
+		sentList = append(sentList, withdraw)
+	}
+	if len(sentList) > 0 {
+		if err := w.persistSentWithdraws(businessId, balanceList, sentList); err != nil {
+			return err
+		}
+		log.Warn("expired withdraws already broadcast, marked as sent", "businessId", businessId, "count", len(sentList))
+	}
+	if len(failList) == 0 {
+		return nil
+	}
+	if err := w.db.FailWithdraws(businessId, failList); err != nil {
+		return err
+	}
+	log.Warn("expired withdraws failed and utxos released", "businessId", businessId, "count", len(failList))
+	return nil
+}
+
+// broadcasted 按签名交易计算的哈希查询交易是否已经在链上或内存池中
+func (w *Withdraw) broadcasted(withdraw database.Withdraws) (bool, string, error) {
+	txHash, err := rawtx.TxHash(withdraw.TxSignHex)
+	if err != nil {
+		return false, "", err
+	}
+	tx, err := w.rpcClient.GetTransactionByHash(txHash)
+	if errors.Is(err, syncclient.ErrTransactionNotFound) {
+		return false, txHash, nil
+	} else if err != nil {
+		return false, txHash, err
+	}
+	if tx.Status == utxo.TxStatus_NotFound || tx.Status == utxo.TxStatus_Failed {
+		return false, txHash, nil
+	}
+	return true, txHash, nil
+}